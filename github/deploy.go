@@ -113,9 +113,6 @@ var (
 
 	//go:embed deploy/.github/workflows/gov4git_cron.yml
 	cronYML string
-
-	//go:embed deploy/.github/python/requirements.txt
-	pythonRequirementsTXT string
 )
 
 func installGithubActions(
@@ -129,7 +126,6 @@ func installGithubActions(
 	// populate helper files for github actions
 	git.StringToFileStage(ctx, t, ns.NS{".github", "scripts", "gov4git_cron.sh"}, cronSH)
 	git.StringToFileStage(ctx, t, ns.NS{".github", "workflows", "gov4git_cron.yml"}, cronYML)
-	git.StringToFileStage(ctx, t, ns.NS{".github", "python", "requirements.txt"}, pythonRequirementsTXT)
 
 	git.Commit(ctx, t, "install gov4git github actions")
 	govCloned.Push(ctx)
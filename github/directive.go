@@ -9,10 +9,12 @@ import (
 	"github.com/google/go-github/v58/github"
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/etc"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_0"
+	"github.com/gov4git/gov4git/v2/proto/multisig"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
@@ -36,6 +38,18 @@ type DirectiveIssue struct {
 	GiveToMatchingFund    *GiveToMatchingFund             `json:"fund_matching_pool,omitempty"`
 }
 
+// Capability returns the capability required to apply the directive, if any.
+// Giving to the matching fund moves the author's own credits and requires no capability.
+func (x DirectiveIssue) Capability() (member.Capability, bool) {
+	switch {
+	case x.IssueVotingCredits != nil, x.TransferVotingCredits != nil:
+		return member.CapIssueCredits, true
+	case x.Freeze != nil, x.Unfreeze != nil:
+		return member.CapFreezeMotions, true
+	}
+	return "", false
+}
+
 type IssueVotingCreditsDirective struct {
 	Amount float64 `json:"amount"`
 	To     string  `json:"to_user"`
@@ -172,6 +186,22 @@ func processDirectiveIssue_StageOnly(
 		return DirectiveIssue{}, err
 	}
 
+	// the directive author must have the capability the directive requires, if the capability is restricted
+	if cap, ok := d.Capability(); ok {
+		if err := member.CheckCapability_Local(ctx, cloned.PublicClone(), member.User(login), cap); err != nil {
+			base.Infof("directive author %q is not permitted (%v)", login, err)
+			replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject,
+				fmt.Sprintf("Directive author @%s does not have the `%v` capability.", login, cap))
+			return DirectiveIssue{}, err
+		}
+	}
+
+	// directives under multi-signature control are recorded as proposals, applied once approved;
+	// gifts to the matching fund are made by members from their own credits, so they are not organizer actions
+	if d.GiveToMatchingFund == nil && etc.GetSettings_StageOnly(ctx, cloned.PublicClone()).RequiresMultisig(etc.OpGithubDirective) {
+		return proposeDirective_StageOnly(ctx, repo, ghc, cloned, issue, d)
+	}
+
 	switch {
 
 	case d.IssueVotingCredits != nil:
@@ -205,7 +235,7 @@ func processDirectiveIssue_StageOnly(
 	case d.TransferVotingCredits != nil:
 		err = must.Try(
 			func() {
-				account.Transfer_StageOnly(
+				account.Spend_StageOnly(
 					ctx,
 					cloned.PublicClone(),
 					member.UserAccountID(member.User(d.TransferVotingCredits.From)),
@@ -274,7 +304,7 @@ func processDirectiveIssue_StageOnly(
 	case d.GiveToMatchingFund != nil:
 		err = must.Try(
 			func() {
-				account.Transfer_StageOnly(
+				account.Spend_StageOnly(
 					ctx,
 					cloned.PublicClone(),
 					member.UserAccountID(member.User(d.GiveToMatchingFund.From)),
@@ -304,6 +334,59 @@ func processDirectiveIssue_StageOnly(
 	panic("unknown directive")
 }
 
+func proposeDirective_StageOnly(
+	ctx context.Context,
+	repo Repo,
+	ghc *github.Client,
+	cloned gov.OwnerCloned,
+	issue *github.Issue,
+	d DirectiveIssue,
+
+) (DirectiveIssue, error) {
+
+	note := fmt.Sprintf("directive from GitHub issue #%v", issue.GetNumber())
+	var action multisig.Action
+	switch {
+	case d.IssueVotingCredits != nil:
+		action.Issue = &multisig.IssueAction{
+			To:     member.UserAccountID(member.User(d.IssueVotingCredits.To)),
+			Amount: account.H(account.PluralAsset, d.IssueVotingCredits.Amount),
+			Note:   note,
+		}
+	case d.TransferVotingCredits != nil:
+		action.Transfer = &multisig.TransferAction{
+			From:   member.UserAccountID(member.User(d.TransferVotingCredits.From)),
+			To:     member.UserAccountID(member.User(d.TransferVotingCredits.To)),
+			Amount: account.H(account.PluralAsset, d.TransferVotingCredits.Amount),
+			Note:   note,
+		}
+	case d.Freeze != nil:
+		action.FreezeMotion = &multisig.MotionAction{Motion: IssueNumberToMotionID(d.Freeze.IssueNumber)}
+	case d.Unfreeze != nil:
+		action.UnfreezeMotion = &multisig.MotionAction{Motion: IssueNumberToMotionID(d.Unfreeze.IssueNumber)}
+	default:
+		panic("unknown directive")
+	}
+
+	var p multisig.Proposal
+	err := must.Try(
+		func() {
+			p = multisig.Propose_StageOnly(ctx, cloned.PublicClone(), etc.OpGithubDirective, action, note).Result
+		},
+	)
+	if err != nil {
+		base.Infof("could not propose directive (%v)", err)
+		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject,
+			fmt.Sprintf("Could not record the directive for approval. Reopen the issue to retry.\n\nBecause: `%v`", err))
+		return DirectiveIssue{}, err
+	}
+	replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject,
+		fmt.Sprintf("This directive requires approval by %d of the designated organizers. "+
+			"It was recorded as proposal `%v` and will be applied once approved with `gov4git multisig approve --id=%v`.",
+			p.Threshold, p.ID, p.ID))
+	return d, nil
+}
+
 // example directives:
 //
 //	"issue 30 credits to @user"
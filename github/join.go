@@ -162,8 +162,9 @@ func processJoinRequestIssue_StageOnly(
 	}
 
 	// fetch comments and find a join approval
+	// only approvers with the approve_joins capability count, if the capability is restricted
 	comments := fetchIssueComments(ctx, repo, ghc, issue)
-	if !isJoinApprovalPresent(ctx, approverGitHubUsers, comments) {
+	if !isJoinApprovalPresent(ctx, capableApprovers_Local(ctx, govCloned.PublicClone(), approverGitHubUsers), comments) {
 		return ""
 	}
 
@@ -183,6 +184,16 @@ func processJoinRequestIssue_StageOnly(
 	return login
 }
 
+func capableApprovers_Local(ctx context.Context, cloned gov.Cloned, approvers []string) []string {
+	capable := []string{}
+	for _, a := range approvers {
+		if member.CheckCapability_Local(ctx, cloned, member.User(a), member.CapApproveJoins) == nil {
+			capable = append(capable, a)
+		}
+	}
+	return capable
+}
+
 type JoinRequest struct {
 	User         string     `json:"github_user"`
 	PublicRepo   Repo       `json:"public_repo"`
@@ -14,6 +14,7 @@ require (
 	golang.org/x/crypto v0.21.0
 	golang.org/x/net v0.22.0
 	golang.org/x/oauth2 v0.11.0
+	golang.org/x/term v0.18.0
 )
 
 require (
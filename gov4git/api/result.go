@@ -115,6 +115,20 @@ func Invoke1[R1 any](f func() R1) Result {
 	return r
 }
 
+// InvokeStream runs f, which writes its output to stdout directly.
+// Unlike Invoke, the result is only reported on error, and then on stderr.
+func InvokeStream(f func()) {
+	xerr := must.TryThru(f)
+	if xerr == nil {
+		return
+	}
+	if base.IsVerbose() {
+		fmt.Fprint(os.Stderr, string(xerr.Stack))
+	}
+	fmt.Fprint(os.Stderr, form.SprintJSON(NewResult(nil, xerr)))
+	os.Exit(1)
+}
+
 func NewResult(r any, err *must.Error) Result {
 	var result Result
 	if err == nil {
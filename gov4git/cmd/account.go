@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/gov4git/gov4git/v2/gov4git/api"
 	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/reconcile"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +26,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapIssueCredits)
 					account.Issue(
 						ctx,
 						setup.Gov,
@@ -68,6 +73,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapIssueCredits)
 					account.Transfer(
 						ctx,
 						setup.Gov,
@@ -85,9 +91,10 @@ var (
 	}
 
 	accountListCmd = &cobra.Command{
-		Use:   "list",
-		Short: "List accounts",
-		Long:  ``,
+		Use:         "list",
+		Short:       "List accounts",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -102,9 +109,10 @@ var (
 	}
 
 	accountShowCmd = &cobra.Command{
-		Use:   "show",
-		Short: "Show account",
-		Long:  ``,
+		Use:         "show",
+		Short:       "Show account",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -119,10 +127,117 @@ var (
 		},
 	}
 
-	accountBalanceCmd = &cobra.Command{
-		Use:   "balance",
-		Short: "Show account balance for a given asset",
+	accountFreezeCmd = &cobra.Command{
+		Use:   "freeze",
+		Short: "Freeze account, blocking outgoing transfers and new votes",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					account.Freeze(
+						ctx,
+						setup.Gov,
+						account.AccountID(accountID),
+						accountNote,
+					)
+				},
+			)
+		},
+	}
+
+	accountUnfreezeCmd = &cobra.Command{
+		Use:   "unfreeze",
+		Short: "Unfreeze account",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					account.Unfreeze(
+						ctx,
+						setup.Gov,
+						account.AccountID(accountID),
+						accountNote,
+					)
+				},
+			)
+		},
+	}
+
+	accountLimitCmd = &cobra.Command{
+		Use:   "limit",
+		Short: "Limit the quantity of an asset that can be spent from an account per period",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					account.SetSpendingLimit(
+						ctx,
+						setup.Gov,
+						account.AccountID(accountID),
+						account.H(
+							account.Asset(accountAsset),
+							accountQuantity,
+						),
+						accountPeriod,
+						accountNote,
+					)
+				},
+			)
+		},
+	}
+
+	accountUnlimitCmd = &cobra.Command{
+		Use:   "unlimit",
+		Short: "Remove the spending limit of an asset from an account",
 		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					account.RemoveSpendingLimit(
+						ctx,
+						setup.Gov,
+						account.AccountID(accountID),
+						account.Asset(accountAsset),
+						accountNote,
+					)
+				},
+			)
+		},
+	}
+
+	accountReconcileCmd = &cobra.Command{
+		Use:   "reconcile",
+		Short: "Find and sweep accounts whose owning ballot or motion no longer exists or is closed",
+		Long: `
+Reconcile reports all ballot escrow and motion accounts with non-zero balances,
+whose ballot or motion has been erased, closed, cancelled or archived.
+With --apply, their balances are swept to the treasury.
+With --apply and --refund, ballot escrow balances are refunded to voters in proportion to their recorded charges.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					chg := reconcile.Reconcile(
+						ctx,
+						setup.Gov,
+						accountApply,
+						accountRefund,
+					)
+					return chg.Result
+				},
+			)
+		},
+	}
+
+	accountBalanceCmd = &cobra.Command{
+		Use:         "balance",
+		Short:       "Show account balance for a given asset",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -146,6 +261,9 @@ var (
 	accountAsset    string
 	accountQuantity float64
 	accountNote     string
+	accountPeriod   time.Duration
+	accountApply    bool
+	accountRefund   bool
 )
 
 func init() {
@@ -190,4 +308,35 @@ func init() {
 	accountBalanceCmd.MarkFlagRequired("id")
 	accountBalanceCmd.Flags().StringVarP(&accountAsset, "asset", "a", "", "asset")
 	accountBalanceCmd.MarkFlagRequired("asset")
+	// freeze
+	accountCmd.AddCommand(accountFreezeCmd)
+	accountFreezeCmd.Flags().StringVar(&accountID, "id", "", "account id")
+	accountFreezeCmd.MarkFlagRequired("id")
+	accountFreezeCmd.Flags().StringVarP(&accountNote, "Note", "n", "manual", "note")
+	// unfreeze
+	accountCmd.AddCommand(accountUnfreezeCmd)
+	accountUnfreezeCmd.Flags().StringVar(&accountID, "id", "", "account id")
+	accountUnfreezeCmd.MarkFlagRequired("id")
+	accountUnfreezeCmd.Flags().StringVarP(&accountNote, "Note", "n", "manual", "note")
+	// limit
+	accountCmd.AddCommand(accountLimitCmd)
+	accountLimitCmd.Flags().StringVar(&accountID, "id", "", "account id")
+	accountLimitCmd.MarkFlagRequired("id")
+	accountLimitCmd.Flags().StringVarP(&accountAsset, "asset", "a", "", "asset")
+	accountLimitCmd.MarkFlagRequired("asset")
+	accountLimitCmd.Flags().Float64VarP(&accountQuantity, "quantity", "q", 0.0, "maximum quantity per period")
+	accountLimitCmd.MarkFlagRequired("quantity")
+	accountLimitCmd.Flags().DurationVarP(&accountPeriod, "period", "p", 24*time.Hour, "spending period")
+	accountLimitCmd.Flags().StringVarP(&accountNote, "Note", "n", "manual", "note")
+	// unlimit
+	accountCmd.AddCommand(accountUnlimitCmd)
+	accountUnlimitCmd.Flags().StringVar(&accountID, "id", "", "account id")
+	accountUnlimitCmd.MarkFlagRequired("id")
+	accountUnlimitCmd.Flags().StringVarP(&accountAsset, "asset", "a", "", "asset")
+	accountUnlimitCmd.MarkFlagRequired("asset")
+	accountUnlimitCmd.Flags().StringVarP(&accountNote, "Note", "n", "manual", "note")
+	// reconcile
+	accountCmd.AddCommand(accountReconcileCmd)
+	accountReconcileCmd.Flags().BoolVar(&accountApply, "apply", false, "sweep orphaned balances")
+	accountReconcileCmd.Flags().BoolVar(&accountRefund, "refund", false, "refund voters according to recorded charges, instead of sweeping to the treasury")
 }
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/alert"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alertCmd = &cobra.Command{
+		Use:   "alert",
+		Short: "Check for and list alerts on anomalous voting and treasury activity",
+		Long: `
+Alerts are raised by rules whose thresholds are set in the alert policy of the system settings.
+Cron checks the activity since its previous check on every run.`,
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+
+	alertCheckCmd = &cobra.Command{
+		Use:   "check",
+		Short: "Check the activity since the last check and raise alerts",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return alert.Check(ctx, setup.Gov).Result
+				},
+			)
+		},
+	}
+
+	alertListCmd = &cobra.Command{
+		Use:         "list",
+		Short:       "List alerts",
+		Long:        ``,
+		Annotations: readOnly,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					filter := journal.All
+					if alertListSince != "" {
+						filter.Since, _ = parseHistoryTime(alertListSince)
+					}
+					return alert.List(ctx, setup.Gov, filter)
+				},
+			)
+		},
+	}
+)
+
+var (
+	alertListSince string
+)
+
+func init() {
+	alertCmd.AddCommand(alertCheckCmd)
+	alertCmd.AddCommand(alertListCmd)
+	alertListCmd.Flags().StringVar(&alertListSince, "since", "", "earliest time to list (RFC 3339 or YYYY-MM-DD)")
+}
@@ -30,6 +30,7 @@ var (
 			api.Invoke1(
 				func() any {
 					LoadConfig()
+					requireCapability(member.CapOpenBallots)
 					chg := ballotapi.Open(
 						ctx,
 						ballotio.QVPolicyName,
@@ -57,6 +58,7 @@ var (
 			api.Invoke1(
 				func() any {
 					LoadConfig()
+					requireCapability(member.CapFreezeMotions)
 					chg := ballotapi.Freeze(
 						ctx,
 						setup.Organizer,
@@ -76,6 +78,7 @@ var (
 			api.Invoke1(
 				func() any {
 					LoadConfig()
+					requireCapability(member.CapFreezeMotions)
 					chg := ballotapi.Unfreeze(
 						ctx,
 						setup.Organizer,
@@ -127,9 +130,10 @@ var (
 	}
 
 	ballotShowCmd = &cobra.Command{
-		Use:   "show",
-		Short: "Show ballot details",
-		Long:  ``,
+		Use:         "show",
+		Short:       "Show ballot details",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -146,9 +150,10 @@ var (
 	}
 
 	ballotListCmd = &cobra.Command{
-		Use:   "list",
-		Short: "List ballots",
-		Long:  ``,
+		Use:         "list",
+		Short:       "List ballots",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
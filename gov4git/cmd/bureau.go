@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
+
 	"github.com/gov4git/gov4git/v2/gov4git/api"
 	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/git"
 	"github.com/spf13/cobra"
 )
 
@@ -20,14 +24,15 @@ var (
 		Short: "Fetch and process requests from community members",
 		Long:  ``,
 		Run: func(cmd *cobra.Command, args []string) {
-			api.Invoke(
-				func() {
+			api.Invoke1(
+				func() any {
 					LoadConfig()
-					bureau.Process(
+					chg, _ := bureau.Process(
 						ctx,
 						setup.Organizer,
 						member.Group(bureauGroup),
 					)
+					return chg.Result
 				},
 			)
 		},
@@ -53,13 +58,127 @@ var (
 			)
 		},
 	}
+
+	bureauStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of your requests to the community governance",
+		Long: `
+Answered requests have the status ok or error, with an error code and message.
+Requests answered by earlier versions have the status legacy, since their outcome was not recorded.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return bureau.Status(
+						ctx,
+						setup.Member.Public,
+						setup.Gov,
+					)
+				},
+			)
+		},
+	}
+
+	bureauJoinCmd = &cobra.Command{
+		Use:   "join",
+		Short: "Request to join an open group",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					bureau.JoinGroup(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						member.Group(bureauGroup),
+					)
+				},
+			)
+		},
+	}
+
+	bureauLeaveCmd = &cobra.Command{
+		Use:   "leave",
+		Short: "Request to leave an open group",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					bureau.LeaveGroup(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						member.Group(bureauGroup),
+					)
+				},
+			)
+		},
+	}
+
+	bureauRotateAddressCmd = &cobra.Command{
+		Use:   "rotate-address",
+		Short: "Request to move your community membership to a new public repo",
+		Long: `
+The request is sent from, and signed by, your current identity.
+It is also signed by the identity in your new repos, to prove you own them.
+The new repos must already be initialized with credentials.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					bureau.RotateAddress(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						id.OwnerAddress{
+							Public:  id.PublicAddress{Repo: git.URL(bureauNewRepo), Branch: git.Branch(bureauNewBranch)},
+							Private: id.PrivateAddress{Repo: git.URL(bureauNewPrivateRepo), Branch: git.Branch(bureauNewPrivateBranch)},
+						},
+					)
+				},
+			)
+		},
+	}
+
+	bureauSetPropCmd = &cobra.Command{
+		Use:   "set-prop",
+		Short: "Request to set a member-editable property of your user profile",
+		Long:  `The value is parsed as JSON, if possible, and otherwise used as a string.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					var value any
+					if err := json.Unmarshal([]byte(bureauValue), &value); err != nil {
+						value = bureauValue
+					}
+					bureau.SetProp(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						bureauKey,
+						value,
+					)
+				},
+			)
+		},
+	}
 )
 
 var (
-	bureauGroup    string
-	bureauFromUser string
-	bureauToUser   string
-	bureauAmount   float64
+	bureauGroup     string
+	bureauFromUser  string
+	bureauToUser    string
+	bureauAmount    float64
+	bureauNewRepo   string
+	bureauNewBranch string
+	bureauKey       string
+	bureauValue     string
+
+	bureauNewPrivateRepo   string
+	bureauNewPrivateBranch string
 )
 
 func init() {
@@ -72,4 +191,30 @@ func init() {
 	bureauTransferCmd.Flags().StringVar(&bureauToUser, "to", "", "transfer to user")
 	bureauTransferCmd.Flags().Float64Var(&bureauAmount, "amount", 0, "transfer amount")
 	bureauTransferCmd.MarkFlagRequired("amount")
+
+	bureauCmd.AddCommand(bureauStatusCmd)
+
+	bureauCmd.AddCommand(bureauJoinCmd)
+	bureauJoinCmd.Flags().StringVar(&bureauGroup, "group", "", "open group to join")
+	bureauJoinCmd.MarkFlagRequired("group")
+
+	bureauCmd.AddCommand(bureauLeaveCmd)
+	bureauLeaveCmd.Flags().StringVar(&bureauGroup, "group", "", "open group to leave")
+	bureauLeaveCmd.MarkFlagRequired("group")
+
+	bureauCmd.AddCommand(bureauRotateAddressCmd)
+	bureauRotateAddressCmd.Flags().StringVar(&bureauNewRepo, "repo", "", "URL of your new public repo")
+	bureauRotateAddressCmd.MarkFlagRequired("repo")
+	bureauRotateAddressCmd.Flags().StringVar(&bureauNewBranch, "branch", "", "branch in your new public repo")
+	bureauRotateAddressCmd.MarkFlagRequired("branch")
+	bureauRotateAddressCmd.Flags().StringVar(&bureauNewPrivateRepo, "private-repo", "", "URL of your new private repo")
+	bureauRotateAddressCmd.MarkFlagRequired("private-repo")
+	bureauRotateAddressCmd.Flags().StringVar(&bureauNewPrivateBranch, "private-branch", "", "branch in your new private repo")
+	bureauRotateAddressCmd.MarkFlagRequired("private-branch")
+
+	bureauCmd.AddCommand(bureauSetPropCmd)
+	bureauSetPropCmd.Flags().StringVar(&bureauKey, "key", "", "property key")
+	bureauSetPropCmd.MarkFlagRequired("key")
+	bureauSetPropCmd.Flags().StringVar(&bureauValue, "value", "", "property value")
+	bureauSetPropCmd.MarkFlagRequired("value")
 }
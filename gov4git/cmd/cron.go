@@ -7,6 +7,7 @@ import (
 	govgh "github.com/gov4git/gov4git/v2/github"
 	"github.com/gov4git/gov4git/v2/gov4git/api"
 	"github.com/gov4git/gov4git/v2/proto/cron"
+	"github.com/gov4git/lib4git/must"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +19,8 @@ var (
 This command is intended as a target for a cronjob which runs every couple of minutes.
 It will ensure that:
 - Governance is synchronized with the issues and pull requests of a GitHub project at a configurable frequency, and
-- Votes from community members are incorporated in governance ballots at a configurable frequency.
+- Votes from community members are incorporated in governance ballots at a configurable frequency, and
+- The community dashboard is published to a GitHub issue, a static site on a git branch, or both (see --dashboard).
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
@@ -27,6 +29,19 @@ It will ensure that:
 					repo := govgh.ParseRepo(ctx, githubProject)
 					govgh.SetTokenSource(ctx, repo, govgh.MakeStaticTokenSource(ctx, githubToken))
 					ghc := govgh.GetGithubClient(ctx, repo)
+					dashboard := cron.DashboardOptions{}
+					switch cronDashboard {
+					case "github":
+						dashboard.GithubIssue = true
+					case "site":
+						site := dashboardSiteAddress(cronSiteRepo, cronSiteBranch)
+						dashboard.Site = &site
+					case "both":
+						site := dashboardSiteAddress(cronSiteRepo, cronSiteBranch)
+						dashboard.GithubIssue, dashboard.Site = true, &site
+					default:
+						must.Errorf(ctx, "unknown dashboard target %v", cronDashboard)
+					}
 					result := cron.Cron(
 						ctx,
 						repo,
@@ -35,6 +50,7 @@ It will ensure that:
 						time.Duration(cronGithubFreqSeconds)*time.Second,
 						time.Duration(cronCommunityFreqSeconds)*time.Second,
 						syncFetchPar,
+						dashboard,
 					)
 					return result
 				},
@@ -46,6 +62,9 @@ It will ensure that:
 var (
 	cronGithubFreqSeconds    int
 	cronCommunityFreqSeconds int
+	cronDashboard            string
+	cronSiteRepo             string
+	cronSiteBranch           string
 )
 
 func init() {
@@ -54,6 +73,9 @@ func init() {
 	cronCmd.Flags().IntVar(&cronGithubFreqSeconds, "github_freq", github.DefaultGithubFreq, "frequency of GitHub import, in seconds")
 	cronCmd.Flags().IntVar(&cronCommunityFreqSeconds, "community_freq", github.DefaultCommunityFreq, "frequency of community tallies, in seconds")
 	cronCmd.Flags().IntVar(&syncFetchPar, "fetch_par", github.DefaultFetchParallelism, "parallelism while clonging member repos for vote collection")
+	cronCmd.Flags().StringVar(&cronDashboard, "dashboard", "github", "where to publish the community dashboard: github, site or both")
+	cronCmd.Flags().StringVar(&cronSiteRepo, "site_repo", "", "repo of the dashboard site (default the public governance repo)")
+	cronCmd.Flags().StringVar(&cronSiteBranch, "site_branch", "", "branch of the dashboard site (default the public governance branch with suffix .site)")
 
 	cronCmd.MarkFlagRequired("project")
 	cronCmd.MarkFlagRequired("token")
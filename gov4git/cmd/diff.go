@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/diff"
+	"github.com/gov4git/lib4git/must"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffCmd = &cobra.Command{
+		Use:         "diff <from> [<to>]",
+		Short:       "Summarize the changes to the governance state between two commits",
+		Annotations: readOnly,
+		Long: `
+Diff compares the governance repo at two revisions and reports users added or removed,
+balance changes per account, ballots opened or closed with their tally changes, motion state changes,
+and journal entries logged in between. Revisions are commit hashes, git revisions such as HEAD~1,
+or times (RFC 3339 or YYYY-MM-DD); <to> defaults to HEAD. The summary is printed as text,
+or as JSON with --format=json.`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			from, to := args[0], "HEAD"
+			if len(args) > 1 {
+				to = args[1]
+			}
+			switch diffFormat {
+			case "json":
+				api.Invoke1(
+					func() any {
+						LoadConfig()
+						return diff.Compare(ctx, setup.Gov, from, to)
+					},
+				)
+			default:
+				api.InvokeStream(
+					func() {
+						must.Assertf(ctx, diffFormat == "text", "unknown format %v", diffFormat)
+						LoadConfig()
+						fmt.Fprint(os.Stdout, diff.Compare(ctx, setup.Gov, from, to).Text())
+					},
+				)
+			}
+		},
+	}
+)
+
+var (
+	diffFormat string
+)
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "output format: text or json")
+}
@@ -20,9 +20,10 @@ var (
 	}
 
 	etcGetCmd = &cobra.Command{
-		Use:   "get",
-		Short: "Get system settings",
-		Long:  ``,
+		Use:         "get",
+		Short:       "Get system settings",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
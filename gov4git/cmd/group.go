@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/gov4git/gov4git/v2/gov4git/api"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/must"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +23,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapManageGroups)
 					member.AddGroup(
 						ctx,
 						setup.Gov,
@@ -40,6 +42,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapManageGroups)
 					member.RemoveGroup(
 						ctx,
 						setup.Gov,
@@ -50,10 +53,31 @@ var (
 		},
 	}
 
-	groupListCmd = &cobra.Command{
-		Use:   "list", // deprecated in favor of `users`
-		Short: "List users in group",
+	groupOpenCmd = &cobra.Command{
+		Use:   "open",
+		Short: "Allow or disallow users to join and leave the group through the bureau",
 		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					requireCapability(member.CapManageGroups)
+					member.SetGroupOpen(
+						ctx,
+						setup.Gov,
+						member.Group(groupName),
+						groupOpen,
+					)
+				},
+			)
+		},
+	}
+
+	groupListCmd = &cobra.Command{
+		Use:         "list", // deprecated in favor of `users`
+		Short:       "List users in group",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -68,9 +92,10 @@ var (
 		},
 	}
 	groupUsersCmd = &cobra.Command{
-		Use:   "users",
-		Short: "List users in group",
-		Long:  ``,
+		Use:         "users",
+		Short:       "List users in group",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -84,10 +109,138 @@ var (
 			)
 		},
 	}
+
+	groupNestCmd = &cobra.Command{
+		Use:   "nest",
+		Short: "Nest a group inside another group",
+		Long: `
+Members of the nested group become members of the parent group.
+Nesting which would make a group a member of itself is refused.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					requireCapability(member.CapManageGroups)
+					member.AddSubgroup(
+						ctx,
+						setup.Gov,
+						member.Group(groupName),
+						member.Group(groupSubgroup),
+					)
+				},
+			)
+		},
+	}
+
+	groupUnnestCmd = &cobra.Command{
+		Use:   "unnest",
+		Short: "Remove a nested group from a group",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					requireCapability(member.CapManageGroups)
+					member.RemoveSubgroup(
+						ctx,
+						setup.Gov,
+						member.Group(groupName),
+						member.Group(groupSubgroup),
+					)
+				},
+			)
+		},
+	}
+
+	groupSubgroupsCmd = &cobra.Command{
+		Use:         "subgroups",
+		Short:       "List groups nested directly in a group",
+		Annotations: readOnly,
+		Long:        ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return member.ListSubgroups(
+						ctx,
+						setup.Gov,
+						member.Group(groupName),
+					)
+				},
+			)
+		},
+	}
+
+	groupGrantCmd = &cobra.Command{
+		Use:   "grant",
+		Short: "Grant a capability to the members of a group",
+		Long: `
+Capabilities are open_ballots, issue_credits, freeze_motions, approve_joins,
+manage_motions and manage_groups. Once a capability is granted to any group,
+only members of the groups it is granted to may perform the corresponding actions.
+The manage_groups capability is restricted from the start: it is held by the organizer,
+in addition to the groups it is granted to.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					requireCapability(member.CapManageGroups)
+					member.GrantCapability(
+						ctx,
+						setup.Gov,
+						member.Group(groupName),
+						member.Capability(groupCapability),
+					)
+				},
+			)
+		},
+	}
+
+	groupRevokeCmd = &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke a capability from a group",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					requireCapability(member.CapManageGroups)
+					member.RevokeCapability(
+						ctx,
+						setup.Gov,
+						member.Group(groupName),
+						member.Capability(groupCapability),
+					)
+				},
+			)
+		},
+	}
+
+	groupCapabilitiesCmd = &cobra.Command{
+		Use:         "capabilities",
+		Short:       "List the capabilities granted to a group",
+		Annotations: readOnly,
+		Long:        ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return member.ListGroupCapabilities(
+						ctx,
+						setup.Gov,
+						member.Group(groupName),
+					)
+				},
+			)
+		},
+	}
 )
 
 var (
-	groupName string
+	groupName       string
+	groupOpen       bool
+	groupCapability string
+	groupSubgroup   string
 )
 
 func init() {
@@ -99,7 +252,50 @@ func init() {
 	groupRemoveCmd.Flags().StringVar(&groupName, "name", "", "group alias within the community")
 	groupRemoveCmd.MarkFlagRequired("name")
 
+	groupCmd.AddCommand(groupOpenCmd)
+	groupOpenCmd.Flags().StringVar(&groupName, "name", "", "group alias within the community")
+	groupOpenCmd.MarkFlagRequired("name")
+	groupOpenCmd.Flags().BoolVar(&groupOpen, "open", true, "whether the group is open")
+
 	groupCmd.AddCommand(groupListCmd)
 	groupListCmd.Flags().StringVar(&groupName, "name", "", "group alias within the community")
 	groupListCmd.MarkFlagRequired("name")
+
+	groupCmd.AddCommand(groupNestCmd)
+	groupNestCmd.Flags().StringVar(&groupName, "name", "", "parent group alias within the community")
+	groupNestCmd.MarkFlagRequired("name")
+	groupNestCmd.Flags().StringVar(&groupSubgroup, "subgroup", "", "alias of the group to nest")
+	groupNestCmd.MarkFlagRequired("subgroup")
+
+	groupCmd.AddCommand(groupUnnestCmd)
+	groupUnnestCmd.Flags().StringVar(&groupName, "name", "", "parent group alias within the community")
+	groupUnnestCmd.MarkFlagRequired("name")
+	groupUnnestCmd.Flags().StringVar(&groupSubgroup, "subgroup", "", "alias of the nested group")
+	groupUnnestCmd.MarkFlagRequired("subgroup")
+
+	groupCmd.AddCommand(groupSubgroupsCmd)
+	groupSubgroupsCmd.Flags().StringVar(&groupName, "name", "", "group alias within the community")
+	groupSubgroupsCmd.MarkFlagRequired("name")
+
+	groupCmd.AddCommand(groupGrantCmd)
+	groupGrantCmd.Flags().StringVar(&groupName, "name", "", "group alias within the community")
+	groupGrantCmd.MarkFlagRequired("name")
+	groupGrantCmd.Flags().StringVar(&groupCapability, "capability", "", "capability to grant")
+	groupGrantCmd.MarkFlagRequired("capability")
+
+	groupCmd.AddCommand(groupRevokeCmd)
+	groupRevokeCmd.Flags().StringVar(&groupName, "name", "", "group alias within the community")
+	groupRevokeCmd.MarkFlagRequired("name")
+	groupRevokeCmd.Flags().StringVar(&groupCapability, "capability", "", "capability to revoke")
+	groupRevokeCmd.MarkFlagRequired("capability")
+
+	groupCmd.AddCommand(groupCapabilitiesCmd)
+	groupCapabilitiesCmd.Flags().StringVar(&groupName, "name", "", "group alias within the community")
+	groupCapabilitiesCmd.MarkFlagRequired("name")
+}
+
+// requireCapability refuses to proceed, unless the identity in the configuration may perform actions which require the capability.
+// Organizer capabilities are also held by whoever can read the community's private credentials.
+func requireCapability(cap member.Capability) {
+	must.NoError(ctx, member.CheckOwnerCapability(ctx, setup.Organizer, setup.Member.Public, cap))
 }
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/history/export"
+	"github.com/gov4git/lib4git/must"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyCmd = &cobra.Command{
+		Use:   "history",
+		Short: "Inspect the metric and trace history of the community",
+		Long:  ``,
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+
+	historyExportCmd = &cobra.Command{
+		Use:         "export",
+		Short:       "Export metric or trace history as CSV or JSONL",
+		Annotations: readOnly,
+		Long: `
+Export flattens the metric or trace history of the community into rows with stable columns,
+documented in the governance manual. Times are RFC 3339 timestamps or dates;
+an --until date includes the whole day. The export is written to stdout, unless --out is given.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if historyExportOut == "" {
+				api.InvokeStream(
+					func() {
+						LoadConfig()
+						since, until := parseHistoryRange()
+						export.Export(ctx, setup.Gov, export.Kind(historyExportKind), since, until, export.Format(historyExportFormat), os.Stdout)
+					},
+				)
+				return
+			}
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					since, until := parseHistoryRange()
+					f, err := os.Create(historyExportOut)
+					must.NoError(ctx, err)
+					defer f.Close()
+					n := export.Export(ctx, setup.Gov, export.Kind(historyExportKind), since, until, export.Format(historyExportFormat), f)
+					return map[string]any{"path": historyExportOut, "rows": n}
+				},
+			)
+		},
+	}
+)
+
+var (
+	historyExportKind   string
+	historyExportFormat string
+	historyExportSince  string
+	historyExportUntil  string
+	historyExportOut    string
+)
+
+func init() {
+	historyCmd.AddCommand(historyExportCmd)
+	historyExportCmd.Flags().StringVar(&historyExportKind, "kind", string(export.KindMetric), "history to export: metric or trace")
+	historyExportCmd.Flags().StringVar(&historyExportFormat, "format", string(export.FormatCSV), "output format: csv or jsonl")
+	historyExportCmd.Flags().StringVar(&historyExportSince, "since", "", "earliest time to export (RFC 3339 or YYYY-MM-DD)")
+	historyExportCmd.Flags().StringVar(&historyExportUntil, "until", "", "latest time to export (RFC 3339 or YYYY-MM-DD)")
+	historyExportCmd.Flags().StringVar(&historyExportOut, "out", "", "output file (default stdout)")
+}
+
+func parseHistoryRange() (since, until time.Time) {
+	if historyExportSince != "" {
+		since, _ = parseHistoryTime(historyExportSince)
+	}
+	if historyExportUntil != "" {
+		var isDate bool
+		until, isDate = parseHistoryTime(historyExportUntil)
+		if isDate {
+			until = until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+	return since, until
+}
+
+func parseHistoryTime(s string) (time.Time, bool) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, true
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	must.NoError(ctx, err)
+	return t, false
+}
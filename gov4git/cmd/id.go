@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/must"
+	"github.com/spf13/cobra"
+)
+
+var (
+	idCmd = &cobra.Command{
+		Use:   "id",
+		Short: "Manage the keys and credentials of your identity",
+		Long:  ``,
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+
+	idRotateCmd = &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the key of your identity with a new one",
+		Long: `
+The rotation is signed by the old key and recorded in the key history of your public repo.
+Communities follow the key history, so your memberships and pending messages remain valid.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return id.Rotate(ctx, setup.Member).Result
+				},
+			)
+		},
+	}
+
+	idEncryptCmd = &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt your private credentials with a passphrase",
+		Long: `
+The passphrase is read from the GOV4GIT_PASSPHRASE environment variable or, if it is not set,
+from a prompt, which asks for it twice. Prompts require stdin to be a terminal.
+Earlier commits of your private repo still contain the plaintext credentials. Run "gov4git id rotate" after encrypting to retire them.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					must.NoError(ctx, promptNewPassphrase(ctx))
+					id.Encrypt(ctx, idOwnerAddress())
+				},
+			)
+		},
+	}
+
+	idDecryptCmd = &cobra.Command{
+		Use:   "decrypt",
+		Short: "Store your private credentials without encryption",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					id.Decrypt(ctx, idOwnerAddress())
+				},
+			)
+		},
+	}
+
+	idRevokeCmd = &cobra.Command{
+		Use:   "revoke",
+		Short: "Replace a compromised key of your identity, and invalidate messages signed by it since a given time",
+		Long: `
+Use this command if your private key has leaked.
+Votes and other messages signed by the revoked key are rejected, unless they were received before the revocation time.
+Votes signed by the revoked key and accepted after the revocation time are struck from open ballots.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					since, err := time.Parse(time.RFC3339, idRevokeSince)
+					must.NoError(ctx, err)
+					return id.Revoke(ctx, setup.Member, since).Result
+				},
+			)
+		},
+	}
+)
+
+var (
+	idRevokeSince string
+	idCommunity   bool
+)
+
+// idOwnerAddress returns the identity of the community if --community is set, and the member's identity otherwise.
+func idOwnerAddress() id.OwnerAddress {
+	if idCommunity {
+		return id.OwnerAddress(setup.Organizer)
+	}
+	return setup.Member
+}
+
+func init() {
+	id.Passphrase = envOrPromptPassphrase
+
+	idCmd.AddCommand(idRotateCmd)
+
+	idCmd.AddCommand(idEncryptCmd)
+	idEncryptCmd.Flags().BoolVar(&idCommunity, "community", false, "encrypt the credentials of the community, instead of your own")
+
+	idCmd.AddCommand(idDecryptCmd)
+	idDecryptCmd.Flags().BoolVar(&idCommunity, "community", false, "decrypt the credentials of the community, instead of your own")
+
+	idCmd.AddCommand(idRevokeCmd)
+	idRevokeCmd.Flags().StringVar(&idRevokeSince, "since", "", "time since which the key is compromised, in RFC3339 format")
+	idRevokeCmd.MarkFlagRequired("since")
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/sync"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mailCmd = &cobra.Command{
+		Use:   "mail",
+		Short: "Manage mailboxes between identities and the community",
+		Long:  ``,
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+
+	mailCompactCmd = &cobra.Command{
+		Use:   "compact",
+		Short: "Roll old messages into signed snapshots",
+		Long: `
+Compaction removes messages which have been received by the other side,
+keeping the most recent messages of each mailbox. Removed messages remain in the repo history.
+By default, the mailboxes of your identity with the community are compacted.
+With --community, the mailboxes of the community with all of its users are compacted.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					if mailCommunity {
+						return sync.CompactMail(ctx, setup.Organizer, mailRetain).Result
+					}
+					return mail.Compact(ctx, setup.Member, id.PublicAddress(setup.Gov), mailRetain).Result
+				},
+			)
+		},
+	}
+
+	mailLsCmd = &cobra.Command{
+		Use:   "ls",
+		Short: "List mailboxes with their sequence numbers",
+		Long: `
+By default, the mailboxes of your identity are listed, together with the number of messages
+not yet received by the community or by you.
+With --community, the mailboxes of the community with all of its users are listed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					if mailCommunity {
+						return sync.ListMail(ctx, setup.Gov)
+					}
+					return mail.ListBoxes(ctx, setup.Member.Public, id.PublicAddress(setup.Gov))
+				},
+			)
+		},
+	}
+
+	mailShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show a message and verify its signatures",
+		Long: `
+By default, the message is read from a mailbox of your identity with the community.
+With --community, the message is read from a mailbox of the community with the given user.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					if mailCommunity {
+						return sync.ShowMail(ctx, setup.Gov, member.User(mailUser), mail.BoxKind(mailBox), mailTopic, mail.SeqNo(mailSeqNo))
+					}
+					return mail.ShowMessage(ctx, setup.Member.Public, id.PublicAddress(setup.Gov), mail.BoxKind(mailBox), mailTopic, mail.SeqNo(mailSeqNo))
+				},
+			)
+		},
+	}
+
+	mailReplayCmd = &cobra.Command{
+		Use:   "replay",
+		Short: "Re-run the community's receiver over a range of messages sent by a user",
+		Long: `
+Replay processes the messages with sequence numbers in [from, to) sent by a user on a topic,
+as if they had not been received yet. The replay runs in a dry-run clone of the community,
+which is neither committed nor pushed. Bureau, multisig and ballot topics can be replayed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return sync.Replay(ctx, setup.Organizer, member.User(mailUser), mailTopic, mail.SeqNo(mailFrom), mail.SeqNo(mailTo))
+				},
+			)
+		},
+	}
+)
+
+var (
+	mailRetain    int
+	mailCommunity bool
+	mailUser      string
+	mailBox       string
+	mailTopic     string
+	mailSeqNo     int64
+	mailFrom      int64
+	mailTo        int64
+)
+
+func init() {
+	mailCmd.AddCommand(mailCompactCmd)
+	mailCompactCmd.Flags().IntVar(&mailRetain, "retain", 100, "number of most recent messages to keep in each mailbox")
+	mailCompactCmd.Flags().BoolVar(&mailCommunity, "community", false, "compact the mailboxes of the community, instead of your identity")
+
+	mailCmd.AddCommand(mailLsCmd)
+	mailLsCmd.Flags().BoolVar(&mailCommunity, "community", false, "list the mailboxes of the community, instead of your identity")
+
+	mailCmd.AddCommand(mailShowCmd)
+	mailShowCmd.Flags().StringVar(&mailBox, "box", "sent", "mailbox kind (sent or received)")
+	mailShowCmd.Flags().StringVar(&mailTopic, "topic", "", "mailbox topic")
+	mailShowCmd.MarkFlagRequired("topic")
+	mailShowCmd.Flags().Int64Var(&mailSeqNo, "seqno", 0, "message sequence number")
+	mailShowCmd.MarkFlagRequired("seqno")
+	mailShowCmd.Flags().BoolVar(&mailCommunity, "community", false, "show a message of the community, instead of your identity")
+	mailShowCmd.Flags().StringVar(&mailUser, "user", "", "user whose mailbox with the community to show (with --community)")
+
+	mailCmd.AddCommand(mailReplayCmd)
+	mailReplayCmd.Flags().StringVar(&mailUser, "user", "", "user who sent the messages")
+	mailReplayCmd.MarkFlagRequired("user")
+	mailReplayCmd.Flags().StringVar(&mailTopic, "topic", "", "mailbox topic")
+	mailReplayCmd.MarkFlagRequired("topic")
+	mailReplayCmd.Flags().Int64Var(&mailFrom, "from", 0, "first sequence number to replay")
+	mailReplayCmd.MarkFlagRequired("from")
+	mailReplayCmd.Flags().Int64Var(&mailTo, "to", 0, "sequence number after the last one to replay")
+	mailReplayCmd.MarkFlagRequired("to")
+}
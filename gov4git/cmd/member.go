@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/invite"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/offboard"
+	"github.com/gov4git/lib4git/git"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +28,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapManageGroups)
 					member.AddMember(
 						ctx,
 						setup.Gov,
@@ -41,6 +48,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapManageGroups)
 					member.RemoveMember(
 						ctx,
 						setup.Gov,
@@ -51,11 +59,131 @@ var (
 			)
 		},
 	}
+
+	memberOffboardCmd = &cobra.Command{
+		Use:   "offboard",
+		Short: "Remove a user from the community, settling their open commitments",
+		Long: `
+Offboard removes a user from the community, together with their group memberships.
+Optionally, it withdraws and refunds the user's votes in open ballots,
+cancels or reassigns the open motions they authored,
+and sweeps their balance according to the community's offboard sweep policy.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return offboard.Offboard(
+						ctx,
+						setup.Organizer,
+						member.User(memberUser),
+						offboard.Options{
+							WithdrawVotes:     memberOffboardWithdrawVotes,
+							CancelMotions:     memberOffboardCancelMotions,
+							ReassignMotionsTo: member.User(memberOffboardReassignTo),
+							SweepBalance:      memberOffboardSweep,
+						},
+					)
+				},
+			)
+		},
+	}
+
+	memberInviteCmd = &cobra.Command{
+		Use:   "invite",
+		Short: "Mint a single-use invitation code for joining the community",
+		Long: `
+Invite mints an invitation for the identity at the given public repo and prints its code.
+The code is not stored and must be delivered to the invitee privately.
+The invitee joins by running "gov4git member redeem" from their identity,
+and is added to the community during the next sync.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					requireCapability(member.CapApproveJoins)
+					groups := make([]member.Group, len(memberInviteGroups))
+					for i, g := range memberInviteGroups {
+						groups[i] = member.Group(g)
+					}
+					return invite.Mint(
+						ctx,
+						setup.Gov,
+						id.PublicAddress{Repo: git.URL(memberInviteRepo), Branch: git.Branch(memberInviteBranch)},
+						member.User(memberUser),
+						groups,
+						memberInviteCredits,
+						memberInviteTTL,
+					)
+				},
+			)
+		},
+	}
+
+	memberInvitationsCmd = &cobra.Command{
+		Use:         "invitations",
+		Short:       "List invitations",
+		Annotations: readOnly,
+		Long:        ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return invite.List(ctx, setup.Gov)
+				},
+			)
+		},
+	}
+
+	memberRevokeInviteCmd = &cobra.Command{
+		Use:   "revoke-invite",
+		Short: "Revoke an invitation",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					invite.Revoke(ctx, setup.Gov, invite.CodeHash(memberInviteHash))
+				},
+			)
+		},
+	}
+
+	memberRedeemCmd = &cobra.Command{
+		Use:   "redeem code",
+		Short: "Request to join the community with an invitation code",
+		Long:  ``,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					invite.Redeem(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						args[0],
+						member.User(memberUser),
+					)
+				},
+			)
+		},
+	}
 )
 
 var (
-	memberUser  string
-	memberGroup string
+	memberUser          string
+	memberGroup         string
+	memberInviteRepo    string
+	memberInviteBranch  string
+	memberInviteGroups  []string
+	memberInviteCredits float64
+	memberInviteTTL     time.Duration
+	memberInviteHash    string
+
+	memberOffboardWithdrawVotes bool
+	memberOffboardCancelMotions bool
+	memberOffboardReassignTo    string
+	memberOffboardSweep         bool
 )
 
 func init() {
@@ -70,4 +198,31 @@ func init() {
 	memberRemoveCmd.MarkFlagRequired("user")
 	memberRemoveCmd.Flags().StringVar(&memberGroup, "group", "", "group alias within the community")
 	memberRemoveCmd.MarkFlagRequired("group")
+
+	memberCmd.AddCommand(memberOffboardCmd)
+	memberOffboardCmd.Flags().StringVar(&memberUser, "user", "", "user alias within the community")
+	memberOffboardCmd.MarkFlagRequired("user")
+	memberOffboardCmd.Flags().BoolVar(&memberOffboardWithdrawVotes, "withdraw-votes", false, "withdraw and refund the user's votes in open ballots")
+	memberOffboardCmd.Flags().BoolVar(&memberOffboardCancelMotions, "cancel-motions", false, "cancel open motions authored by the user")
+	memberOffboardCmd.Flags().StringVar(&memberOffboardReassignTo, "reassign-motions-to", "", "make this user the author of the user's open motions")
+	memberOffboardCmd.Flags().BoolVar(&memberOffboardSweep, "sweep", false, "sweep the user's balance according to the offboard sweep policy")
+
+	memberCmd.AddCommand(memberInviteCmd)
+	memberInviteCmd.Flags().StringVar(&memberInviteRepo, "repo", "", "URL of the invitee's public repo")
+	memberInviteCmd.MarkFlagRequired("repo")
+	memberInviteCmd.Flags().StringVar(&memberInviteBranch, "branch", "", "branch in the invitee's public repo")
+	memberInviteCmd.MarkFlagRequired("branch")
+	memberInviteCmd.Flags().StringVar(&memberUser, "user", "", "pre-assigned user alias (optional)")
+	memberInviteCmd.Flags().StringSliceVar(&memberInviteGroups, "group", nil, "pre-assigned groups (optional)")
+	memberInviteCmd.Flags().Float64Var(&memberInviteCredits, "credits", 0, "starting credits")
+	memberInviteCmd.Flags().DurationVar(&memberInviteTTL, "ttl", 7*24*time.Hour, "invitation lifetime")
+
+	memberCmd.AddCommand(memberInvitationsCmd)
+
+	memberCmd.AddCommand(memberRevokeInviteCmd)
+	memberRevokeInviteCmd.Flags().StringVar(&memberInviteHash, "hash", "", "invitation hash")
+	memberRevokeInviteCmd.MarkFlagRequired("hash")
+
+	memberCmd.AddCommand(memberRedeemCmd)
+	memberRedeemCmd.Flags().StringVar(&memberUser, "user", "", "requested user alias, if the invitation does not pre-assign one")
 }
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/metrics"
+	"github.com/gov4git/gov4git/v2/proto/metrics/prom"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/spf13/cobra"
+)
+
+var (
+	metricsCmd = &cobra.Command{
+		Use:   "metrics",
+		Short: "Community metrics",
+		Long:  ``,
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+
+	metricsServeCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Serve community metrics to Prometheus",
+		Long: `
+Serve runs an HTTP server which exposes community metrics at /metrics in the Prometheus text format.
+The governance repo is cloned periodically, every --refresh period; if a refresh fails,
+the previous values continue to be served. Serve runs until interrupted.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					prom.Serve(ctx, setup.Gov, metricsServeListen, metricsServeRefresh)
+				},
+			)
+		},
+	}
+
+	metricsSiteCmd = &cobra.Command{
+		Use:         "site",
+		Short:       "Generate the community dashboard as a static website",
+		Annotations: readOnly,
+		Long: `
+Site renders the community dashboard, its charts, the motion leaderboards and a page for each member as HTML.
+The site is written to the directory given by --dir, or published to a git branch with --publish,
+which replaces the contents of the branch. The site branch defaults to the public governance branch
+with suffix .site, and can be served by any static web host. A site rendered from a past snapshot with --at
+can only be written to a directory, since publishing it would replace the live site.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					must.Assertf(ctx, metricsSiteDir != "" || metricsSitePublish, "either --dir or --publish is required")
+					must.Assertf(ctx, !metricsSitePublish || snapshotAt == "", "--publish cannot be used with --at")
+					LoadConfig()
+					site := metrics.AssembleSite(ctx, setup.Gov, metrics.TimeDailyLowerBound, metrics.Today().AddDate(0, 0, 1))
+					result := map[string]any{"files": len(site.Files)}
+					if metricsSiteDir != "" {
+						site.WriteDir(ctx, metricsSiteDir)
+						result["dir"] = metricsSiteDir
+					}
+					if metricsSitePublish {
+						addr := dashboardSiteAddress(metricsSiteRepo, metricsSiteBranch)
+						metrics.PublishSite(ctx, addr, site)
+						result["published"] = addr
+					}
+					return result
+				},
+			)
+		},
+	}
+
+	metricsMemberCmd = &cobra.Command{
+		Use:         "member <user>",
+		Short:       "Report on the contributions and voting of a community member",
+		Annotations: readOnly,
+		Long: `
+Member reports the motions authored and accepted by a member, the bounties and rewards they received,
+the credits they spent on votes, how often their votes on approval polls agreed with the final decision,
+and their balance over time. The report is printed as markdown, or as JSON with --format=json.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			switch metricsMemberFormat {
+			case "json":
+				api.Invoke1(
+					func() any {
+						LoadConfig()
+						return metrics.AssembleMemberReport(ctx, setup.Gov, member.User(args[0]))
+					},
+				)
+			default:
+				api.InvokeStream(
+					func() {
+						must.Assertf(ctx, metricsMemberFormat == "markdown", "unknown format %v", metricsMemberFormat)
+						LoadConfig()
+						r := metrics.AssembleMemberReport(ctx, setup.Gov, member.User(args[0]))
+						fmt.Fprint(os.Stdout, r.ReportMD)
+					},
+				)
+			}
+		},
+	}
+)
+
+// dashboardSiteAddress returns the branch to which the dashboard site is published.
+// By default, it is the public governance branch with suffix .site.
+func dashboardSiteAddress(repo string, branch string) git.Address {
+	addr := git.Address(setup.Gov)
+	addr.Branch = addr.Branch + ".site"
+	if repo != "" {
+		addr.Repo = git.URL(repo)
+	}
+	if branch != "" {
+		addr.Branch = git.Branch(branch)
+	}
+	return addr
+}
+
+var (
+	metricsSiteDir      string
+	metricsSitePublish  bool
+	metricsSiteRepo     string
+	metricsSiteBranch   string
+	metricsMemberFormat string
+	metricsServeListen  string
+	metricsServeRefresh time.Duration
+)
+
+func init() {
+	metricsCmd.AddCommand(metricsMemberCmd)
+	metricsMemberCmd.Flags().StringVar(&metricsMemberFormat, "format", "markdown", "output format: markdown or json")
+
+	metricsCmd.AddCommand(metricsSiteCmd)
+	metricsSiteCmd.Flags().StringVar(&metricsSiteDir, "dir", "", "directory to write the site to")
+	metricsSiteCmd.Flags().BoolVar(&metricsSitePublish, "publish", false, "publish the site to a git branch")
+	metricsSiteCmd.Flags().StringVar(&metricsSiteRepo, "site_repo", "", "repo of the site (default the public governance repo)")
+	metricsSiteCmd.Flags().StringVar(&metricsSiteBranch, "site_branch", "", "branch of the site (default the public governance branch with suffix .site)")
+
+	metricsCmd.AddCommand(metricsServeCmd)
+	metricsServeCmd.Flags().StringVar(&metricsServeListen, "listen", prom.DefaultListen, "address to listen on")
+	metricsServeCmd.Flags().DurationVar(&metricsServeRefresh, "refresh", prom.DefaultRefresh, "how often to re-read the governance repo")
+}
@@ -27,6 +27,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapManageMotions)
 					motionapi.OpenMotion(
 						ctx,
 						setup.Organizer,
@@ -52,6 +53,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapManageMotions)
 					var d motionproto.Decision
 					if motionAccept {
 						d = motionproto.Accept
@@ -70,9 +72,10 @@ var (
 	}
 
 	motionListCmd = &cobra.Command{
-		Use:   "list",
-		Short: "List motions",
-		Long:  ``,
+		Use:         "list",
+		Short:       "List motions",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -88,9 +91,10 @@ var (
 	}
 
 	motionShowCmd = &cobra.Command{
-		Use:   "show",
-		Short: "Show motion state and associated objects",
-		Long:  ``,
+		Use:         "show",
+		Short:       "Show motion state and associated objects",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -106,9 +110,10 @@ var (
 	}
 
 	motionPoliciesCmd = &cobra.Command{
-		Use:   "policies",
-		Short: "Display descriptors for installed motion policies",
-		Long:  ``,
+		Use:         "policies",
+		Short:       "Display descriptors for installed motion policies",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() map[string]motionproto.PolicyDescriptor {
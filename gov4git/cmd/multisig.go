@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/multisig"
+	"github.com/gov4git/lib4git/must"
+	"github.com/spf13/cobra"
+)
+
+var (
+	multisigCmd = &cobra.Command{
+		Use:   "multisig",
+		Short: "Propose and approve organizer actions which require multiple signatures",
+		Long: `
+Organizer actions listed in the multi-signature policy of the system settings
+apply only after a threshold of the designated signers approve them.`,
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+
+	multisigProposeCmd = &cobra.Command{
+		Use:   "propose",
+		Short: "Propose an organizer action",
+		Long:  ``,
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+
+	multisigProposeIssueCmd = &cobra.Command{
+		Use:   "issue",
+		Short: "Propose to issue to an account",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					action := multisig.Action{
+						Issue: &multisig.IssueAction{
+							To:     account.AccountID(multisigTo),
+							Amount: account.H(account.Asset(multisigAsset), multisigQuantity),
+							Note:   multisigNote,
+						},
+					}
+					return multisig.Propose(ctx, setup.Gov, etc.OpAccountIssue, action, multisigNote).Result
+				},
+			)
+		},
+	}
+
+	multisigProposeRemoveUserCmd = &cobra.Command{
+		Use:   "remove-user",
+		Short: "Propose to remove a user",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					action := multisig.Action{
+						RemoveUser: &multisig.RemoveUserAction{User: member.User(multisigUser)},
+					}
+					return multisig.Propose(ctx, setup.Gov, etc.OpMemberRemoveUser, action, multisigNote).Result
+				},
+			)
+		},
+	}
+
+	multisigProposeEraseBallotCmd = &cobra.Command{
+		Use:   "erase-ballot",
+		Short: "Propose to erase a ballot",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					action := multisig.Action{
+						EraseBallot: &multisig.EraseBallotAction{Ballot: ballotproto.ParseBallotID(multisigBallot)},
+					}
+					return multisig.Propose(ctx, setup.Gov, etc.OpBallotErase, action, multisigNote).Result
+				},
+			)
+		},
+	}
+
+	multisigProposeSetPolicyCmd = &cobra.Command{
+		Use:   "set-policy",
+		Short: "Propose to change the multi-signature policy",
+		Long:  `The new policy must be given as JSON on the standard input.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+
+					jsonData, err := io.ReadAll(os.Stdin)
+					must.NoError(ctx, err)
+
+					var policy etc.MultisigPolicy
+					err = json.Unmarshal(jsonData, &policy)
+					must.NoError(ctx, err)
+
+					action := multisig.Action{SetPolicy: &policy}
+					return multisig.Propose(ctx, setup.Gov, multisig.OpSetPolicy, action, multisigNote).Result
+				},
+			)
+		},
+	}
+
+	multisigApproveCmd = &cobra.Command{
+		Use:   "approve",
+		Short: "Sign a proposal with your identity and send the approval to the community",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					multisig.Approve(ctx, setup.Member, setup.Gov, multisig.ProposalID(multisigID))
+				},
+			)
+		},
+	}
+
+	multisigCancelCmd = &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel an open proposal",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					multisig.Cancel(ctx, setup.Gov, multisig.ProposalID(multisigID), multisigNote)
+				},
+			)
+		},
+	}
+
+	multisigListCmd = &cobra.Command{
+		Use:         "list",
+		Short:       "List proposals",
+		Annotations: readOnly,
+		Long:        ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return multisig.List(ctx, setup.Gov)
+				},
+			)
+		},
+	}
+
+	multisigProcessCmd = &cobra.Command{
+		Use:   "process",
+		Short: "Collect approvals and apply approved proposals",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					chg, _ := multisig.Process(ctx, setup.Organizer)
+					return chg.Result
+				},
+			)
+		},
+	}
+)
+
+var (
+	multisigID       string
+	multisigTo       string
+	multisigAsset    string
+	multisigQuantity float64
+	multisigUser     string
+	multisigBallot   string
+	multisigNote     string
+)
+
+func init() {
+	multisigCmd.AddCommand(multisigProposeCmd)
+
+	multisigProposeCmd.AddCommand(multisigProposeIssueCmd)
+	multisigProposeIssueCmd.Flags().StringVar(&multisigTo, "to", "", "to account id")
+	multisigProposeIssueCmd.MarkFlagRequired("to")
+	multisigProposeIssueCmd.Flags().StringVarP(&multisigAsset, "asset", "a", "", "asset")
+	multisigProposeIssueCmd.MarkFlagRequired("asset")
+	multisigProposeIssueCmd.Flags().Float64VarP(&multisigQuantity, "quantity", "q", 0.0, "quantity")
+	multisigProposeIssueCmd.MarkFlagRequired("quantity")
+	multisigProposeIssueCmd.Flags().StringVarP(&multisigNote, "note", "n", "manual", "note")
+
+	multisigProposeCmd.AddCommand(multisigProposeRemoveUserCmd)
+	multisigProposeRemoveUserCmd.Flags().StringVar(&multisigUser, "name", "", "user name")
+	multisigProposeRemoveUserCmd.MarkFlagRequired("name")
+	multisigProposeRemoveUserCmd.Flags().StringVarP(&multisigNote, "note", "n", "", "note")
+
+	multisigProposeCmd.AddCommand(multisigProposeEraseBallotCmd)
+	multisigProposeEraseBallotCmd.Flags().StringVar(&multisigBallot, "name", "", "ballot name")
+	multisigProposeEraseBallotCmd.MarkFlagRequired("name")
+	multisigProposeEraseBallotCmd.Flags().StringVarP(&multisigNote, "note", "n", "", "note")
+
+	multisigProposeCmd.AddCommand(multisigProposeSetPolicyCmd)
+	multisigProposeSetPolicyCmd.Flags().StringVarP(&multisigNote, "note", "n", "", "note")
+
+	multisigCmd.AddCommand(multisigApproveCmd)
+	multisigApproveCmd.Flags().StringVar(&multisigID, "id", "", "proposal id")
+	multisigApproveCmd.MarkFlagRequired("id")
+
+	multisigCmd.AddCommand(multisigCancelCmd)
+	multisigCancelCmd.Flags().StringVar(&multisigID, "id", "", "proposal id")
+	multisigCancelCmd.MarkFlagRequired("id")
+	multisigCancelCmd.Flags().StringVarP(&multisigNote, "note", "n", "", "note")
+
+	multisigCmd.AddCommand(multisigListCmd)
+	multisigCmd.AddCommand(multisigProcessCmd)
+}
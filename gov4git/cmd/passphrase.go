@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"golang.org/x/term"
+)
+
+var errPassphraseMismatch = errors.New("passphrases do not match")
+
+var passphraseOnce struct {
+	sync.Mutex
+	value *string
+}
+
+// envOrPromptPassphrase reads the passphrase from the environment, or else prompts for it on the terminal, once per process.
+func envOrPromptPassphrase(ctx context.Context) (string, error) {
+	if p, err := id.PassphraseFromEnv(ctx); err == nil {
+		return p, nil
+	}
+
+	passphraseOnce.Lock()
+	defer passphraseOnce.Unlock()
+	if passphraseOnce.value != nil {
+		return *passphraseOnce.value, nil
+	}
+
+	p, err := readPassphrase("Passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	passphraseOnce.value = &p
+	return p, nil
+}
+
+// promptNewPassphrase prompts for a new passphrase twice, unless it is set in the environment,
+// and remembers it for the rest of the process.
+func promptNewPassphrase(ctx context.Context) error {
+	if _, err := id.PassphraseFromEnv(ctx); err == nil {
+		return nil
+	}
+
+	passphraseOnce.Lock()
+	defer passphraseOnce.Unlock()
+
+	p, err := readPassphrase("New passphrase: ")
+	if err != nil {
+		return err
+	}
+	q, err := readPassphrase("Repeat passphrase: ")
+	if err != nil {
+		return err
+	}
+	if p != q {
+		return errPassphraseMismatch
+	}
+	passphraseOnce.value = &p
+	return nil
+}
+
+// readPassphrase reads a line from the terminal without echoing it.
+// It fails if stdin is not a terminal, rather than reading a passphrase which would be echoed or piped in.
+func readPassphrase(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("%w: stdin is not a terminal, set %s", id.ErrNoPassphrase, id.PassphraseEnv)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -10,6 +10,7 @@ import (
 	gov4git "github.com/gov4git/gov4git/v2"
 	"github.com/gov4git/gov4git/v2/github"
 	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/gov"
 	_ "github.com/gov4git/gov4git/v2/runtime"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
@@ -24,9 +25,21 @@ var (
 		Long:  ``,
 		Run: func(cmd *cobra.Command, args []string) {
 		},
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if snapshotAt != "" && cmd.Annotations[readOnlyAnnotation] != "true" {
+				return fmt.Errorf("%q may modify the community and cannot be run with --at", cmd.CommandPath())
+			}
+			return nil
+		},
 	}
 )
 
+// readOnly annotates commands which only read the governance repo.
+// Only these commands can be run against a past snapshot with --at.
+const readOnlyAnnotation = "read_only"
+
+var readOnly = map[string]string{readOnlyAnnotation: "true"}
+
 var ctx = github.WithTokenSource(git.WithTTL(git.WithAuth(context.Background(), nil), nil), nil)
 
 var (
@@ -34,6 +47,7 @@ var (
 	verbose        bool
 	cpuProfilePath string
 	memProfilePath string
+	snapshotAt     string
 )
 
 func init() {
@@ -45,8 +59,10 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "run in developer mode with verbose logging")
 	rootCmd.PersistentFlags().StringVarP(&cpuProfilePath, "cpu", "p", "", "cpu profile path")
 	rootCmd.PersistentFlags().StringVarP(&memProfilePath, "mem", "m", "", "memory profile path")
+	rootCmd.PersistentFlags().StringVar(&snapshotAt, "at", "", "run a read-only command against the governance repo at a past commit or time (RFC 3339 or YYYY-MM-DD)")
 
 	rootCmd.AddCommand(initIDCmd)
+	rootCmd.AddCommand(idCmd)
 	rootCmd.AddCommand(initGovCmd)
 	rootCmd.AddCommand(userCmd)
 	rootCmd.AddCommand(groupCmd)
@@ -61,7 +77,13 @@ func init() {
 	rootCmd.AddCommand(githubCmd)
 	rootCmd.AddCommand(motionCmd)
 	rootCmd.AddCommand(etcCmd)
+	rootCmd.AddCommand(multisigCmd)
+	rootCmd.AddCommand(mailCmd)
 	rootCmd.AddCommand(panoramaCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(metricsCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(alertCmd)
 }
 
 func initAfterFlags() {
@@ -102,6 +124,10 @@ func LoadConfig() {
 		ctx = git.WithCache(ctx, config.CacheDir)
 	}
 
+	if snapshotAt != "" {
+		ctx = gov.WithSnapshot(ctx, snapshotAt)
+	}
+
 	setup = config.Setup(ctx)
 }
 
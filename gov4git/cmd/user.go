@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/json"
+
 	"github.com/gov4git/gov4git/v2/gov4git/api"
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/member"
@@ -24,6 +26,7 @@ var (
 			api.Invoke(
 				func() {
 					LoadConfig()
+					requireCapability(member.CapApproveJoins)
 					member.AddUserByPublicAddress(
 						ctx,
 						setup.Gov,
@@ -54,9 +57,10 @@ var (
 	}
 
 	userPropGetCmd = &cobra.Command{
-		Use:   "prop-get",
-		Short: "Get user property",
-		Long:  ``,
+		Use:         "prop-get",
+		Short:       "Get user property",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -72,10 +76,57 @@ var (
 		},
 	}
 
+	userPropSetCmd = &cobra.Command{
+		Use:   "prop-set",
+		Short: "Set user property",
+		Long: `
+The value is parsed as JSON. Values which are not valid JSON are taken as strings.
+If the community has a profile schema, the property must be declared in it with a matching type.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					var value any
+					if json.Unmarshal([]byte(userValue), &value) != nil {
+						value = userValue
+					}
+					member.SetUserProp[any](
+						ctx,
+						setup.Gov,
+						member.User(userName),
+						userKey,
+						value,
+					)
+				},
+			)
+		},
+	}
+
+	userShowCmd = &cobra.Command{
+		Use:         "show",
+		Short:       "Show user profile, with properties typed according to the profile schema",
+		Annotations: readOnly,
+		Long:        ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return member.ShowUser(
+						ctx,
+						setup.Gov,
+						member.User(userName),
+						userAll,
+					)
+				},
+			)
+		},
+	}
+
 	userListGroupsCmd = &cobra.Command{
-		Use:   "groups",
-		Short: "List user's group memberships",
-		Long:  ``,
+		Use:         "groups",
+		Short:       "List user's group memberships",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
@@ -97,6 +148,7 @@ var (
 	userBranch string
 	userKey    string
 	userValue  string
+	userAll    bool
 )
 
 func init() {
@@ -118,6 +170,19 @@ func init() {
 	userPropGetCmd.Flags().StringVar(&userKey, "key", "", "property key")
 	userPropGetCmd.MarkFlagRequired("key")
 
+	userCmd.AddCommand(userPropSetCmd)
+	userPropSetCmd.Flags().StringVar(&userName, "name", "", "user alias within the community")
+	userPropSetCmd.MarkFlagRequired("name")
+	userPropSetCmd.Flags().StringVar(&userKey, "key", "", "property key")
+	userPropSetCmd.MarkFlagRequired("key")
+	userPropSetCmd.Flags().StringVar(&userValue, "value", "", "property value")
+	userPropSetCmd.MarkFlagRequired("value")
+
+	userCmd.AddCommand(userShowCmd)
+	userShowCmd.Flags().StringVar(&userName, "name", "", "user alias within the community")
+	userShowCmd.MarkFlagRequired("name")
+	userShowCmd.Flags().BoolVar(&userAll, "all", false, "include organizer-only and undeclared properties")
+
 	userCmd.AddCommand(userListGroupsCmd)
 	userListGroupsCmd.Flags().StringVar(&userName, "name", "", "user alias within the community")
 	userListGroupsCmd.MarkFlagRequired("name")
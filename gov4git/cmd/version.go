@@ -8,9 +8,10 @@ import (
 
 var (
 	versionCmd = &cobra.Command{
-		Use:   "version",
-		Short: "Version and build information",
-		Long:  ``,
+		Use:         "version",
+		Short:       "Version and build information",
+		Annotations: readOnly,
+		Long:        ``,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
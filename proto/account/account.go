@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/history/metric"
 	"github.com/gov4git/gov4git/v2/proto/history/trace"
@@ -35,6 +36,10 @@ type Account struct {
 	ID     AccountID     `json:"id"`
 	Owner  AccountID     `json:"owner"`
 	Assets AssetHoldings `json:"assets"`
+	// controls
+	Frozen bool           `json:"frozen,omitempty"`
+	Limits SpendingLimits `json:"spending_limits,omitempty"`
+	Audit  ControlLog     `json:"control_log,omitempty"`
 }
 
 func (a *Account) DepositOverDraft(ctx context.Context, h Holding) {
@@ -78,7 +83,7 @@ func (x AssetHoldings) DepositOverDraft(ctx context.Context, h Holding) {
 func (x AssetHoldings) Deposit(ctx context.Context, h Holding) {
 	if g, ok := x[h.Asset]; ok {
 		d := SumHolding(ctx, g, h)
-		must.Assertf(ctx, d.Quantity >= 0, "insufficient funds")
+		must.Assert(ctx, d.Quantity >= 0, ErrInsufficientFunds)
 		x[h.Asset] = d
 	} else {
 		d := h
@@ -133,10 +138,8 @@ func Create_StageOnly(
 	must.Assertf(ctx, !Exists_Local(ctx, cloned, id), "account %v already exists", id)
 	set_StageOnly(ctx, cloned, id, NewAccount(id, owner))
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "account_create",
-		Note:   note,
-		Args:   trace.M{"id": id, "owner": owner},
-		Result: nil,
+		Note:    note,
+		Payload: &CreateTrace{ID: id, Owner: owner},
 	})
 }
 
@@ -212,10 +215,8 @@ func Transfer_StageOnly(
 	set_StageOnly(ctx, cloned, toID, to)
 
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "account_transfer",
-		Note:   note,
-		Args:   trace.M{"from": fromID, "to": toID, "amount": amount},
-		Result: nil,
+		Note:    note,
+		Payload: &TransferTrace{From: fromID, To: toID, Amount: amount},
 	})
 	metric.Log_StageOnly(ctx, cloned, &metric.Event{
 		Account: &metric.AccountEvent{
@@ -228,6 +229,36 @@ func Transfer_StageOnly(
 	})
 }
 
+// Spend_StageOnly transfers on behalf of the holder of the from account,
+// which must not be frozen and must be within its spending limits.
+func Spend_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	fromID AccountID,
+	toID AccountID,
+	amount Holding,
+	note string,
+
+) {
+
+	from := Get_Local(ctx, cloned, fromID)
+	from.Spend(ctx, amount)
+	set_StageOnly(ctx, cloned, fromID, from)
+	Transfer_StageOnly(ctx, cloned, fromID, toID, amount, note)
+}
+
+func TrySpend_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	from AccountID,
+	to AccountID,
+	amount Holding,
+	note string,
+
+) error {
+	return must.Try(func() { Spend_StageOnly(ctx, cloned, from, to, amount, note) })
+}
+
 func TryTransfer_StageOnly(
 	ctx context.Context,
 	cloned gov.Cloned,
@@ -264,6 +295,7 @@ func Issue_StageOnly(
 
 ) {
 
+	must.NoError(ctx, etc.CheckUnilateral_Local(ctx, cloned, etc.OpAccountIssue))
 	TransferOverDraft_StageOnly(
 		metric.Mute(ctx),
 		cloned,
@@ -273,10 +305,8 @@ func Issue_StageOnly(
 		note,
 	)
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "account_issue",
-		Note:   note,
-		Args:   trace.M{"from": IssueAccountID, "to": toID, "amount": amount},
-		Result: nil,
+		Note:    note,
+		Payload: &IssueTrace{From: IssueAccountID, To: toID, Amount: amount},
 	})
 	metric.Log_StageOnly(ctx, cloned, &metric.Event{
 		Account: &metric.AccountEvent{
@@ -320,10 +350,8 @@ func Burn_StageOnly(
 		note,
 	)
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "account_burn",
-		Note:   note,
-		Args:   trace.M{"from": fromID, "to": BurnAccountID, "amount": amount},
-		Result: nil,
+		Note:    note,
+		Payload: &BurnTrace{From: fromID, To: BurnAccountID, Amount: amount},
 	})
 	metric.Log_StageOnly(ctx, cloned, &metric.Event{
 		Account: &metric.AccountEvent{
@@ -382,10 +410,17 @@ func TransferOverDraft_StageOnly(
 	set_StageOnly(ctx, cloned, toID, to)
 
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "account_transfer_overdraft",
-		Note:   note,
-		Args:   trace.M{"from": fromID, "to": toID, "amount": amount},
-		Result: nil,
+		Note:    note,
+		Payload: &TransferOverDraftTrace{From: fromID, To: toID, Amount: amount},
+	})
+	metric.Log_StageOnly(ctx, cloned, &metric.Event{
+		Account: &metric.AccountEvent{
+			Transfer: &metric.AccountTransferEvent{
+				From:   fromID.MetricAccountID(),
+				To:     toID.MetricAccountID(),
+				Amount: amount.MetricHolding(),
+			},
+		},
 	})
 }
 
@@ -0,0 +1,248 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/lib4git/must"
+)
+
+// SpendingLimit caps the quantity of an asset that can be withdrawn from an account within a period of time.
+type SpendingLimit struct {
+	Max    float64       `json:"max"`
+	Period time.Duration `json:"period"`
+	// current period
+	PeriodStart time.Time `json:"period_start"`
+	PeriodSpent float64   `json:"period_spent"`
+}
+
+func (x SpendingLimit) advance(now time.Time) SpendingLimit {
+	if now.Sub(x.PeriodStart) >= x.Period {
+		x.PeriodStart = now
+		x.PeriodSpent = 0
+	}
+	return x
+}
+
+type SpendingLimits map[Asset]SpendingLimit
+
+type ControlAction string
+
+const (
+	ControlFreeze           ControlAction = "freeze"
+	ControlUnfreeze         ControlAction = "unfreeze"
+	ControlSetSpendingLimit ControlAction = "set_spending_limit"
+	ControlRemoveLimit      ControlAction = "remove_spending_limit"
+)
+
+// ControlRecord is an audit trail entry for an account control change.
+type ControlRecord struct {
+	Time   time.Time     `json:"time"`
+	Action ControlAction `json:"action"`
+	Asset  Asset         `json:"asset,omitempty"`
+	Note   string        `json:"note"`
+}
+
+type ControlLog []ControlRecord
+
+// CheckSpend returns an error if the holding cannot be withdrawn from the account at time now.
+func (a *Account) CheckSpend(h Holding, now time.Time) error {
+	if a.Frozen {
+		return fmt.Errorf("%w: %v", ErrAccountFrozen, a.ID)
+	}
+	limit, ok := a.Limits[h.Asset]
+	if !ok || h.Quantity <= 0 {
+		return nil
+	}
+	limit = limit.advance(now)
+	if limit.PeriodSpent+h.Quantity > limit.Max {
+		return fmt.Errorf("%w: account %v can spend %v more %v until %v",
+			ErrSpendingLimitExceeded,
+			a.ID,
+			max(0, limit.Max-limit.PeriodSpent),
+			h.Asset,
+			limit.PeriodStart.Add(limit.Period).Format(time.RFC3339),
+		)
+	}
+	return nil
+}
+
+// Spend verifies that the holding can be withdrawn and records it against the account's spending limits.
+func (a *Account) Spend(ctx context.Context, h Holding) {
+	now := time.Now()
+	must.NoError(ctx, a.CheckSpend(h, now))
+	limit, ok := a.Limits[h.Asset]
+	if !ok || h.Quantity <= 0 {
+		return
+	}
+	limit = limit.advance(now)
+	limit.PeriodSpent += h.Quantity
+	a.Limits[h.Asset] = limit
+}
+
+func (a *Account) logControl(action ControlAction, asset Asset, note string) {
+	a.Audit = append(a.Audit, ControlRecord{Time: time.Now(), Action: action, Asset: asset, Note: note})
+}
+
+func IsFrozen_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id AccountID,
+
+) bool {
+	return Get_Local(ctx, cloned, id).Frozen
+}
+
+func CheckSpend_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id AccountID,
+	amount Holding,
+
+) error {
+	return Get_Local(ctx, cloned, id).CheckSpend(amount, time.Now())
+}
+
+func Freeze(
+	ctx context.Context,
+	addr gov.Address,
+	id AccountID,
+	note string,
+
+) {
+	cloned := gov.Clone(ctx, addr)
+	Freeze_StageOnly(ctx, cloned, id, note)
+	proto.Commitf(ctx, cloned, "account_freeze", "freeze account %v (%v)", id, note)
+	cloned.Push(ctx)
+}
+
+func Freeze_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id AccountID,
+	note string,
+
+) {
+	a := Get_Local(ctx, cloned, id)
+	must.Assertf(ctx, !a.Frozen, "account %v is already frozen", id)
+	a.Frozen = true
+	a.logControl(ControlFreeze, "", note)
+	set_StageOnly(ctx, cloned, id, a)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Note:    note,
+		Payload: &FreezeTrace{ID: id},
+	})
+}
+
+func Unfreeze(
+	ctx context.Context,
+	addr gov.Address,
+	id AccountID,
+	note string,
+
+) {
+	cloned := gov.Clone(ctx, addr)
+	Unfreeze_StageOnly(ctx, cloned, id, note)
+	proto.Commitf(ctx, cloned, "account_unfreeze", "unfreeze account %v (%v)", id, note)
+	cloned.Push(ctx)
+}
+
+func Unfreeze_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id AccountID,
+	note string,
+
+) {
+	a := Get_Local(ctx, cloned, id)
+	must.Assertf(ctx, a.Frozen, "account %v is not frozen", id)
+	a.Frozen = false
+	a.logControl(ControlUnfreeze, "", note)
+	set_StageOnly(ctx, cloned, id, a)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Note:    note,
+		Payload: &UnfreezeTrace{ID: id},
+	})
+}
+
+func SetSpendingLimit(
+	ctx context.Context,
+	addr gov.Address,
+	id AccountID,
+	limit Holding,
+	period time.Duration,
+	note string,
+
+) {
+	cloned := gov.Clone(ctx, addr)
+	SetSpendingLimit_StageOnly(ctx, cloned, id, limit, period, note)
+	proto.Commitf(ctx, cloned, "account_set_spending_limit", "limit spending from account %v to %v per %v (%v)", id, limit, period, note)
+	cloned.Push(ctx)
+}
+
+func SetSpendingLimit_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id AccountID,
+	limit Holding,
+	period time.Duration,
+	note string,
+
+) {
+	must.Assertf(ctx, limit.Quantity >= 0, "spending limit must be non-negative")
+	must.Assertf(ctx, period > 0, "spending limit period must be positive")
+	a := Get_Local(ctx, cloned, id)
+	if a.Limits == nil {
+		a.Limits = SpendingLimits{}
+	}
+	a.Limits[limit.Asset] = SpendingLimit{
+		Max:         limit.Quantity,
+		Period:      period,
+		PeriodStart: time.Now(),
+		PeriodSpent: 0,
+	}
+	a.logControl(ControlSetSpendingLimit, limit.Asset, note)
+	set_StageOnly(ctx, cloned, id, a)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Note:    note,
+		Payload: &SetSpendingLimitTrace{ID: id, Limit: limit, Period: period.String()},
+	})
+}
+
+func RemoveSpendingLimit(
+	ctx context.Context,
+	addr gov.Address,
+	id AccountID,
+	asset Asset,
+	note string,
+
+) {
+	cloned := gov.Clone(ctx, addr)
+	RemoveSpendingLimit_StageOnly(ctx, cloned, id, asset, note)
+	proto.Commitf(ctx, cloned, "account_remove_spending_limit", "remove %v spending limit from account %v (%v)", asset, id, note)
+	cloned.Push(ctx)
+}
+
+func RemoveSpendingLimit_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id AccountID,
+	asset Asset,
+	note string,
+
+) {
+	a := Get_Local(ctx, cloned, id)
+	_, ok := a.Limits[asset]
+	must.Assertf(ctx, ok, "account %v has no spending limit for %v", id, asset)
+	delete(a.Limits, asset)
+	a.logControl(ControlRemoveLimit, asset, note)
+	set_StageOnly(ctx, cloned, id, a)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Note:    note,
+		Payload: &RemoveSpendingLimitTrace{ID: id, Asset: asset},
+	})
+}
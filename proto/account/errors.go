@@ -0,0 +1,9 @@
+package account
+
+import "errors"
+
+var (
+	ErrInsufficientFunds     = errors.New("insufficient funds")
+	ErrAccountFrozen         = errors.New("account is frozen")
+	ErrSpendingLimitExceeded = errors.New("spending limit exceeded")
+)
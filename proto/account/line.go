@@ -1,5 +1,7 @@
 package account
 
+import "strings"
+
 // abc:123+def:a/b/c
 
 // type ID string // [a-zA-Z0-9/_]
@@ -17,3 +19,9 @@ func Pair(p, q string) Line {
 func Cat(p, q Line) Line {
 	return Line(p + "+" + q)
 }
+
+// LeadingPair returns the value of the first term of the line, if it is a pair with key p.
+func (x Line) LeadingPair(p string) (string, bool) {
+	first, _, _ := strings.Cut(string(x), "+")
+	return strings.CutPrefix(first, p+":")
+}
@@ -0,0 +1,86 @@
+package account
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+)
+
+func init() {
+	ctx := context.Background()
+	trace.Register[CreateTrace](ctx, 1)
+	trace.Register[TransferTrace](ctx, 1)
+	trace.Register[TransferOverDraftTrace](ctx, 1)
+	trace.Register[IssueTrace](ctx, 1)
+	trace.Register[BurnTrace](ctx, 1)
+	trace.Register[FreezeTrace](ctx, 1)
+	trace.Register[UnfreezeTrace](ctx, 1)
+	trace.Register[SetSpendingLimitTrace](ctx, 1)
+	trace.Register[RemoveSpendingLimitTrace](ctx, 1)
+}
+
+type CreateTrace struct {
+	ID    AccountID `json:"id"`
+	Owner AccountID `json:"owner"`
+}
+
+func (*CreateTrace) TraceOp() string { return "account_create" }
+
+type TransferTrace struct {
+	From   AccountID `json:"from"`
+	To     AccountID `json:"to"`
+	Amount Holding   `json:"amount"`
+}
+
+func (*TransferTrace) TraceOp() string { return "account_transfer" }
+
+type TransferOverDraftTrace struct {
+	From   AccountID `json:"from"`
+	To     AccountID `json:"to"`
+	Amount Holding   `json:"amount"`
+}
+
+func (*TransferOverDraftTrace) TraceOp() string { return "account_transfer_overdraft" }
+
+type IssueTrace struct {
+	From   AccountID `json:"from"`
+	To     AccountID `json:"to"`
+	Amount Holding   `json:"amount"`
+}
+
+func (*IssueTrace) TraceOp() string { return "account_issue" }
+
+type BurnTrace struct {
+	From   AccountID `json:"from"`
+	To     AccountID `json:"to"`
+	Amount Holding   `json:"amount"`
+}
+
+func (*BurnTrace) TraceOp() string { return "account_burn" }
+
+type FreezeTrace struct {
+	ID AccountID `json:"id"`
+}
+
+func (*FreezeTrace) TraceOp() string { return "account_freeze" }
+
+type UnfreezeTrace struct {
+	ID AccountID `json:"id"`
+}
+
+func (*UnfreezeTrace) TraceOp() string { return "account_unfreeze" }
+
+type SetSpendingLimitTrace struct {
+	ID     AccountID `json:"id"`
+	Limit  Holding   `json:"limit"`
+	Period string    `json:"period"` // as formatted by time.Duration
+}
+
+func (*SetSpendingLimitTrace) TraceOp() string { return "account_set_spending_limit" }
+
+type RemoveSpendingLimitTrace struct {
+	ID    AccountID `json:"id"`
+	Asset Asset     `json:"asset"`
+}
+
+func (*RemoveSpendingLimitTrace) TraceOp() string { return "account_remove_spending_limit" }
@@ -0,0 +1,65 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+var (
+	AlertNS = proto.RootNS.Append("alert")
+	StateNS = AlertNS.Append("state.json")
+
+	alertHistoryNS = history.HistoryNS.Append("alert")
+	alertHistory   = journal.Journal[*Alert]{Root: alertHistoryNS}
+)
+
+// Alert is an entry of the alert journal, reporting anomalous voting or treasury activity.
+type Alert struct {
+	Rule      RuleName             `json:"rule"`
+	Message   string               `json:"message"`
+	User      member.User          `json:"user,omitempty"`
+	Account   account.AccountID    `json:"account,omitempty"`
+	Ballot    ballotproto.BallotID `json:"ballot,omitempty"`
+	Value     float64              `json:"value"`     // the observed value
+	Threshold float64              `json:"threshold"` // the threshold exceeded by the value
+}
+
+// JournalKind classifies alerts by the rule which raised them.
+func (x *Alert) JournalKind() string {
+	if x == nil {
+		return ""
+	}
+	return string(x.Rule)
+}
+
+// State records the time of the last check, so that each check covers only new activity.
+type State struct {
+	LastCheck time.Time `json:"last_check"`
+}
+
+func List(
+	ctx context.Context,
+	addr gov.Address,
+	filter journal.Filter,
+) journal.Entries[*Alert] {
+
+	cloned := gov.Clone(ctx, addr)
+	return List_Local(ctx, cloned, filter)
+}
+
+func List_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	filter journal.Filter,
+) journal.Entries[*Alert] {
+
+	return alertHistory.List_Local(ctx, cloned.Tree(), filter)
+}
@@ -0,0 +1,71 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// FirstCheckPeriod is the period covered by the first check, after alerts are enabled.
+const FirstCheckPeriod = 24 * time.Hour
+
+func Check(
+	ctx context.Context,
+	addr gov.Address,
+) git.Change[form.None, []*Alert] {
+
+	cloned := gov.Clone(ctx, addr)
+	chg := Check_StageOnly(ctx, cloned)
+	return proto.CommitIfChanged(ctx, cloned, chg)
+}
+
+// Check_StageOnly runs the installed rules over the activity since the last check and logs the alerts they raise.
+// Nothing is checked, unless alerts are enabled in the settings.
+func Check_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+) git.Change[form.None, []*Alert] {
+
+	alerts := []*Alert{}
+	policy := etc.GetSettings_StageOnly(ctx, cloned).Alerts
+	if policy.IsActive() {
+
+		state, err := git.TryFromFile[State](ctx, cloned.Tree(), StateNS)
+		must.Assertf(ctx, err == nil || git.IsNotExist(err), "reading alert state (%v)", err)
+
+		w := &Window{Since: state.LastCheck, Until: time.Now()}
+		if w.Since.IsZero() {
+			w.Since = w.Until.Add(-FirstCheckPeriod)
+		}
+		w.Events = metric.ListFilter_Local(ctx, cloned, journal.Filter{Since: w.Since.Add(time.Nanosecond), Until: w.Until})
+
+		names, rules := ruleRegistry.List()
+		for i, rule := range rules {
+			for _, a := range rule.Check(ctx, cloned, policy, w) {
+				a.Rule = names[i]
+				alertHistory.Log_StageOnly(ctx, cloned.Tree(), a)
+				alerts = append(alerts, a)
+			}
+		}
+
+		state.LastCheck = w.Until
+		git.ToFileStage(ctx, cloned.Tree(), StateNS, state)
+	}
+
+	return git.NewChange[form.None, []*Alert](
+		fmt.Sprintf("Checked for anomalies, raised %d alerts", len(alerts)),
+		"alert_check",
+		form.None{},
+		alerts,
+		nil,
+	)
+}
@@ -0,0 +1,39 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/gov4git/v2/proto/mod"
+	"github.com/gov4git/lib4git/must"
+)
+
+type RuleName string
+
+// Window is the period covered by a check, and the metric events logged within it.
+type Window struct {
+	Since  time.Time // exclusive
+	Until  time.Time // inclusive
+	Events journal.Entries[*metric.Event]
+}
+
+// Rule detects anomalous activity within a window.
+// Rules return no alerts when their threshold in the policy is zero.
+type Rule interface {
+	Check(ctx context.Context, cloned gov.Cloned, policy *etc.AlertPolicy, w *Window) []*Alert
+}
+
+var ruleRegistry = mod.NewModuleRegistry[RuleName, Rule]()
+
+func Install(ctx context.Context, name RuleName, rule Rule) {
+	must.Assertf(ctx, name != "", "alert rule name must not be empty")
+	ruleRegistry.Set(ctx, name, rule)
+}
+
+func InstalledRules() []RuleName {
+	return ruleRegistry.ListKeys()
+}
@@ -0,0 +1,183 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/git"
+)
+
+const (
+	RuleVoteCharge    RuleName = "vote_charge"
+	RuleDailyIssuance RuleName = "daily_issuance"
+	RuleRejectedVotes RuleName = "rejected_votes"
+)
+
+func init() {
+	ctx := context.Background()
+	Install(ctx, RuleVoteCharge, voteChargeRule{})
+	Install(ctx, RuleDailyIssuance, dailyIssuanceRule{})
+	Install(ctx, RuleRejectedVotes, rejectedVotesRule{})
+}
+
+// voteChargeRule alerts on votes which charge a large part of the voter's balance.
+type voteChargeRule struct{}
+
+func (voteChargeRule) Check(ctx context.Context, cloned gov.Cloned, policy *etc.AlertPolicy, w *Window) []*Alert {
+
+	if policy.VoteChargePercent <= 0 {
+		return nil
+	}
+	plural := account.PluralAsset.MetricAsset()
+
+	// current balances of the voters
+	balances := map[metric.AccountID]float64{}
+	for _, e := range w.Events {
+		if v := e.Payload.Vote; v != nil {
+			for _, rcpt := range v.Receipts {
+				if _, ok := balances[rcpt.To]; !ok && account.Exists_Local(ctx, cloned, account.AccountID(rcpt.To)) {
+					balances[rcpt.To] = account.Get_Local(ctx, cloned, account.AccountID(rcpt.To)).Balance(account.PluralAsset).Quantity
+				}
+			}
+		}
+	}
+
+	// walk back in time, reconstructing the balance of each voter before each vote
+	alerts := []*Alert{}
+	for i := len(w.Events) - 1; i >= 0; i-- {
+		e := w.Events[i].Payload
+		if e.Account != nil {
+			for acct := range balances {
+				balances[acct] -= e.Account.BalanceChange(acct, plural)
+			}
+		}
+		if v := e.Vote; v != nil {
+			for _, rcpt := range v.Receipts {
+				after, ok := balances[rcpt.To]
+				if !ok || rcpt.Type != metric.ReceiptTypeCharge || rcpt.Amount.Asset != plural || rcpt.Amount.Quantity <= 0 {
+					continue
+				}
+				// the charge has been withdrawn from the balance, when the vote is logged
+				before := after + rcpt.Amount.Quantity
+				pct := 100 * rcpt.Amount.Quantity / before
+				if pct > policy.VoteChargePercent {
+					alerts = append(alerts, &Alert{
+						Message: fmt.Sprintf("a vote by %v charged %0.6f credits, %0.1f%% of their balance of %0.6f credits",
+							v.By, rcpt.Amount.Quantity, pct, before),
+						User:      member.User(v.By),
+						Account:   account.AccountID(rcpt.To),
+						Value:     pct,
+						Threshold: policy.VoteChargePercent,
+					})
+				}
+			}
+		}
+	}
+	slices.Reverse(alerts)
+	return alerts
+}
+
+// dailyIssuanceRule alerts when the credits issued within a day exceed a limit.
+// Each day raises at most one alert, when the limit is first exceeded.
+type dailyIssuanceRule struct{}
+
+func (dailyIssuanceRule) Check(ctx context.Context, cloned gov.Cloned, policy *etc.AlertPolicy, w *Window) []*Alert {
+
+	if policy.DailyIssuance <= 0 {
+		return nil
+	}
+
+	days := []time.Time{}
+	for _, e := range w.Events {
+		if issuedCredits(e.Payload) > 0 {
+			if day := dayOf(e.Stamp); !slices.Contains(days, day) {
+				days = append(days, day)
+			}
+		}
+	}
+
+	alerts := []*Alert{}
+	for _, day := range days {
+		filter := journal.Filter{
+			Since: day,
+			Until: day.AddDate(0, 0, 1).Add(-time.Nanosecond),
+			Kinds: []string{metric.KindAccount},
+		}
+		before, total := 0.0, 0.0
+		for _, e := range metric.ListFilter_Local(ctx, cloned, filter) {
+			q := issuedCredits(e.Payload)
+			total += q
+			if !e.Stamp.After(w.Since) {
+				before += q
+			}
+		}
+		if before <= policy.DailyIssuance && total > policy.DailyIssuance {
+			alerts = append(alerts, &Alert{
+				Message: fmt.Sprintf("%0.6f credits were issued on %s, more than the daily limit of %0.6f credits",
+					total, day.Format(time.DateOnly), policy.DailyIssuance),
+				Value:     total,
+				Threshold: policy.DailyIssuance,
+			})
+		}
+	}
+	return alerts
+}
+
+func issuedCredits(e *metric.Event) float64 {
+	if e.Account == nil || e.Account.Issue == nil || e.Account.Issue.Amount.Asset != account.PluralAsset.MetricAsset() {
+		return 0
+	}
+	return e.Account.Issue.Amount.Quantity
+}
+
+func dayOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// rejectedVotesRule alerts when many votes on a ballot are rejected within the window.
+// Rejected votes are not logged as metric events, so they are read from the tallies of the open ballots.
+type rejectedVotesRule struct{}
+
+func (rejectedVotesRule) Check(ctx context.Context, cloned gov.Cloned, policy *etc.AlertPolicy, w *Window) []*Alert {
+
+	if policy.RejectedVotes <= 0 {
+		return nil
+	}
+
+	alerts := []*Alert{}
+	for _, ad := range ballotproto.FilterOpenClosedAds(false, ballotapi.List_Local(ctx, cloned)) {
+		tally, err := git.TryFromFile[ballotproto.Tally](ctx, cloned.Tree(), ad.ID.TallyNS())
+		if err != nil {
+			continue
+		}
+		n := 0
+		for _, rejected := range tally.RejectedVotes {
+			for _, r := range rejected {
+				if r.Time.After(w.Since) && !r.Time.After(w.Until) {
+					n++
+				}
+			}
+		}
+		if n > policy.RejectedVotes {
+			alerts = append(alerts, &Alert{
+				Message: fmt.Sprintf("%d votes on ballot %v were rejected since %s",
+					n, ad.ID, w.Since.UTC().Format(time.DateTime)),
+				Ballot:    ad.ID,
+				Value:     float64(n),
+				Threshold: float64(policy.RejectedVotes),
+			})
+		}
+	}
+	return alerts
+}
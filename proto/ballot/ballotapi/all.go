@@ -8,6 +8,7 @@ import (
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
@@ -19,25 +20,25 @@ func TallyAll(
 	ctx context.Context,
 	addr gov.OwnerAddress,
 	maxPar int,
-) git.Change[form.Map, []ballotproto.Tally] {
+) (git.Change[form.Map, []ballotproto.Tally], mail.Rejections) {
 
 	base.Infof("fetching and tallying community votes ...")
 
 	govOwner := gov.CloneOwner(ctx, addr)
-	chg := TallyAll_StageOnly(ctx, govOwner, maxPar)
+	chg, rejected := TallyAll_StageOnly(ctx, govOwner, maxPar)
 	if len(chg.Result) == 0 {
-		return chg
+		return chg, rejected
 	}
 	proto.Commit(ctx, govOwner.Public.Tree(), chg)
 	govOwner.Public.Push(ctx)
-	return chg
+	return chg, rejected
 }
 
 func TallyAll_StageOnly(
 	ctx context.Context,
 	cloned gov.OwnerCloned,
 	maxPar int,
-) git.Change[form.Map, []ballotproto.Tally] {
+) (git.Change[form.Map, []ballotproto.Tally], mail.Rejections) {
 
 	// list all open ballots
 	ads := ballotproto.FilterOpenClosedAds(false, List_Local(ctx, cloned.PublicClone()))
@@ -63,20 +64,31 @@ func TallyAll_StageOnly(
 	// perform tallies for all open ballots
 	tallyChanges := []git.Change[map[string]form.Form, ballotproto.Tally]{}
 	tallies := []ballotproto.Tally{}
+	rejected := mail.Rejections{}
+	strikeErrors := map[ballotproto.BallotID]form.Form{}
 	for _, pv := range participatingVoters {
-		if tallyChg, changed := TallyVotersCloned_StageOnly(ctx, cloned, pv.Ad.ID, pv.VoterAccounts, pv.VoterClones); changed {
+		tallyChg, changed, rej := tallyVotersCloned_StageOnly(ctx, cloned, pv.Ad.ID, pv.VoterAccounts, pv.VoterClones)
+		rejected = append(rejected, rej...)
+		if errs, ok := tallyChg.Query["strike_errors"]; ok {
+			strikeErrors[pv.Ad.ID] = errs
+		}
+		if changed {
 			tallyChanges = append(tallyChanges, tallyChg)
 			tallies = append(tallies, tallyChg.Result)
 		}
 	}
 
+	query := form.Map{}
+	if len(strikeErrors) > 0 {
+		query["strike_errors"] = strikeErrors
+	}
 	return git.NewChange(
 		fmt.Sprintf("Tallied votes on all ballots"),
 		"ballot_tally_all",
-		form.Map{},
+		query,
 		tallies,
 		form.ToForms(tallyChanges),
-	)
+	), rejected
 }
 
 func clonePar(ctx context.Context, userAccounts map[member.User]member.UserProfile, maxPar int) map[member.User]git.Cloned {
@@ -53,9 +53,7 @@ func Cancel_StageOnly(
 
 	// log
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "ballot_cancel",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"ad": ad, "outcome": chg.Result},
+		Payload: &CancelTrace{ID: id, Ad: ad, Outcome: chg.Result},
 	})
 
 	return chg
@@ -77,9 +77,7 @@ func Close_StageOnly(
 
 	// log
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "ballot_close",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"ad": ad, "outcome": chg.Result},
+		Payload: &CloseTrace{ID: id, Ad: ad, Outcome: chg.Result},
 	})
 
 	return chg
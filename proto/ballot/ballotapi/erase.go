@@ -7,10 +7,12 @@ import (
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
 )
 
 func Erase(
@@ -34,6 +36,7 @@ func Erase_StageOnly(
 
 ) git.Change[form.Map, bool] {
 
+	must.NoError(ctx, etc.CheckUnilateral_Local(ctx, gov.Cloned(cloned.PublicClone()), etc.OpBallotErase))
 	t := cloned.Public.Tree()
 
 	// verify ad is present
@@ -9,6 +9,7 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
 )
@@ -39,11 +40,23 @@ func fetchVotes(
 	id ballotproto.BallotID,
 	user member.User,
 	account member.UserProfile,
-) git.Change[form.Map, FetchedVotes] {
+) (git.Change[form.Map, FetchedVotes], mail.Rejections) {
 	userCloned := git.CloneOne(ctx, git.Address(account.PublicAddress))
 	return fetchVotesCloned(ctx, cloned, id, user, account, userCloned)
 }
 
+// FetchVotes_StageOnly fetches the pending votes of a user on a ballot, read from a clone of the user's public repo.
+func FetchVotes_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	id ballotproto.BallotID,
+	user member.User,
+	account member.UserProfile,
+	userCloned git.Cloned,
+) (git.Change[form.Map, FetchedVotes], mail.Rejections) {
+	return fetchVotesCloned(ctx, cloned, id, user, account, userCloned)
+}
+
 func fetchVotesCloned(
 	ctx context.Context,
 	cloned gov.OwnerCloned,
@@ -51,7 +64,22 @@ func fetchVotesCloned(
 	user member.User,
 	account member.UserProfile,
 	userCloned git.Cloned,
-) git.Change[form.Map, FetchedVotes] {
+) (git.Change[form.Map, FetchedVotes], mail.Rejections) {
+
+	voterPublicTree := userCloned.Tree()
+	topic := ballotproto.BallotTopic(id)
+
+	// refuse votes from a repo whose credentials are not the ones the voter is registered with
+	if err := mail.VerifySender_Local(ctx, voterPublicTree, account.ID); err != nil {
+		base.Infof("rejecting votes from user %v on ballot %v (%v)", user, id, err)
+		return git.NewChange(
+			fmt.Sprintf("Rejected votes from user %v on ballot %v", user, id),
+			"ballot_fetch_votes",
+			form.Map{"id": id, "user": user, "account": account},
+			FetchedVotes{},
+			nil,
+		), mail.RejectPending_Local(ctx, cloned.Public.Tree(), account.PublicAddress, voterPublicTree, topic, err)
+	}
 
 	fetched := FetchedVotes{}
 	var respond mail.Responder[ballotproto.VoteEnvelope, ballotproto.VoteEnvelope] = func(
@@ -72,13 +100,12 @@ func fetchVotesCloned(
 		return req, nil
 	}
 
-	voterPublicTree := userCloned.Tree()
-	mail.Respond_StageOnly[ballotproto.VoteEnvelope, ballotproto.VoteEnvelope](
+	_, rejected := mail.Respond_StageOnly[ballotproto.VoteEnvelope, ballotproto.VoteEnvelope](
 		ctx,
 		cloned.IDOwnerCloned(),
 		account.PublicAddress,
 		voterPublicTree,
-		ballotproto.BallotTopic(id),
+		topic,
 		respond,
 	)
 
@@ -88,5 +115,5 @@ func fetchVotesCloned(
 		form.Map{"id": id, "user": user, "account": account},
 		fetched,
 		nil,
-	)
+	), rejected
 }
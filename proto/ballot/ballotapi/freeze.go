@@ -47,9 +47,7 @@ func Freeze_StageOnly(
 	git.ToFileStage(ctx, t, id.AdNS(), ad)
 
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "ballot_freeze",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"ad": ad},
+		Payload: &FreezeTrace{ID: id, Ad: ad},
 	})
 
 	return git.NewChangeNoResult(fmt.Sprintf("Freeze ballot %v", id), "ballot_freeze")
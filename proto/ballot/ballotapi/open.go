@@ -118,9 +118,7 @@ func Open_StageOnly(
 
 	// log
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "ballot_open",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"ad": ad},
+		Payload: &OpenTrace{ID: id, Ad: ad},
 	})
 
 	return git.NewChange(
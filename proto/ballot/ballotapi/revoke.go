@@ -0,0 +1,84 @@
+package ballotapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// StrikeRevokedVotes_StageOnly withdraws the accepted votes of a user on an open ballot,
+// which were signed by a key the user has since revoked and were accepted at or after the revocation time.
+// Acceptance times are recorded by the community when tallying, so unlike the times of the votes, they cannot be backdated.
+// The user's other votes are tallied again.
+// Signing keys are read from the messages received by the community, so votes whose messages have been compacted are not re-checked.
+// It returns the number of struck votes, or an error if the received votes cannot be read, in which case no votes are struck.
+func StrikeRevokedVotes_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	ballotID ballotproto.BallotID,
+	user member.User,
+	voterPublic *git.Tree,
+
+) (int, error) {
+
+	chain := id.GetKeyChain(ctx, voterPublic)
+	if !chain.HasRevoked() {
+		return 0, nil
+	}
+
+	t := cloned.Public.Tree()
+	accepted := loadTally_Local(ctx, t, ballotID).AcceptedVotes[user]
+	if len(accepted) == 0 {
+		return 0, nil
+	}
+
+	// find the keys which signed the received votes
+	received, err := must.Try1(
+		func() mail.MsgEffects[id.Signed[mail.RequestEnvelope[ballotproto.VoteEnvelope]], id.Signed[mail.ResponseEnvelope[ballotproto.VoteEnvelope]]] {
+			r, _ := mail.ListReceived_Local[id.Signed[mail.RequestEnvelope[ballotproto.VoteEnvelope]], id.Signed[mail.ResponseEnvelope[ballotproto.VoteEnvelope]]](
+				ctx, voterPublic, t, ballotproto.BallotTopic(ballotID),
+			)
+			return r
+		},
+	)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil // no votes were received
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading votes received from user %v on ballot %v (%w)", user, ballotID, err)
+	}
+	signers := map[id.ID]id.Ed25519PublicKey{}
+	for _, r := range received {
+		for _, el := range r.Msg.Value.Request.Elections {
+			signers[el.VoteID] = r.Msg.PublicKeyEd25519
+		}
+	}
+
+	struck := 0
+	strike := func(el ballotproto.AcceptedElection) bool {
+		key, ok := signers[el.Vote.VoteID]
+		if !ok {
+			return false
+		}
+		since := chain.RevokedSince(key)
+		return since != nil && !el.Time.Before(*since)
+	}
+	for _, el := range accepted {
+		if strike(el) {
+			struck++
+		}
+	}
+	if struck > 0 {
+		withdrawVotes_StageOnly(ctx, cloned.PublicClone(), ballotID, user, strike, "vote is signed by a revoked key")
+	}
+	return struck, nil
+}
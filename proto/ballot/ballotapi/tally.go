@@ -11,7 +11,9 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/history/trace"
 	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
 	"github.com/gov4git/lib4git/must"
@@ -82,18 +84,47 @@ func TallyVotersCloned_StageOnly(
 
 ) (git.Change[form.Map, ballotproto.Tally], bool) {
 
+	chg, changed, _ := tallyVotersCloned_StageOnly(ctx, cloned, id, voterAccounts, votersCloned)
+	return chg, changed
+}
+
+func tallyVotersCloned_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	id ballotproto.BallotID,
+	voterAccounts map[member.User]member.UserProfile,
+	votersCloned map[member.User]git.Cloned,
+
+) (git.Change[form.Map, ballotproto.Tally], bool, mail.Rejections) {
+
 	var fetchedVotes FetchedVotes
+	rejected := mail.Rejections{}
+	struck := 0
+	strikeErrors := map[member.User]string{}
 	for user, account := range voterAccounts {
-		chg := fetchVotesCloned(ctx, cloned, id, user, account, votersCloned[user])
+		// votes accepted before the community learned that their key was revoked
+		n, err := StrikeRevokedVotes_StageOnly(ctx, cloned, id, user, votersCloned[user].Tree())
+		if err != nil {
+			base.Infof("striking revoked votes of user %v on ballot %v failed (%v)", user, id, err)
+			strikeErrors[user] = err.Error()
+		}
+		struck += n
+		chg, rej := fetchVotesCloned(ctx, cloned, id, user, account, votersCloned[user])
 		fetchedVotes = append(fetchedVotes, chg.Result...)
+		rejected = append(rejected, rej...)
 	}
 
-	return TallyFetchedVotes_StageOnly(
+	chg, changed := TallyFetchedVotes_StageOnly(
 		ctx,
 		cloned.PublicClone(),
 		id,
 		fetchedVotes,
 	)
+	// votes signed by revoked keys remain counted until they can be struck, so failures are reported
+	if len(strikeErrors) > 0 {
+		chg.Query["strike_errors"] = strikeErrors
+	}
+	return chg, changed || struck > 0, rejected
 }
 
 func TallyFetchedVotes_StageOnly(
@@ -137,16 +168,32 @@ func TallyFetchedVotes_StageOnly(
 		), true
 	}
 
+	// reject votes from users who are not members of the participant group, directly or through nested groups
+	fetchedVotes, nonParticipants := splitParticipantVotes_Local(ctx, cloned, ad, fetchedVotes)
+
 	updatedTally := policy.Tally(ctx, cloned, &ad, &currentTally, fetchedVotesToElections(fetchedVotes)).Result
+	if len(nonParticipants) > 0 {
+		if updatedTally.RejectedVotes == nil {
+			updatedTally.RejectedVotes = map[member.User]ballotproto.RejectedElections{}
+		}
+		for _, fv := range nonParticipants {
+			if _, ok := updatedTally.RejectedVotes[fv.Voter]; !ok {
+				updatedTally.RejectedVotes[fv.Voter] = currentTally.RejectedVotes[fv.Voter]
+			}
+		}
+		rejectFetchedVotesWithReason(
+			nonParticipants,
+			updatedTally.RejectedVotes,
+			fmt.Sprintf("voter is not a member of participant group %v", ad.Participants),
+		)
+	}
 
 	// write updated tally
 	git.ToFileStage(ctx, t, id.TallyNS(), updatedTally)
 
 	// log
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "ballot_tally",
-		Args:   trace.M{"ballot": id},
-		Result: trace.M{"ad": ad, "tally": updatedTally},
+		Payload: newTallyTrace(id, currentTally, updatedTally),
 	})
 
 	return git.NewChange(
@@ -175,16 +222,43 @@ func ReTally_StageOnly(
 }
 
 func rejectFetchedVotes(fv FetchedVotes, rej map[member.User]ballotproto.RejectedElections) {
+	rejectFetchedVotesWithReason(fv, rej, "ballot is frozen")
+}
+
+func rejectFetchedVotesWithReason(fv FetchedVotes, rej map[member.User]ballotproto.RejectedElections, reason string) {
 	for _, fv := range fv {
 		for _, el := range fv.Elections {
 			rej[fv.Voter] = append(
 				rej[fv.Voter],
-				ballotproto.RejectedElection{Time: time.Now(), Vote: el, Reason: "ballot is frozen"},
+				ballotproto.RejectedElection{Time: time.Now(), Vote: el, Reason: reason},
 			)
 		}
 	}
 }
 
+// splitParticipantVotes_Local separates the fetched votes of participants from those of users outside the participant group.
+func splitParticipantVotes_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad ballotproto.Ad,
+	fv FetchedVotes,
+
+) (participants FetchedVotes, nonParticipants FetchedVotes) {
+
+	isParticipant := map[member.User]bool{}
+	for _, u := range member.ListGroupUsers_Local(ctx, cloned, ad.Participants) {
+		isParticipant[u] = true
+	}
+	for _, v := range fv {
+		if isParticipant[v.Voter] {
+			participants = append(participants, v)
+		} else {
+			nonParticipants = append(nonParticipants, v)
+		}
+	}
+	return participants, nonParticipants
+}
+
 func loadTally_Local(
 	ctx context.Context,
 	t *git.Tree,
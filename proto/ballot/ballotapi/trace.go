@@ -0,0 +1,98 @@
+package ballotapi
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+func init() {
+	ctx := context.Background()
+	trace.Register[OpenTrace](ctx, 1)
+	trace.Register[FreezeTrace](ctx, 1)
+	trace.Register[UnfreezeTrace](ctx, 1)
+	trace.Register[TallyTrace](ctx, 2)
+	trace.Register[CloseTrace](ctx, 1)
+	trace.Register[CancelTrace](ctx, 1)
+}
+
+type OpenTrace struct {
+	ID ballotproto.BallotID `json:"id"`
+	Ad ballotproto.Ad       `json:"ad"`
+}
+
+func (*OpenTrace) TraceOp() string { return "ballot_open" }
+
+type FreezeTrace struct {
+	ID ballotproto.BallotID `json:"id"`
+	Ad ballotproto.Ad       `json:"ad"`
+}
+
+func (*FreezeTrace) TraceOp() string { return "ballot_freeze" }
+
+type UnfreezeTrace struct {
+	ID ballotproto.BallotID `json:"id"`
+	Ad ballotproto.Ad       `json:"ad"`
+}
+
+func (*UnfreezeTrace) TraceOp() string { return "ballot_unfreeze" }
+
+// TallyTrace records the changes of a tally, rather than the tally itself, to keep the trace small.
+// Version 1 recorded the ad and the full tally; only the ballot is decoded from it.
+type TallyTrace struct {
+	Ballot      ballotproto.BallotID               `json:"ballot"`
+	Voters      []member.User                      `json:"voters"`       // voters whose scores changed, sorted
+	ScoreDeltas map[member.User]map[string]float64 `json:"score_deltas"` // voter -> choice -> change of score
+}
+
+func (*TallyTrace) TraceOp() string { return "ballot_tally" }
+
+func newTallyTrace(id ballotproto.BallotID, before, after ballotproto.Tally) *TallyTrace {
+	deltas := map[member.User]map[string]float64{}
+	add := func(user member.User, choice string, d float64) {
+		if d == 0 {
+			return
+		}
+		if deltas[user] == nil {
+			deltas[user] = map[string]float64{}
+		}
+		deltas[user][choice] += d
+	}
+	for user, choices := range after.ScoresByUser {
+		for choice, ss := range choices {
+			add(user, choice, ss.Score-before.ScoresByUser[user][choice].Score)
+		}
+	}
+	for user, choices := range before.ScoresByUser {
+		for choice, ss := range choices {
+			if _, ok := after.ScoresByUser[user][choice]; !ok {
+				add(user, choice, -ss.Score)
+			}
+		}
+	}
+	voters := make([]member.User, 0, len(deltas))
+	for user := range deltas {
+		voters = append(voters, user)
+	}
+	slices.Sort(voters)
+	return &TallyTrace{Ballot: id, Voters: voters, ScoreDeltas: deltas}
+}
+
+type CloseTrace struct {
+	ID      ballotproto.BallotID `json:"id"`
+	Ad      ballotproto.Ad       `json:"ad"`
+	Outcome ballotproto.Outcome  `json:"outcome"`
+}
+
+func (*CloseTrace) TraceOp() string { return "ballot_close" }
+
+type CancelTrace struct {
+	ID      ballotproto.BallotID `json:"id"`
+	Ad      ballotproto.Ad       `json:"ad"`
+	Outcome ballotproto.Outcome  `json:"outcome"`
+}
+
+func (*CancelTrace) TraceOp() string { return "ballot_cancel" }
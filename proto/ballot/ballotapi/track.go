@@ -33,8 +33,8 @@ func FindVoterUser_Local(
 
 ) member.User {
 
-	voterCred := id.GetPublicCredentials(ctx, voterOwner.Public.Tree())
-	users := member.LookupUserByID_Local(ctx, cloned, voterCred.ID)
+	voterChain := id.GetKeyChain(ctx, voterOwner.Public.Tree())
+	users := member.LookupUserByID_Local(ctx, cloned, voterChain.IDs()...)
 	must.Assertf(ctx, len(users) > 0, "user not found in community")
 	return users[0]
 }
@@ -57,12 +57,12 @@ func Track_StageOnly(
 	tally := loadTally_Local(ctx, cloned.Tree(), ballotID)
 
 	// read the voter's log
-	govCred := id.GetPublicCredentials(ctx, cloned.Tree())
-	voteLogNS := ballotproto.VoteLogPath(govCred.ID, ballotID)
+	govID := id.GetRootID(ctx, cloned.Tree())
+	voteLogNS := ballotproto.VoteLogPath(govID, ballotID)
 	voteLog, err := git.TryFromFile[ballotproto.VoteLog](ctx, voterOwner.Public.Tree(), voteLogNS)
 	if git.IsNotExist(err) {
 		return ballotproto.VoterStatus{
-			GovID:         govCred.ID,
+			GovID:         govID,
 			GovAddress:    cloned.Address(),
 			BallotID:      ballotID,
 			AcceptedVotes: nil,
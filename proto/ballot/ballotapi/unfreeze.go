@@ -48,9 +48,7 @@ func Unfreeze_StageOnly(
 	git.ToFileStage(ctx, t, id.AdNS(), ad)
 
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "ballot_freeze",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"ad": ad},
+		Payload: &UnfreezeTrace{ID: id, Ad: ad},
 	})
 
 	return git.NewChangeNoResult(fmt.Sprintf("Unfreeze ballot %v", id), "ballot_unfreeze")
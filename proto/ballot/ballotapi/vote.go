@@ -2,13 +2,16 @@ package ballotapi
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
 	"github.com/gov4git/lib4git/must"
@@ -47,6 +50,11 @@ func Vote_StageOnly(
 	must.Assertf(ctx, !ad.Closed, "ballot is closed")
 	must.Assertf(ctx, !ad.Frozen, "ballot is frozen")
 
+	// frozen accounts cannot cast new votes
+	voter := member.FindClonedUser_Local(ctx, cloned, voterOwner)
+	voterAccount := member.UserAccountID(voter)
+	must.Assert(ctx, !account.IsFrozen_Local(ctx, cloned, voterAccount), fmt.Errorf("%w: %v", account.ErrAccountFrozen, voterAccount))
+
 	verifyElections(ctx, policy, voterAddr, cloned.Address(), voterOwner, cloned, ad, elections)
 	envelope := ballotproto.VoteEnvelope{
 		AdCommit:  git.Head(ctx, cloned.Repo()),
@@ -56,14 +64,14 @@ func Vote_StageOnly(
 
 	// record vote in voter's repo
 	voterTree := voterOwner.Public.Tree()
-	govCred := id.GetPublicCredentials(ctx, cloned.Tree())
-	voteLogNS := ballotproto.VoteLogPath(govCred.ID, ballotID)
+	govID := id.GetRootID(ctx, cloned.Tree())
+	voteLogNS := ballotproto.VoteLogPath(govID, ballotID)
 
 	// read current vote log
 	voteLog, err := git.TryFromFile[ballotproto.VoteLog](ctx, voterTree, voteLogNS)
 	if git.IsNotExist(err) {
 		voteLog = ballotproto.VoteLog{
-			GovID:         govCred.ID,
+			GovID:         govID,
 			GovAddress:    cloned.Address(),
 			BallotID:      ballotID,
 			VoteEnvelopes: nil,
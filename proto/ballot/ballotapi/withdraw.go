@@ -0,0 +1,109 @@
+package ballotapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// WithdrawVotes_StageOnly removes the accepted votes of a user from an open ballot,
+// refunds the user's charges from the ballot escrow and re-tallies the ballot.
+// The withdrawn votes are kept in the tally as rejected votes with the given reason.
+// It returns the refunded amount, which is zero if the user has not voted.
+func WithdrawVotes_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ballotproto.BallotID,
+	user member.User,
+	reason string,
+
+) account.Holding {
+
+	return withdrawVotes_StageOnly(ctx, cloned, id, user, func(ballotproto.AcceptedElection) bool { return true }, reason)
+}
+
+// withdrawVotes_StageOnly withdraws the accepted votes of a user selected by strike.
+// All of the user's charges are refunded, and the votes which are not struck are tallied again.
+func withdrawVotes_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ballotproto.BallotID,
+	user member.User,
+	strike func(ballotproto.AcceptedElection) bool,
+	reason string,
+
+) account.Holding {
+
+	t := cloned.Tree()
+	ad, policy := ballotio.LoadAdPolicy_Local(ctx, t, id)
+	must.Assertf(ctx, !ad.Closed, "ballot is closed")
+
+	tally := loadTally_Local(ctx, t, id)
+	accepted, voted := tally.AcceptedVotes[user]
+	charge := tally.Charges[user]
+	refund := account.H(account.PluralAsset, charge)
+	if !voted && charge == 0 {
+		return refund
+	}
+
+	// refund escrowed charges
+	if charge != 0 {
+		account.Transfer_StageOnly(
+			ctx,
+			cloned,
+			ballotproto.BallotEscrowAccountID(id),
+			member.UserAccountID(user),
+			refund,
+			fmt.Sprintf("refund from withdrawing votes on ballot %v", id),
+		)
+	}
+
+	// remove the user's votes and re-tally
+	rejected := tally.RejectedVotes[user]
+	kept := ballotproto.Elections{}
+	for _, el := range accepted {
+		if strike(el) {
+			rejected = append(rejected, ballotproto.RejectedElection{Time: time.Now(), Vote: el.Vote, Reason: reason})
+		} else {
+			kept = append(kept, el.Vote)
+		}
+	}
+	delete(tally.AcceptedVotes, user)
+	delete(tally.ScoresByUser, user)
+	delete(tally.Charges, user)
+	delete(tally.RejectedVotes, user)
+	git.ToFileStage(ctx, t, id.TallyNS(), tally)
+	ReTally_StageOnly(ctx, cloned, id)
+
+	// tally the votes which are not withdrawn, charging the user again, and keep the times they were first accepted
+	tally = loadTally_Local(ctx, t, id)
+	if len(kept) > 0 {
+		tally = policy.Tally(ctx, cloned, &ad, &tally, map[member.User]ballotproto.Elections{user: kept}).Result
+		acceptedAt := map[string]time.Time{}
+		for _, el := range accepted {
+			acceptedAt[string(el.Vote.VoteID)] = el.Time
+		}
+		for i, el := range tally.AcceptedVotes[user] {
+			if at, ok := acceptedAt[string(el.Vote.VoteID)]; ok {
+				tally.AcceptedVotes[user][i].Time = at
+			}
+		}
+	}
+
+	// retain the withdrawn votes for the record
+	if tally.RejectedVotes == nil {
+		tally.RejectedVotes = map[member.User]ballotproto.RejectedElections{}
+	}
+	tally.RejectedVotes[user] = rejected
+	git.ToFileStage(ctx, t, id.TallyNS(), tally)
+
+	return refund
+}
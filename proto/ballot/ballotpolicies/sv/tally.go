@@ -121,7 +121,12 @@ func chargeUser(
 	note string,
 ) error {
 
-	return account.TryTransfer_StageOnly(
+	// new votes are spent by the user, subject to the controls of the user's account; refunds are not
+	transfer := account.TryTransfer_StageOnly
+	if charge > 0 {
+		transfer = account.TrySpend_StageOnly
+	}
+	return transfer(
 		ctx,
 		govCloned,
 		member.UserAccountID(user),
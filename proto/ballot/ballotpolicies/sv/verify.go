@@ -2,7 +2,9 @@ package sv
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
@@ -21,12 +23,16 @@ func (qv SV) VerifyElections(
 	elections ballotproto.Elections,
 ) {
 
-	voterCred := id.GetPublicCredentials(ctx, voterCloned.Public.Tree())
-	user := member.LookupUserByID_Local(ctx, govCloned, voterCred.ID)
+	voterChain := id.GetKeyChain(ctx, voterCloned.Public.Tree())
+	user := member.LookupUserByID_Local(ctx, govCloned, voterChain.IDs()...)
 	if len(user) == 0 {
-		must.Errorf(ctx, "cannot find user with id %v in the community", voterCred.ID)
+		must.Errorf(ctx, "cannot find user with id %v in the community", voterChain.Current.ID)
 	}
 
+	// frozen accounts cannot cast new votes
+	voterAccount := member.UserAccountID(user[0])
+	must.Assert(ctx, !account.IsFrozen_Local(ctx, govCloned, voterAccount), fmt.Errorf("%w: %v", account.ErrAccountFrozen, voterAccount))
+
 	// tally writes to the gov repo, but the repo is throw-away and won't be committed
 	qv.tally(ctx, govCloned, ad, prior, map[member.User]ballotproto.Elections{user[0]: elections}, true)
 }
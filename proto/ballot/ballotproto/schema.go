@@ -5,8 +5,16 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/gov"
 )
 
+const ballotEscrowAccountKey = "ballot_escrow"
+
 func BallotEscrowAccountID(ballotName BallotID) account.AccountID {
-	return account.AccountIDFromLine(account.Pair("ballot_escrow", ballotName.GitPath()))
+	return account.AccountIDFromLine(account.Pair(ballotEscrowAccountKey, ballotName.GitPath()))
+}
+
+// EscrowAccountBallot returns the ballot owning an account, if it is a ballot escrow account.
+func EscrowAccountBallot(id account.AccountID) (BallotID, bool) {
+	p, ok := account.Line(id).LeadingPair(ballotEscrowAccountKey)
+	return BallotID(p), ok
 }
 
 func BallotTopic(ballotName BallotID) string {
@@ -0,0 +1,68 @@
+package bureau
+
+import (
+	"errors"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+)
+
+var (
+	ErrUnrecognizedRequest = errors.New("unrecognized bureau request")
+	ErrNotRequestingUser   = errors.New("request is not on behalf of the requesting user")
+	ErrGroupNotFound       = errors.New("group does not exist")
+	ErrGroupNotOpen        = errors.New("group is not open")
+	ErrAlreadyMember       = errors.New("user is already a member of the group")
+	ErrNotMember           = errors.New("user is not a member of the group")
+	ErrInvalidSignature    = errors.New("signature is not valid")
+	ErrPropNotEditable     = errors.New("user property is not editable by members")
+	ErrIdentityTaken       = errors.New("identity is registered to another user")
+	ErrAddressTaken        = errors.New("address is registered to another user")
+)
+
+// ErrorCode is a stable, machine-readable classification of a failed bureau request.
+type ErrorCode string
+
+const (
+	ErrorCodeUnrecognizedRequest   ErrorCode = "unrecognized_request"
+	ErrorCodeNotRequestingUser     ErrorCode = "not_requesting_user"
+	ErrorCodeInsufficientFunds     ErrorCode = "insufficient_funds"
+	ErrorCodeAccountFrozen         ErrorCode = "account_frozen"
+	ErrorCodeSpendingLimitExceeded ErrorCode = "spending_limit_exceeded"
+	ErrorCodeGroupNotFound         ErrorCode = "group_not_found"
+	ErrorCodeGroupNotOpen          ErrorCode = "group_not_open"
+	ErrorCodeAlreadyMember         ErrorCode = "already_member"
+	ErrorCodeNotMember             ErrorCode = "not_member"
+	ErrorCodeInvalidSignature      ErrorCode = "invalid_signature"
+	ErrorCodePropNotEditable       ErrorCode = "prop_not_editable"
+	ErrorCodeIdentityTaken         ErrorCode = "identity_taken"
+	ErrorCodeAddressTaken          ErrorCode = "address_taken"
+	ErrorCodeOther                 ErrorCode = "other"
+)
+
+var errorCodes = []struct {
+	Err  error
+	Code ErrorCode
+}{
+	{ErrUnrecognizedRequest, ErrorCodeUnrecognizedRequest},
+	{ErrNotRequestingUser, ErrorCodeNotRequestingUser},
+	{account.ErrInsufficientFunds, ErrorCodeInsufficientFunds},
+	{account.ErrAccountFrozen, ErrorCodeAccountFrozen},
+	{account.ErrSpendingLimitExceeded, ErrorCodeSpendingLimitExceeded},
+	{ErrGroupNotFound, ErrorCodeGroupNotFound},
+	{ErrGroupNotOpen, ErrorCodeGroupNotOpen},
+	{ErrAlreadyMember, ErrorCodeAlreadyMember},
+	{ErrNotMember, ErrorCodeNotMember},
+	{ErrInvalidSignature, ErrorCodeInvalidSignature},
+	{ErrPropNotEditable, ErrorCodePropNotEditable},
+	{ErrIdentityTaken, ErrorCodeIdentityTaken},
+	{ErrAddressTaken, ErrorCodeAddressTaken},
+}
+
+func ErrorCodeOf(err error) ErrorCode {
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.Err) {
+			return ec.Code
+		}
+	}
+	return ErrorCodeOther
+}
@@ -0,0 +1,116 @@
+package bureau
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+func JoinGroup(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	group member.Group,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := JoinGroup_StageOnly(ctx, userOwner, govCloned, group)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	userOwner.Public.Push(ctx)
+	return chg
+}
+
+func JoinGroup_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	group member.Group,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	must.Assert(ctx, member.IsGroupOpen_Local(ctx, govCloned, group), fmt.Errorf("%w: %v", ErrGroupNotOpen, group))
+
+	request := Request{JoinGroup: &GroupRequest{Group: group}}
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Request to join group.",
+		"bureau_join_group",
+		form.Map{"group": group},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func LeaveGroup(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	group member.Group,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := LeaveGroup_StageOnly(ctx, userOwner, govCloned, group)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	userOwner.Public.Push(ctx)
+	return chg
+}
+
+func LeaveGroup_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	group member.Group,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	must.Assert(ctx, member.IsGroupOpen_Local(ctx, govCloned, group), fmt.Errorf("%w: %v", ErrGroupNotOpen, group))
+
+	request := Request{LeaveGroup: &GroupRequest{Group: group}}
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Request to leave group.",
+		"bureau_leave_group",
+		form.Map{"group": group},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func applyJoinGroup_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	user member.User,
+	req *GroupRequest,
+) {
+
+	cloned := govOwner.PublicClone()
+	must.Assert(ctx, member.IsGroup_Local(ctx, cloned, req.Group), fmt.Errorf("%w: %v", ErrGroupNotFound, req.Group))
+	must.Assert(ctx, member.IsGroupOpen_Local(ctx, cloned, req.Group), fmt.Errorf("%w: %v", ErrGroupNotOpen, req.Group))
+	must.Assert(ctx, !member.IsDirectMember_Local(ctx, cloned, user, req.Group), fmt.Errorf("%w: user %v, group %v", ErrAlreadyMember, user, req.Group))
+	member.AddMember_StageOnly(ctx, cloned, user, req.Group)
+	base.Infof("bureau: user %v joined group %v", user, req.Group)
+}
+
+func applyLeaveGroup_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	user member.User,
+	req *GroupRequest,
+) {
+
+	cloned := govOwner.PublicClone()
+	must.Assert(ctx, member.IsGroup_Local(ctx, cloned, req.Group), fmt.Errorf("%w: %v", ErrGroupNotFound, req.Group))
+	must.Assert(ctx, member.IsGroupOpen_Local(ctx, cloned, req.Group), fmt.Errorf("%w: %v", ErrGroupNotOpen, req.Group))
+	must.Assert(ctx, member.IsDirectMember_Local(ctx, cloned, user, req.Group), fmt.Errorf("%w: user %v, group %v", ErrNotMember, user, req.Group))
+	member.RemoveMember_StageOnly(ctx, cloned, user, req.Group)
+	base.Infof("bureau: user %v left group %v", user, req.Group)
+}
@@ -19,24 +19,24 @@ func Process(
 	ctx context.Context,
 	govAddr gov.OwnerAddress,
 	group member.Group,
-) git.ChangeNoResult {
+) (git.Change[form.Map, Results], mail.Rejections) {
 
 	base.Infof("fetching service requests from the community ...")
 
 	govOwner := gov.CloneOwner(ctx, govAddr)
-	chg, changed := Process_StageOnly(ctx, govOwner, group)
+	chg, changed, rejected := Process_StageOnly(ctx, govOwner, group)
 	if changed {
 		proto.Commit(ctx, govOwner.Public.Tree(), chg)
 		govOwner.Public.Push(ctx)
 	}
-	return chg
+	return chg, rejected
 }
 
 func Process_StageOnly(
 	ctx context.Context,
 	govOwner gov.OwnerCloned,
 	group member.Group,
-) (change git.ChangeNoResult, changed bool) {
+) (change git.Change[form.Map, Results], changed bool, rejected mail.Rejections) {
 
 	// list participating users
 	users := member.ListGroupUsers_Local(ctx, govOwner.PublicClone(), group)
@@ -47,111 +47,145 @@ func Process_StageOnly(
 		accounts[i] = member.GetUser_Local(ctx, govOwner.PublicClone(), user)
 	}
 
-	// fetch user requests
-	var fetchedReqs FetchedRequests
+	// fetch and process user requests
+	results := Results{}
+	rejected = mail.Rejections{}
 	for i, account := range accounts {
-		if fetched, err := fetchUserRequests(ctx, govOwner, users[i], account); err != nil {
+		if processed, rej, err := processUserRequests_StageOnly(ctx, govOwner, users[i], account); err != nil {
 			base.Infof("fetching bureau requests for user %v (%v)", users[i], err)
 		} else {
-			fetchedReqs = append(fetchedReqs, fetched.Result...)
+			results = append(results, processed.Result...)
+			rejected = append(rejected, rej...)
 		}
 	}
+	changed = len(results) > 0
 
-	// process requests
-	for _, fetched := range fetchedReqs {
-		nOK, nErr := processRequest_StageOnly(ctx, govOwner, fetched)
-		if nOK+nErr > 0 {
-			changed = true
-		}
-	}
-
-	return git.NewChangeNoResult(
+	return git.NewChange(
 		fmt.Sprintf("Process bureau requests of users in group %v", group),
 		"bureau_process",
-	), changed
+		form.Map{"group": group},
+		results,
+		nil,
+	), changed, rejected
 }
 
 func processRequest_StageOnly(
 	ctx context.Context,
 	govOwner gov.OwnerCloned,
-	fetched FetchedRequest,
-) (numOK int, numErr int) {
-	for _, req := range fetched.Requests {
-		if req.Transfer == nil {
-			numErr++
-			continue
-		}
-		if req.Transfer.FromUser != fetched.User {
-			base.Infof("bureau: invalid transfer request from user %v; origin of transfer is not the requesting user", fetched.User)
-			numErr++
-			continue
-		}
-		err := must.Try(func() {
-			account.Transfer_StageOnly(
-				ctx,
-				govOwner.PublicClone(),
-				member.UserAccountID(req.Transfer.FromUser),
-				member.UserAccountID(req.Transfer.ToUser),
-				account.H(account.PluralAsset, req.Transfer.Amount),
-				fmt.Sprintf("bureau transfer"),
-			)
-		})
-		if err != nil {
-			base.Infof("bureau: transfer error (%v)", err)
-			numErr++
-			continue
-		}
-		numOK++
-		base.Infof("bureau: transferred %v credits from user %v to user %v",
-			req.Transfer.Amount,
-			req.Transfer.FromUser,
-			req.Transfer.ToUser,
-		)
+	user member.User,
+	req Request,
+) Response {
+
+	err := must.Try(func() { applyRequest_StageOnly(ctx, govOwner, user, req) })
+	if err != nil {
+		base.Infof("bureau: request from user %v failed (%v)", user, err)
+	}
+	return NewResponse(err)
+}
+
+func applyRequest_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	user member.User,
+	req Request,
+) {
+
+	switch {
+	case req.Transfer != nil:
+		applyTransfer_StageOnly(ctx, govOwner, user, req.Transfer)
+	case req.JoinGroup != nil:
+		applyJoinGroup_StageOnly(ctx, govOwner, user, req.JoinGroup)
+	case req.LeaveGroup != nil:
+		applyLeaveGroup_StageOnly(ctx, govOwner, user, req.LeaveGroup)
+	case req.RotateAddress != nil:
+		applyRotateAddress_StageOnly(ctx, govOwner, user, req.RotateAddress)
+	case req.SetProp != nil:
+		applySetProp_StageOnly(ctx, govOwner, user, req.SetProp)
+	default:
+		must.Panic(ctx, ErrUnrecognizedRequest)
+	}
+}
+
+func applyTransfer_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	user member.User,
+	req *TransferRequest,
+) {
+
+	must.Assert(ctx, req.FromUser == user, fmt.Errorf("%w: origin of transfer %v is not the requesting user %v", ErrNotRequestingUser, req.FromUser, user))
+	account.Spend_StageOnly(
+		ctx,
+		govOwner.PublicClone(),
+		member.UserAccountID(req.FromUser),
+		member.UserAccountID(req.ToUser),
+		account.H(account.PluralAsset, req.Amount),
+		fmt.Sprintf("bureau transfer"),
+	)
+	base.Infof("bureau: transferred %v credits from user %v to user %v", req.Amount, req.FromUser, req.ToUser)
+}
+
+func processUserRequests_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	user member.User,
+	account member.UserProfile,
+) (git.Change[form.Map, Results], mail.Rejections, error) {
+
+	userPublic, err := git.TryCloneOne(ctx, git.Address(account.PublicAddress))
+	if err != nil {
+		return git.Change[form.Map, Results]{}, nil, err
 	}
-	return
+	chg, rejected := ProcessUserRequestsCloned_StageOnly(ctx, govOwner, user, account, userPublic.Tree())
+	return chg, rejected, nil
 }
 
-func fetchUserRequests(
+// ProcessUserRequestsCloned_StageOnly processes the pending requests of a user, read from a clone of the user's public repo.
+func ProcessUserRequestsCloned_StageOnly(
 	ctx context.Context,
 	govOwner gov.OwnerCloned,
 	user member.User,
 	account member.UserProfile,
-) (git.Change[form.Map, FetchedRequests], error) {
+	userPublic *git.Tree,
+) (git.Change[form.Map, Results], mail.Rejections) {
 
-	fetched := FetchedRequests{}
-	var respond mail.Responder[Request, Request] = func(
+	results := Results{}
+	var respond mail.Responder[Request, Response] = func(
 		ctx context.Context,
-		_ mail.SeqNo,
+		seqNo mail.SeqNo,
 		req Request,
-	) (resp Request, err error) {
-		fetched = append(fetched,
-			FetchedRequest{
-				User:     user,
-				Address:  account.PublicAddress,
-				Requests: Requests{req},
-			})
-		return req, nil
+	) (resp Response, err error) {
+		resp = processRequest_StageOnly(ctx, govOwner, user, req)
+		results = append(results, Result{User: user, SeqNo: seqNo, Request: req, Response: resp})
+		return resp, nil
 	}
 
-	userPublic, err := git.TryCloneOne(ctx, git.Address(account.PublicAddress))
-	if err != nil {
-		return git.Change[form.Map, FetchedRequests]{}, err
+	// refuse requests from a repo whose credentials are not the ones the user is registered with
+	if err := mail.VerifySender_Local(ctx, userPublic, account.ID); err != nil {
+		base.Infof("bureau: rejecting requests from user %v (%v)", user, err)
+		return git.NewChange(
+			fmt.Sprintf("Rejected requests from user %v", user),
+			"bureau_process_user_requests",
+			form.Map{"user": user, "account": account},
+			results,
+			nil,
+		), mail.RejectPending_Local(ctx, govOwner.Public.Tree(), account.PublicAddress, userPublic, BureauTopic, err)
 	}
 
-	recvOnly := mail.Respond_StageOnly[Request, Request](
+	recvOnly, rejected := mail.Respond_StageOnly[Request, Response](
 		ctx,
 		govOwner.IDOwnerCloned(),
 		account.PublicAddress,
-		userPublic.Tree(),
+		userPublic,
 		BureauTopic,
 		respond,
 	)
 
 	return git.NewChange(
-		fmt.Sprintf("Fetched requests from user %v", user),
-		"bureau_fetch_user_requests",
+		fmt.Sprintf("Processed requests from user %v", user),
+		"bureau_process_user_requests",
 		form.Map{"user": user, "account": account},
-		fetched,
+		results,
 		form.Forms{recvOnly},
-	), nil
+	), rejected
 }
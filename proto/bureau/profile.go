@@ -0,0 +1,156 @@
+package bureau
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// RotateAddress asks the community to register the public address of newAddr as the public address of the user at userAddr.
+// The request is sent from, and signed by, the user's current identity.
+// It is also signed by the new identity, proving that the user owns it.
+func RotateAddress(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	newAddr id.OwnerAddress,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	newOwner := id.CloneOwner(ctx, newAddr)
+	chg := RotateAddress_StageOnly(ctx, userOwner, govCloned, newOwner)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	userOwner.Public.Push(ctx)
+	return chg
+}
+
+func RotateAddress_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	newOwner id.OwnerCloned,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	user := member.FindClonedUser_Local(ctx, govCloned, userOwner)
+	newAddr := newOwner.Address().Public
+
+	rotation := AddressRotation{User: user, NewAddress: newAddr}
+	request := Request{
+		RotateAddress: &RotateAddressRequest{
+			Rotation: id.Sign(ctx, id.GetOwnerCredentials(ctx, userOwner), rotation),
+			Proof:    id.Sign(ctx, id.GetOwnerCredentials(ctx, newOwner), rotation),
+		},
+	}
+
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Request to rotate public address.",
+		"bureau_rotate_address",
+		form.Map{"user": user, "new_address": newAddr},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func applyRotateAddress_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	user member.User,
+	req *RotateAddressRequest,
+) {
+
+	cloned := govOwner.PublicClone()
+	profile := member.GetUser_Local(ctx, cloned, user)
+
+	// verify the rotation is signed by the user's current identity
+	signed := req.Rotation
+	must.Assert(ctx, signed.Verify(ctx), fmt.Errorf("%w: address rotation", ErrInvalidSignature))
+	oldChain := id.GetKeyChain(ctx, git.CloneOne(ctx, git.Address(profile.PublicAddress)).Tree())
+	must.Assert(ctx, oldChain.Has(profile.ID) && oldChain.CheckKey(signed.PublicKeyEd25519, nil) == nil, fmt.Errorf("%w: address rotation is not signed by the identity of user %v", ErrInvalidSignature, user))
+	must.Assert(ctx, signed.Value.User == user, fmt.Errorf("%w: address rotation is for user %v, not %v", ErrNotRequestingUser, signed.Value.User, user))
+
+	// verify the rotation is also signed by the new identity
+	proof := req.Proof
+	must.Assert(ctx, proof.Verify(ctx), fmt.Errorf("%w: address rotation proof", ErrInvalidSignature))
+	must.Assert(ctx, proof.Value == signed.Value, fmt.Errorf("%w: address rotation proof does not match the rotation", ErrInvalidSignature))
+	newTree := git.CloneOne(ctx, git.Address(signed.Value.NewAddress)).Tree()
+	newCred := id.GetPublicCredentials(ctx, newTree)
+	newChain := id.GetKeyChain(ctx, newTree)
+	must.Assert(ctx, newChain.CheckKey(proof.PublicKeyEd25519, nil) == nil, fmt.Errorf("%w: address rotation is not signed by the identity at %v", ErrInvalidSignature, signed.Value.NewAddress))
+
+	// verify the new identity and address are not registered to another user
+	for _, u := range member.LookupUserByID_Local(ctx, cloned, newChain.IDs()...) {
+		must.Assert(ctx, u == user, fmt.Errorf("%w: identity %v is registered to user %v", ErrIdentityTaken, newCred.ID, u))
+	}
+	for _, u := range member.LookupUserByAddress_Local(ctx, cloned, signed.Value.NewAddress) {
+		must.Assert(ctx, u == user, fmt.Errorf("%w: address %v is registered to user %v", ErrAddressTaken, signed.Value.NewAddress, u))
+	}
+
+	member.SetUserProfile_StageOnly(ctx, cloned, user, member.UserProfile{ID: newCred.ID, PublicAddress: signed.Value.NewAddress})
+	base.Infof("bureau: user %v moved to %v", user, signed.Value.NewAddress)
+}
+
+// SetProp asks the community to set a member-editable property of the user at userAddr.
+func SetProp(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	key string,
+	value any,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := SetProp_StageOnly(ctx, userOwner, govCloned, key, value)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	userOwner.Public.Push(ctx)
+	return chg
+}
+
+func SetProp_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	key string,
+	value any,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	settings := etc.GetSettings_StageOnly(ctx, govCloned)
+	must.Assert(ctx, settings.IsMemberEditableUserProp(key), fmt.Errorf("%w: %v", ErrPropNotEditable, key))
+	must.NoError(ctx, settings.ProfileSchema.ValidateUserProp(key, value))
+
+	request := Request{SetProp: &SetPropRequest{Key: key, Value: value}}
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Request to set user property.",
+		"bureau_set_prop",
+		form.Map{"key": key, "value": value},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func applySetProp_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	user member.User,
+	req *SetPropRequest,
+) {
+
+	cloned := govOwner.PublicClone()
+	settings := etc.GetSettings_StageOnly(ctx, cloned)
+	must.Assert(ctx, settings.IsMemberEditableUserProp(req.Key), fmt.Errorf("%w: %v", ErrPropNotEditable, req.Key))
+	member.SetUserProp_StageOnly[any](ctx, cloned, user, req.Key, req.Value)
+	base.Infof("bureau: user %v set property %v", user, req.Key)
+}
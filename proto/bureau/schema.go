@@ -1,14 +1,22 @@
 package bureau
 
 import (
+	"encoding/json"
+
 	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
 )
 
 const BureauTopic = "bureau"
 
+// Request holds exactly one bureau request.
 type Request struct {
-	Transfer *TransferRequest `json:"transfer"`
+	Transfer      *TransferRequest      `json:"transfer"`
+	JoinGroup     *GroupRequest         `json:"join_group,omitempty"`
+	LeaveGroup    *GroupRequest         `json:"leave_group,omitempty"`
+	RotateAddress *RotateAddressRequest `json:"rotate_address,omitempty"`
+	SetProp       *SetPropRequest       `json:"set_prop,omitempty"`
 }
 
 type Requests []Request
@@ -19,10 +27,105 @@ type TransferRequest struct {
 	Amount   float64     `json:"amount"`
 }
 
-type FetchedRequest struct {
-	User     member.User      `json:"requesting_user"`
-	Address  id.PublicAddress `json:"requesting_address"`
-	Requests Requests         `json:"requests"`
+// GroupRequest asks to join or leave an open group.
+type GroupRequest struct {
+	Group member.Group `json:"group"`
+}
+
+// AddressRotation is a statement by a user that their public repo has moved to a new address.
+type AddressRotation struct {
+	User       member.User      `json:"user"`
+	NewAddress id.PublicAddress `json:"new_address"`
+}
+
+// RotateAddressRequest carries an address rotation, signed by the user's current identity,
+// and a proof of ownership of the new identity: the same rotation, signed by the new identity.
+type RotateAddressRequest struct {
+	Rotation id.Signed[AddressRotation] `json:"rotation"`
+	Proof    id.Signed[AddressRotation] `json:"proof"`
+}
+
+// SetPropRequest asks to set a member-editable property of the requesting user.
+type SetPropRequest struct {
+	Key   string `json:"key"`
+	Value any    `json:"value"`
+}
+
+// ResponseStatus is the outcome of a request.
+type ResponseStatus string
+
+const (
+	StatusOK    ResponseStatus = "ok"
+	StatusError ResponseStatus = "error"
+	// StatusLegacy is the status of responses written before statuses were recorded. Their outcome is unknown.
+	StatusLegacy ResponseStatus = "legacy"
+)
+
+// Response is the community's answer to a single request.
+type Response struct {
+	Status ResponseStatus `json:"status"`
+	Error  *ResponseError `json:"error,omitempty"`
+}
+
+// UnmarshalJSON decodes responses without a status as legacy responses.
+func (x *Response) UnmarshalJSON(data []byte) error {
+	type plain Response
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	if p.Status == "" {
+		p.Status = StatusLegacy
+	}
+	*x = Response(p)
+	return nil
+}
+
+func (x Response) OK() bool {
+	return x.Status == StatusOK
+}
+
+type ResponseError struct {
+	Code ErrorCode `json:"code"`
+	Msg  string    `json:"msg"`
+}
+
+func NewResponse(err error) Response {
+	if err == nil {
+		return Response{Status: StatusOK}
+	}
+	return Response{Status: StatusError, Error: &ResponseError{Code: ErrorCodeOf(err), Msg: err.Error()}}
+}
+
+// Result is the outcome of processing a single request.
+type Result struct {
+	User     member.User `json:"requesting_user"`
+	SeqNo    mail.SeqNo  `json:"seqno"`
+	Request  Request     `json:"request"`
+	Response Response    `json:"response"`
+}
+
+type Results []Result
+
+func (x Results) NumOK() (n int) {
+	for _, r := range x {
+		if r.Response.OK() {
+			n++
+		}
+	}
+	return
+}
+
+func (x Results) NumErr() int {
+	return len(x) - x.NumOK()
+}
+
+// RequestStatus is the status of a request, as seen by the requesting user.
+type RequestStatus struct {
+	SeqNo    mail.SeqNo `json:"seqno"`
+	Request  Request    `json:"request"`
+	Answered bool       `json:"answered"`
+	Response *Response  `json:"response,omitempty"`
 }
 
-type FetchedRequests []FetchedRequest
+type RequestStatuses []RequestStatus
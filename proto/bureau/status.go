@@ -0,0 +1,42 @@
+package bureau
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/lib4git/git"
+)
+
+// Status returns the answered and pending bureau requests sent by the user at userAddr.
+func Status(
+	ctx context.Context,
+	userAddr id.PublicAddress,
+	govAddr gov.Address,
+) RequestStatuses {
+
+	return Status_Local(
+		ctx,
+		git.CloneOne(ctx, git.Address(userAddr)).Tree(),
+		gov.Clone(ctx, govAddr).Tree(),
+	)
+}
+
+func Status_Local(
+	ctx context.Context,
+	userTree *git.Tree,
+	govTree *git.Tree,
+) RequestStatuses {
+
+	answered, pending := mail.ConfirmCall_Local[Request, Response](ctx, userTree, govTree, BureauTopic)
+	statuses := RequestStatuses{}
+	for _, a := range answered {
+		resp := a.Effect
+		statuses = append(statuses, RequestStatus{SeqNo: a.SeqNo, Request: a.Msg, Answered: true, Response: &resp})
+	}
+	for _, p := range pending {
+		statuses = append(statuses, RequestStatus{SeqNo: p.SeqNo, Request: p.Msg, Answered: false})
+	}
+	return statuses
+}
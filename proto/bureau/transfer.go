@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/mail"
@@ -40,11 +41,11 @@ func Transfer_StageOnly(
 	amount float64,
 ) git.Change[form.Map, mail.RequestEnvelope[Request]] {
 
-	userCred := id.GetPublicCredentials(ctx, userOwner.Public.Tree())
+	userChain := id.GetKeyChain(ctx, userOwner.Public.Tree())
 
 	// find the user name of userAddr in the community repo
 	if fromUserOpt == "" {
-		us := member.LookupUserByID_Local(ctx, govCloned, userCred.ID)
+		us := member.LookupUserByID_Local(ctx, govCloned, userChain.IDs()...)
 		switch len(us) {
 		case 0:
 			must.Errorf(ctx, "%s not found in community %v", userAddr.Public, govCloned.Address())
@@ -55,6 +56,9 @@ func Transfer_StageOnly(
 		}
 	}
 
+	// fail early if the transfer would be rejected by the community
+	must.NoError(ctx, account.CheckSpend_Local(ctx, govCloned, member.UserAccountID(fromUserOpt), account.H(account.PluralAsset, amount)))
+
 	request := Request{
 		Transfer: &TransferRequest{
 			FromUser: fromUserOpt,
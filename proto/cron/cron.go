@@ -10,9 +10,12 @@ import (
 	"github.com/gov4git/gov4git/v2"
 	govgh "github.com/gov4git/gov4git/v2/github"
 	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/alert"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
 	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/metrics"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/multisig"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
@@ -32,6 +35,7 @@ func Cron(
 	communityFreq time.Duration, // frequency of fetching community votes and service requests
 	//
 	maxPar int, // parallelism for fetching community votes
+	dashboard DashboardOptions,
 ) form.Map {
 
 	cloned := gov.CloneOwner(ctx, govAddr)
@@ -80,19 +84,42 @@ func Cron(
 
 		// tally votes for all ballots from all community members
 		base.Infof("CRON: tallying community votes")
-		report["tally"] = ballotapi.TallyAll_StageOnly(ctx, cloned, maxPar).Result
+		tallyChg, rejected := ballotapi.TallyAll_StageOnly(ctx, cloned, maxPar)
+		report["tally"] = tallyChg.Result
+		if errs, ok := tallyChg.Query["strike_errors"]; ok {
+			report["tally_strike_errors"] = errs
+		}
+
+		// collect approvals of organizer proposals, including directives, and apply the approved ones
+		base.Infof("CRON: processing organizer proposals")
+		multisigChg, _, multisigRejected := multisig.Process_StageOnly(ctx, cloned)
+		report["multisig"] = multisigChg.Result
+		report["rejected_messages"] = len(rejected) + len(multisigRejected)
 
 		state.LastCommunityTally = time.Now()
 	}
 
 	motionapi.Pipeline_StageOnly(ctx, cloned)
 
+	// check for anomalous voting and treasury activity
+	base.Infof("CRON: checking for anomalies")
+	report["alerts"] = alert.Check_StageOnly(ctx, cloned.PublicClone()).Result
+
 	// display notices on github
 	govgh.DisplayNotices_StageOnly(ctx, repo, ghc, cloned.PublicClone())
 
 	// update community dashboard on github
-	base.Infof("CRON: publishing community dashboard")
-	govgh.PublishDashboard(ctx, repo, ghc, cloned.PublicClone())
+	if dashboard.GithubIssue {
+		base.Infof("CRON: publishing community dashboard")
+		govgh.PublishDashboard(ctx, repo, ghc, cloned.PublicClone())
+	}
+
+	// publish community dashboard as a static site
+	if dashboard.Site != nil {
+		base.Infof("CRON: publishing community dashboard site to %v", *dashboard.Site)
+		site := metrics.AssembleSite_Local(ctx, cloned.PublicClone(), metrics.TimeDailyLowerBound, metrics.Today().AddDate(0, 0, 1))
+		metrics.PublishSite(ctx, *dashboard.Site, site)
+	}
 
 	// prepare commit message
 	report["cron"] = state
@@ -139,6 +166,12 @@ type LatestChange struct {
 	Gov4GitVersion gov4git.VersionInfo `json:"gov4git_version"`
 }
 
+// DashboardOptions select where cron publishes the community dashboard.
+type DashboardOptions struct {
+	GithubIssue bool         // update the GitHub issue labelled gov4git:dashboard
+	Site        *git.Address // publish a static site to this branch, unless nil
+}
+
 type CronState struct {
 	LastGithubImport   time.Time `json:"last_github_import"`
 	LastCommunityTally time.Time `json:"last_community_tally"`
@@ -0,0 +1,336 @@
+package diff
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/alert"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+)
+
+// Diff summarizes the changes to the governance state between two commits of the governance repo.
+type Diff struct {
+	From git.CommitHash `json:"from"`
+	To   git.CommitHash `json:"to"`
+	//
+	UsersAdded   []member.User   `json:"users_added"`
+	UsersRemoved []member.User   `json:"users_removed"`
+	Balances     []BalanceChange `json:"balances"`
+	Ballots      []BallotChange  `json:"ballots"`
+	Motions      []MotionChange  `json:"motions"`
+	Journal      []JournalEntry  `json:"journal"` // entries logged after from, in the order they were logged
+}
+
+func (x *Diff) IsEmpty() bool {
+	return len(x.UsersAdded) == 0 && len(x.UsersRemoved) == 0 &&
+		len(x.Balances) == 0 && len(x.Ballots) == 0 && len(x.Motions) == 0 && len(x.Journal) == 0
+}
+
+// BalanceChange is the change of an account's holding of one asset.
+// Accounts which are created or removed change from or to zero.
+type BalanceChange struct {
+	Account account.AccountID `json:"account"`
+	Asset   account.Asset     `json:"asset"`
+	From    float64           `json:"from"`
+	To      float64           `json:"to"`
+	Delta   float64           `json:"delta"`
+}
+
+// Status values of ballots and motions. A ballot or motion which does not exist has no status.
+const (
+	StatusNone      = ""
+	StatusOpen      = "open"
+	StatusFrozen    = "frozen"
+	StatusClosed    = "closed"
+	StatusCancelled = "cancelled"
+)
+
+type BallotChange struct {
+	ID          ballotproto.BallotID `json:"id"`
+	Title       string               `json:"title"`
+	FromStatus  string               `json:"from_status"`
+	ToStatus    string               `json:"to_status"`
+	FromVoters  int                  `json:"from_voters"`
+	ToVoters    int                  `json:"to_voters"`
+	ScoreDeltas map[string]float64   `json:"score_deltas"` // choice -> change of score
+	ChargeDelta float64              `json:"charge_delta"` // change of the credits spent on the ballot
+}
+
+type MotionChange struct {
+	ID         motionproto.MotionID   `json:"id"`
+	Type       motionproto.MotionType `json:"type"`
+	Title      string                 `json:"title"`
+	FromStatus string                 `json:"from_status"`
+	ToStatus   string                 `json:"to_status"`
+}
+
+// Journal names.
+const (
+	JournalMetric = "metric"
+	JournalTrace  = "trace"
+	JournalAlert  = "alert"
+)
+
+type JournalEntry struct {
+	Journal string    `json:"journal"`
+	ID      id.ID     `json:"id"`
+	Stamp   time.Time `json:"stamp"`
+	Kind    string    `json:"kind"`
+	Note    string    `json:"note,omitempty"`
+}
+
+// Compare computes the changes between two revisions of the governance repo.
+// Revisions are commit hashes, revisions understood by git (such as HEAD~1), or times, as accepted by gov.WithSnapshot.
+func Compare(
+	ctx context.Context,
+	addr gov.Address,
+	from string,
+	to string,
+
+) *Diff {
+
+	fromCloned := gov.Clone(gov.WithSnapshot(ctx, from), addr)
+	toCloned := gov.Clone(gov.WithSnapshot(ctx, to), addr)
+	return Compare_Local(ctx, fromCloned, toCloned)
+}
+
+func Compare_Local(
+	ctx context.Context,
+	from gov.Cloned,
+	to gov.Cloned,
+
+) *Diff {
+
+	return &Diff{
+		From:         git.Head(ctx, from.Repo()),
+		To:           git.Head(ctx, to.Repo()),
+		UsersAdded:   diffUsers(ctx, to, from),
+		UsersRemoved: diffUsers(ctx, from, to),
+		Balances:     diffBalances(ctx, from, to),
+		Ballots:      diffBallots(ctx, from, to),
+		Motions:      diffMotions(ctx, from, to),
+		Journal:      diffJournal(ctx, from, to),
+	}
+}
+
+// diffUsers returns the users of x which are not users of y.
+func diffUsers(ctx context.Context, x, y gov.Cloned) []member.User {
+	ys := map[member.User]bool{}
+	for _, u := range member.ListGroupUsers_Local(ctx, y, member.Everybody) {
+		ys[u] = true
+	}
+	users := []member.User{}
+	for _, u := range member.ListGroupUsers_Local(ctx, x, member.Everybody) {
+		if !ys[u] {
+			users = append(users, u)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+	return users
+}
+
+func loadHoldings(ctx context.Context, cloned gov.Cloned) map[account.AccountID]account.AssetHoldings {
+	holdings := map[account.AccountID]account.AssetHoldings{}
+	for _, id := range account.List_Local(ctx, cloned) {
+		holdings[id] = account.Get_Local(ctx, cloned, id).Assets
+	}
+	return holdings
+}
+
+func diffBalances(ctx context.Context, from, to gov.Cloned) []BalanceChange {
+
+	fromHoldings, toHoldings := loadHoldings(ctx, from), loadHoldings(ctx, to)
+	changes := []BalanceChange{}
+	add := func(id account.AccountID, asset account.Asset) {
+		f := fromHoldings[id].Balance(asset).Quantity
+		t := toHoldings[id].Balance(asset).Quantity
+		if f != t {
+			changes = append(changes, BalanceChange{Account: id, Asset: asset, From: f, To: t, Delta: t - f})
+		}
+	}
+	for id, assets := range toHoldings {
+		for asset := range assets {
+			add(id, asset)
+		}
+	}
+	// holdings which have disappeared
+	for id, assets := range fromHoldings {
+		for asset := range assets {
+			if _, ok := toHoldings[id][asset]; !ok {
+				add(id, asset)
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Account != changes[j].Account {
+			return changes[i].Account < changes[j].Account
+		}
+		return changes[i].Asset < changes[j].Asset
+	})
+	return changes
+}
+
+func ballotStatus(ad ballotproto.Ad) string {
+	switch {
+	case ad.Cancelled:
+		return StatusCancelled
+	case ad.Closed:
+		return StatusClosed
+	case ad.Frozen:
+		return StatusFrozen
+	}
+	return StatusOpen
+}
+
+func loadBallots(ctx context.Context, cloned gov.Cloned) map[ballotproto.BallotID]ballotproto.AdTallyMargin {
+	ballots := map[ballotproto.BallotID]ballotproto.AdTallyMargin{}
+	for _, ad := range ballotapi.List_Local(ctx, cloned) {
+		ballots[ad.ID] = ballotapi.Show_Local(ctx, cloned, ad.ID)
+	}
+	return ballots
+}
+
+func diffBallots(ctx context.Context, from, to gov.Cloned) []BallotChange {
+
+	fromBallots, toBallots := loadBallots(ctx, from), loadBallots(ctx, to)
+	ids := map[ballotproto.BallotID]bool{}
+	for id := range fromBallots {
+		ids[id] = true
+	}
+	for id := range toBallots {
+		ids[id] = true
+	}
+
+	changes := []BallotChange{}
+	for id := range ids {
+		f, inFrom := fromBallots[id]
+		t, inTo := toBallots[id]
+		chg := BallotChange{ID: id, ScoreDeltas: map[string]float64{}}
+		if inFrom {
+			chg.Title = f.Ad.Title
+			chg.FromStatus = ballotStatus(f.Ad)
+			chg.FromVoters = f.Tally.NumVoters()
+		}
+		if inTo {
+			chg.Title = t.Ad.Title
+			chg.ToStatus = ballotStatus(t.Ad)
+			chg.ToVoters = t.Tally.NumVoters()
+		}
+		for choice, score := range t.Tally.Scores {
+			if d := score - f.Tally.Scores[choice]; d != 0 {
+				chg.ScoreDeltas[choice] = d
+			}
+		}
+		for choice, score := range f.Tally.Scores {
+			if _, ok := t.Tally.Scores[choice]; !ok && score != 0 {
+				chg.ScoreDeltas[choice] = -score
+			}
+		}
+		chg.ChargeDelta = t.Tally.Capitalization() - f.Tally.Capitalization()
+		if chg.FromStatus != chg.ToStatus || chg.FromVoters != chg.ToVoters || len(chg.ScoreDeltas) > 0 || chg.ChargeDelta != 0 {
+			changes = append(changes, chg)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+	return changes
+}
+
+func motionStatus(m motionproto.Motion) string {
+	switch {
+	case m.Cancelled:
+		return StatusCancelled
+	case m.Closed:
+		return StatusClosed
+	case m.Frozen:
+		return StatusFrozen
+	}
+	return StatusOpen
+}
+
+func loadMotions(ctx context.Context, cloned gov.Cloned) map[motionproto.MotionID]motionproto.Motion {
+	motions := map[motionproto.MotionID]motionproto.Motion{}
+	for _, m := range motionapi.ListMotions_Local(ctx, cloned.Tree()) {
+		motions[m.ID] = m
+	}
+	return motions
+}
+
+func diffMotions(ctx context.Context, from, to gov.Cloned) []MotionChange {
+
+	fromMotions, toMotions := loadMotions(ctx, from), loadMotions(ctx, to)
+	changes := []MotionChange{}
+	for id, t := range toMotions {
+		chg := MotionChange{ID: id, Type: t.Type, Title: t.Title, ToStatus: motionStatus(t)}
+		if f, ok := fromMotions[id]; ok {
+			chg.FromStatus = motionStatus(f)
+		}
+		if chg.FromStatus != chg.ToStatus {
+			changes = append(changes, chg)
+		}
+	}
+	for id, f := range fromMotions {
+		if _, ok := toMotions[id]; !ok {
+			changes = append(changes, MotionChange{ID: id, Type: f.Type, Title: f.Title, FromStatus: motionStatus(f)})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+	return changes
+}
+
+func diffJournal(ctx context.Context, from, to gov.Cloned) []JournalEntry {
+
+	entries := []JournalEntry{}
+	entries = append(entries, newEntries(JournalMetric,
+		metric.List_Local(ctx, from), metric.List_Local(ctx, to),
+		func(*metric.Event) string { return "" })...)
+	entries = append(entries, newEntries(JournalTrace,
+		trace.List_Local(ctx, from), trace.List_Local(ctx, to),
+		func(e *trace.Event) string {
+			if e == nil {
+				return ""
+			}
+			return e.Note
+		})...)
+	entries = append(entries, newEntries(JournalAlert,
+		alert.List_Local(ctx, from, journal.All), alert.List_Local(ctx, to, journal.All),
+		func(a *alert.Alert) string {
+			if a == nil {
+				return ""
+			}
+			return a.Message
+		})...)
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Stamp.Before(entries[j].Stamp) })
+	return entries
+}
+
+// newEntries returns the entries of a journal at to which are not in the journal at from.
+func newEntries[X form.Form](name string, from, to journal.Entries[X], note func(X) string) []JournalEntry {
+	seen := map[id.ID]bool{}
+	for _, e := range from {
+		seen[e.ID] = true
+	}
+	entries := []JournalEntry{}
+	for _, e := range to {
+		if !seen[e.ID] {
+			entries = append(entries, JournalEntry{Journal: name, ID: e.ID, Stamp: e.Stamp, Kind: e.Kind, Note: note(e.Payload)})
+		}
+	}
+	return entries
+}
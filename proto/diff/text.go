@@ -0,0 +1,87 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Text renders the diff for reading in a terminal or a CI log.
+func (x *Diff) Text() string {
+
+	var w bytes.Buffer
+	fmt.Fprintf(&w, "Changes from %s to %s\n", x.From, x.To)
+	if x.IsEmpty() {
+		fmt.Fprintf(&w, "\nNo changes.\n")
+		return w.String()
+	}
+
+	if len(x.UsersAdded) > 0 || len(x.UsersRemoved) > 0 {
+		fmt.Fprintf(&w, "\nUsers\n")
+		for _, u := range x.UsersAdded {
+			fmt.Fprintf(&w, "  + %s\n", u)
+		}
+		for _, u := range x.UsersRemoved {
+			fmt.Fprintf(&w, "  - %s\n", u)
+		}
+	}
+
+	if len(x.Balances) > 0 {
+		fmt.Fprintf(&w, "\nBalances\n")
+		for _, b := range x.Balances {
+			fmt.Fprintf(&w, "  %s %s: %0.6f -> %0.6f (%+0.6f)\n", b.Account, b.Asset, b.From, b.To, b.Delta)
+		}
+	}
+
+	if len(x.Ballots) > 0 {
+		fmt.Fprintf(&w, "\nBallots\n")
+		for _, b := range x.Ballots {
+			fmt.Fprintf(&w, "  %s %q: %s, voters %d -> %d", b.ID, b.Title, statusChange(b.FromStatus, b.ToStatus), b.FromVoters, b.ToVoters)
+			if b.ChargeDelta != 0 {
+				fmt.Fprintf(&w, ", charges %+0.6f", b.ChargeDelta)
+			}
+			fmt.Fprintf(&w, "\n")
+			choices := make([]string, 0, len(b.ScoreDeltas))
+			for c := range b.ScoreDeltas {
+				choices = append(choices, c)
+			}
+			sort.Strings(choices)
+			for _, c := range choices {
+				fmt.Fprintf(&w, "    score of %q %+0.6f\n", c, b.ScoreDeltas[c])
+			}
+		}
+	}
+
+	if len(x.Motions) > 0 {
+		fmt.Fprintf(&w, "\nMotions\n")
+		for _, m := range x.Motions {
+			fmt.Fprintf(&w, "  %s %s %q: %s\n", m.Type, m.ID, m.Title, statusChange(m.FromStatus, m.ToStatus))
+		}
+	}
+
+	if len(x.Journal) > 0 {
+		fmt.Fprintf(&w, "\nJournal\n")
+		for _, e := range x.Journal {
+			fmt.Fprintf(&w, "  %s %s %s", e.Stamp.UTC().Format(time.RFC3339), e.Journal, e.Kind)
+			if e.Note != "" {
+				fmt.Fprintf(&w, " (%s)", e.Note)
+			}
+			fmt.Fprintf(&w, "\n")
+		}
+	}
+
+	return w.String()
+}
+
+func statusChange(from, to string) string {
+	switch {
+	case from == StatusNone:
+		return "new, " + to
+	case to == StatusNone:
+		return "removed, was " + from
+	case from == to:
+		return to
+	}
+	return from + " -> " + to
+}
@@ -0,0 +1,34 @@
+package etc
+
+import "fmt"
+
+// AlertPolicy sets the thresholds of the anomaly alerts, which cron raises when checking recent activity.
+// A zero threshold disables its alert.
+type AlertPolicy struct {
+	// VoteChargePercent alerts when a single vote charges more than this percentage of the voter's balance.
+	VoteChargePercent float64 `json:"vote_charge_percent,omitempty"`
+	// DailyIssuance alerts when more than this many credits are issued within a day (UTC).
+	DailyIssuance float64 `json:"daily_issuance,omitempty"`
+	// RejectedVotes alerts when more than this many votes on a ballot are rejected between two checks.
+	RejectedVotes int `json:"rejected_votes,omitempty"`
+}
+
+func (x *AlertPolicy) IsActive() bool {
+	return x != nil && (x.VoteChargePercent > 0 || x.DailyIssuance > 0 || x.RejectedVotes > 0)
+}
+
+func (x *AlertPolicy) Validate() error {
+	if x == nil {
+		return nil
+	}
+	if x.VoteChargePercent < 0 || x.VoteChargePercent > 100 {
+		return fmt.Errorf("%w: vote charge percentage %v is not between 0 and 100", ErrInvalidAlertPolicy, x.VoteChargePercent)
+	}
+	if x.DailyIssuance < 0 {
+		return fmt.Errorf("%w: daily issuance %v is negative", ErrInvalidAlertPolicy, x.DailyIssuance)
+	}
+	if x.RejectedVotes < 0 {
+		return fmt.Errorf("%w: number of rejected votes %v is negative", ErrInvalidAlertPolicy, x.RejectedVotes)
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package etc
+
+import "errors"
+
+var (
+	ErrMultisigRequired = errors.New("operation requires multi-signature approval")
+	ErrInvalidMultisig  = errors.New("multi-signature policy is not valid")
+
+	ErrInvalidProfileSchema = errors.New("profile schema is not valid")
+	ErrUnknownUserProp      = errors.New("user property is not declared in the profile schema")
+	ErrUserPropType         = errors.New("user property value does not match its declared type")
+
+	ErrInvalidSweepPolicy = errors.New("balance sweep policy is not valid")
+
+	ErrInvalidAlertPolicy = errors.New("alert policy is not valid")
+)
@@ -0,0 +1,92 @@
+package etc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+)
+
+// Operations that can be placed under multi-signature control.
+const (
+	OpAccountIssue     = "account_issue"
+	OpMemberRemoveUser = "member_remove_user"
+	OpGithubDirective  = "github_directive"
+	OpBallotErase      = "ballot_erase"
+)
+
+var MultisigOps = []string{OpAccountIssue, OpMemberRemoveUser, OpGithubDirective, OpBallotErase}
+
+// MultisigPolicy requires that Threshold of the Signers approve each of the listed operations before it is applied.
+// Once a policy is in effect, changes to the policy itself also require approval.
+type MultisigPolicy struct {
+	Signers   []string `json:"signers"` // user names of the designated organizers
+	Threshold int      `json:"threshold"`
+	Ops       []string `json:"ops"`
+}
+
+func (x *MultisigPolicy) IsActive() bool {
+	return x != nil && x.Threshold > 0
+}
+
+func (x *MultisigPolicy) Requires(op string) bool {
+	return x.IsActive() && slices.Contains(x.Ops, op)
+}
+
+func (x *MultisigPolicy) IsSigner(user string) bool {
+	return x != nil && slices.Contains(x.Signers, user)
+}
+
+func (x *MultisigPolicy) Validate() error {
+	if x == nil {
+		return nil
+	}
+	if x.Threshold < 0 || x.Threshold > len(x.Signers) {
+		return fmt.Errorf("%w: threshold %d is not between 0 and the number of signers %d", ErrInvalidMultisig, x.Threshold, len(x.Signers))
+	}
+	for i, s := range x.Signers {
+		if s == "" || slices.Contains(x.Signers[:i], s) {
+			return fmt.Errorf("%w: signer %q is empty or repeated", ErrInvalidMultisig, s)
+		}
+	}
+	for _, op := range x.Ops {
+		if !slices.Contains(MultisigOps, op) {
+			return fmt.Errorf("%w: unknown operation %q", ErrInvalidMultisig, op)
+		}
+	}
+	return nil
+}
+
+func (x Settings) RequiresMultisig(op string) bool {
+	return x.Multisig.Requires(op)
+}
+
+type approvedCtxKey struct{}
+
+// WithApproval returns a context under which operations under multi-signature control are permitted.
+// It is used when applying approved proposals, and by operations which were approved earlier or are not performed by an organizer.
+func WithApproval(ctx context.Context) context.Context {
+	return context.WithValue(ctx, approvedCtxKey{}, true)
+}
+
+func isApproved(ctx context.Context) bool {
+	v, ok := ctx.Value(approvedCtxKey{}).(bool)
+	return ok && v
+}
+
+// CheckUnilateral_Local returns an error if op cannot be applied without multi-signature approval.
+func CheckUnilateral_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	op string,
+) error {
+
+	if isApproved(ctx) {
+		return nil
+	}
+	if GetSettings_StageOnly(ctx, cloned).RequiresMultisig(op) {
+		return fmt.Errorf("%w: %v", ErrMultisigRequired, op)
+	}
+	return nil
+}
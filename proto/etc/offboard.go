@@ -0,0 +1,34 @@
+package etc
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SweepPolicy determines what happens to the remaining balance of an offboarded user.
+type SweepPolicy string
+
+const (
+	SweepBurn   SweepPolicy = "burn"   // burn the balance
+	SweepIssuer SweepPolicy = "issuer" // return the balance to the issuance pool
+	SweepKeep   SweepPolicy = "keep"   // leave the balance in the user's account
+)
+
+var SweepPolicies = []SweepPolicy{SweepBurn, SweepIssuer, SweepKeep}
+
+// DefaultSweepPolicy applies when the settings do not specify a sweep policy.
+const DefaultSweepPolicy = SweepBurn
+
+func (x SweepPolicy) Validate() error {
+	if x == "" || slices.Contains(SweepPolicies, x) {
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidSweepPolicy, x)
+}
+
+func (x SweepPolicy) OrDefault() SweepPolicy {
+	if x == "" {
+		return DefaultSweepPolicy
+	}
+	return x
+}
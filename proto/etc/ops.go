@@ -2,6 +2,8 @@ package etc
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
@@ -27,6 +29,15 @@ func SetSettings_StageOnly(
 	config Settings,
 ) git.Change[Settings, form.None] {
 
+	// an active multi-signature policy can only be changed by an approved proposal
+	old := GetSettings_StageOnly(ctx, cloned)
+	if old.Multisig.IsActive() && !reflect.DeepEqual(old.Multisig, config.Multisig) && !isApproved(ctx) {
+		must.Panic(ctx, fmt.Errorf("%w: changing the multi-signature policy", ErrMultisigRequired))
+	}
+	must.NoError(ctx, config.Multisig.Validate())
+	must.NoError(ctx, config.ProfileSchema.Validate())
+	must.NoError(ctx, config.OffboardSweep.Validate())
+	must.NoError(ctx, config.Alerts.Validate())
 	git.ToFileStage[Settings](ctx, cloned.Tree(), SettingsNS, config)
 	return git.NewChange[Settings, form.None](
 		"Change settings",
@@ -0,0 +1,105 @@
+package etc
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Types of user properties.
+type PropType string
+
+const (
+	PropString PropType = "string"
+	PropNumber PropType = "number"
+	PropBool   PropType = "bool"
+	PropJSON   PropType = "json" // any JSON value
+)
+
+var PropTypes = []PropType{PropString, PropNumber, PropBool, PropJSON}
+
+// Visibility of user properties.
+// The governance repo is public, so visibility controls presentation, not access.
+type PropVisibility string
+
+const (
+	PropPublic    PropVisibility = "public"
+	PropOrganizer PropVisibility = "organizer" // shown only to organizers
+)
+
+var PropVisibilities = []PropVisibility{PropPublic, PropOrganizer}
+
+// UserPropSchema declares a user property.
+type UserPropSchema struct {
+	Key            string         `json:"key"`
+	Type           PropType       `json:"type"`
+	Visibility     PropVisibility `json:"visibility"`
+	MemberEditable bool           `json:"member_editable"` // members can set the property for themselves through the bureau
+	Description    string         `json:"description,omitempty"`
+}
+
+// ProfileSchema declares the user properties allowed in the community.
+// An empty schema allows any property.
+type ProfileSchema []UserPropSchema
+
+func (x ProfileSchema) IsEmpty() bool {
+	return len(x) == 0
+}
+
+func (x ProfileSchema) Lookup(key string) (UserPropSchema, bool) {
+	for _, p := range x {
+		if p.Key == key {
+			return p, true
+		}
+	}
+	return UserPropSchema{}, false
+}
+
+func (x ProfileSchema) Validate() error {
+	for i, p := range x {
+		if p.Key == "" || slices.ContainsFunc(x[:i], func(q UserPropSchema) bool { return q.Key == p.Key }) {
+			return fmt.Errorf("%w: property key %q is empty or repeated", ErrInvalidProfileSchema, p.Key)
+		}
+		if !slices.Contains(PropTypes, p.Type) {
+			return fmt.Errorf("%w: property %q has unknown type %q", ErrInvalidProfileSchema, p.Key, p.Type)
+		}
+		if !slices.Contains(PropVisibilities, p.Visibility) {
+			return fmt.Errorf("%w: property %q has unknown visibility %q", ErrInvalidProfileSchema, p.Key, p.Visibility)
+		}
+	}
+	return nil
+}
+
+// ValidateUserProp checks that the property is declared in the schema and that the value has the declared type.
+// Values are checked by their JSON encoding, so that values of Go types and values decoded from JSON are treated alike.
+func (x ProfileSchema) ValidateUserProp(key string, value any) error {
+	if x.IsEmpty() {
+		return nil
+	}
+	p, ok := x.Lookup(key)
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownUserProp, key)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%w: property %v cannot be encoded (%v)", ErrUserPropType, key, err)
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return fmt.Errorf("%w: property %v cannot be decoded (%v)", ErrUserPropType, key, err)
+	}
+	switch decoded.(type) {
+	case string:
+		ok = p.Type == PropString || p.Type == PropJSON
+	case float64:
+		ok = p.Type == PropNumber || p.Type == PropJSON
+	case bool:
+		ok = p.Type == PropBool || p.Type == PropJSON
+	default:
+		ok = p.Type == PropJSON
+	}
+	if !ok {
+		return fmt.Errorf("%w: property %v must be of type %v, got %s", ErrUserPropType, key, p.Type, data)
+	}
+	return nil
+}
@@ -8,6 +8,27 @@ var (
 )
 
 type Settings struct {
+	// MemberEditableUserProps lists the user properties that members can set for themselves through the bureau.
+	// Properties declared member-editable in the profile schema are also editable.
+	MemberEditableUserProps []string `json:"member_editable_user_props,omitempty"`
+	// ProfileSchema declares the user properties allowed in the community, their types and visibility.
+	ProfileSchema ProfileSchema `json:"profile_schema,omitempty"`
+	// OffboardSweep determines what happens to the balance of offboarded users; it defaults to burning.
+	OffboardSweep SweepPolicy `json:"offboard_sweep,omitempty"`
+	// Multisig lists the organizer operations which require approval by multiple designated organizers.
+	Multisig *MultisigPolicy `json:"multisig,omitempty"`
+	// Alerts sets the thresholds of the alerts on anomalous voting and treasury activity; alerts are disabled if unset.
+	Alerts *AlertPolicy `json:"alerts,omitempty"`
+}
+
+func (x Settings) IsMemberEditableUserProp(key string) bool {
+	for _, k := range x.MemberEditableUserProps {
+		if k == key {
+			return true
+		}
+	}
+	p, ok := x.ProfileSchema.Lookup(key)
+	return ok && p.MemberEditable
 }
 
 var DefaultSettings = Settings{}
@@ -0,0 +1,8 @@
+package gov
+
+import "errors"
+
+var (
+	ErrSnapshotReadOnly = errors.New("governance snapshots are read-only")
+	ErrSnapshotNotFound = errors.New("governance snapshot not found")
+)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
 )
 
 // Non-owner
@@ -16,7 +17,11 @@ func (a Address) Git() git.Address {
 }
 
 func Clone(ctx context.Context, addr Address) Cloned {
-	return Cloned(id.Clone(ctx, id.PublicAddress(addr)))
+	cloned := id.Clone(ctx, id.PublicAddress(addr))
+	if at := SnapshotAt(ctx); at != "" {
+		cloned = checkoutSnapshot(ctx, cloned, at)
+	}
+	return Cloned(cloned)
 }
 
 type Cloned id.Cloned
@@ -30,6 +35,9 @@ func (x Cloned) Address() Address {
 type OwnerAddress id.OwnerAddress
 
 func CloneOwner(ctx context.Context, addr OwnerAddress) OwnerCloned {
+	if SnapshotAt(ctx) != "" {
+		must.Panic(ctx, ErrSnapshotReadOnly)
+	}
 	cloned := OwnerCloned(id.CloneOwner(ctx, id.OwnerAddress(addr)))
 	invokePostCloners(ctx, cloned)
 	return cloned
@@ -0,0 +1,99 @@
+package gov
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+type snapshotKey struct{}
+
+// WithSnapshot returns a context in which governance repos are cloned as read-only snapshots.
+// The snapshot revision is a commit hash, or a time (RFC 3339 or YYYY-MM-DD) which resolves
+// to the latest commit on the governance branch that was made at or before that time.
+// Commit times are those recorded by the gov4git client which made the commit.
+// Owner clones, which are used to modify the governance repo, are refused within the context.
+func WithSnapshot(ctx context.Context, at string) context.Context {
+	return context.WithValue(ctx, snapshotKey{}, at)
+}
+
+// SnapshotAt returns the snapshot revision of the context, or the empty string.
+func SnapshotAt(ctx context.Context) string {
+	at, _ := ctx.Value(snapshotKey{}).(string)
+	return at
+}
+
+func checkoutSnapshot(ctx context.Context, cloned id.Cloned, at string) id.Cloned {
+	repo := cloned.Repo()
+	h := ResolveSnapshot(ctx, repo, at)
+	base.Infof("using snapshot %v of %v", h, cloned.Address().Repo)
+	wt, err := repo.Worktree()
+	must.NoError(ctx, err)
+	must.NoError(ctx, wt.Checkout(&gogit.CheckoutOptions{Hash: h, Force: true}))
+	cloned.Cloned = readOnlyCloned{cloned.Cloned}
+	return cloned
+}
+
+// ResolveSnapshot resolves a snapshot revision to a commit in the history of the checked out branch.
+func ResolveSnapshot(ctx context.Context, repo *git.Repository, at string) plumbing.Hash {
+
+	if t, ok := parseSnapshotTime(at); ok {
+		ref, err := repo.Head()
+		must.NoError(ctx, err)
+		iter, err := repo.Log(&gogit.LogOptions{From: ref.Hash(), Order: gogit.LogOrderCommitterTime})
+		must.NoError(ctx, err)
+		var found plumbing.Hash
+		err = iter.ForEach(func(c *object.Commit) error {
+			// commits made without an author time (at the unix epoch) cannot be placed in time
+			if c.Committer.When.Unix() > 0 && !c.Committer.When.After(t) {
+				found = c.Hash
+				return storer.ErrStop
+			}
+			return nil
+		})
+		must.NoError(ctx, err)
+		if found.IsZero() {
+			must.Panic(ctx, fmt.Errorf("%w: no commit at or before %v", ErrSnapshotNotFound, at))
+		}
+		return found
+	}
+
+	h, err := repo.ResolveRevision(plumbing.Revision(at))
+	if err != nil {
+		must.Panic(ctx, fmt.Errorf("%w: %v (%v)", ErrSnapshotNotFound, at, err))
+	}
+	return *h
+}
+
+func parseSnapshotTime(at string) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, at); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.DateOnly, at); err == nil {
+		// a date refers to the state at the end of the day
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), true
+	}
+	return time.Time{}, false
+}
+
+// readOnlyCloned refuses to push or pull, so that a snapshot is never written back or moved.
+type readOnlyCloned struct {
+	git.Cloned
+}
+
+func (x readOnlyCloned) Push(ctx context.Context) {
+	must.Panic(ctx, ErrSnapshotReadOnly)
+}
+
+func (x readOnlyCloned) Pull(ctx context.Context) {
+	must.Panic(ctx, ErrSnapshotReadOnly)
+}
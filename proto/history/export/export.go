@@ -0,0 +1,323 @@
+// Package export flattens the metric and trace history of a community into tables, for analysis outside of gov4git.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/lib4git/must"
+)
+
+type Kind string
+
+const (
+	KindMetric Kind = "metric"
+	KindTrace  Kind = "trace"
+)
+
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// Table is a list of rows with a fixed set of columns.
+// Row values are strings, float64, int, json.RawMessage or nil.
+type Table struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// Export writes the history of the given kind, logged between since and until, to w.
+// It returns the number of rows written.
+func Export(
+	ctx context.Context,
+	addr gov.Address,
+	kind Kind,
+	since time.Time,
+	until time.Time,
+	format Format,
+	w io.Writer,
+
+) int {
+
+	return Export_Local(ctx, gov.Clone(ctx, addr), kind, since, until, format, w)
+}
+
+func Export_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	kind Kind,
+	since time.Time,
+	until time.Time,
+	format Format,
+	w io.Writer,
+
+) int {
+
+	table := Tabulate_Local(ctx, cloned, kind, journal.Filter{Since: since, Until: until})
+	table.Write(ctx, format, w)
+	return len(table.Rows)
+}
+
+func Tabulate_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	kind Kind,
+	filter journal.Filter,
+
+) Table {
+
+	switch kind {
+	case KindMetric:
+		return MetricTable(ctx, metric.ListFilter_Local(ctx, cloned, filter))
+	case KindTrace:
+		return TraceTable(ctx, trace.ListFilter_Local(ctx, cloned, filter))
+	}
+	must.Errorf(ctx, "unknown history kind %q", kind)
+	return Table{}
+}
+
+func MetricTable(ctx context.Context, entries journal.Entries[*metric.Event]) Table {
+	index := columnIndex(ctx, MetricColumns)
+	t := Table{Columns: MetricColumns}
+	for _, e := range entries {
+		t.Rows = append(t.Rows, metricRows(index, e)...)
+	}
+	return t
+}
+
+func metricRows(index columns, e journal.Entry[*metric.Event]) [][]any {
+
+	row := newRow(index)
+	row.set("id", string(e.ID))
+	row.set("stamp", formatStamp(e.Stamp))
+	row.set("kind", e.Payload.JournalKind())
+
+	var receipts metric.Receipts
+	p := e.Payload
+	switch {
+	case p == nil:
+	case p.Join != nil:
+		row.set("action", "join")
+		row.set("user", string(p.Join.User))
+	case p.Motion != nil:
+		switch m := p.Motion; {
+		case m.Open != nil:
+			row.set("action", "open")
+			row.setMotion(m.Open.ID, m.Open.Type, m.Open.Policy)
+		case m.Close != nil:
+			row.set("action", "close")
+			row.setMotion(m.Close.ID, m.Close.Type, m.Close.Policy)
+			row.set("motion_decision", string(m.Close.Decision))
+			receipts = m.Close.Receipts
+		case m.Cancel != nil:
+			row.set("action", "cancel")
+			row.setMotion(m.Cancel.ID, m.Cancel.Type, m.Cancel.Policy)
+			receipts = m.Cancel.Receipts
+		}
+	case p.Account != nil:
+		switch a := p.Account; {
+		case a.Issue != nil:
+			row.set("action", "issue")
+			row.set("to_account", string(a.Issue.To))
+			row.setHolding("asset", "quantity", a.Issue.Amount)
+		case a.Burn != nil:
+			row.set("action", "burn")
+			row.set("from_account", string(a.Burn.From))
+			row.setHolding("asset", "quantity", a.Burn.Amount)
+		case a.Transfer != nil:
+			row.set("action", "transfer")
+			row.set("from_account", string(a.Transfer.From))
+			row.set("to_account", string(a.Transfer.To))
+			row.setHolding("asset", "quantity", a.Transfer.Amount)
+		}
+	case p.Vote != nil:
+		row.set("action", "vote")
+		row.set("user", string(p.Vote.By))
+		row.set("motion_policy", string(p.Vote.MotionPolicy))
+		row.set("ballot_policy", string(p.Vote.BallotPolicy))
+		row.set("vote_purpose", string(p.Vote.Purpose))
+		receipts = p.Vote.Receipts
+	}
+
+	if len(receipts) == 0 {
+		return [][]any{row.values}
+	}
+	rows := make([][]any, len(receipts))
+	for i, r := range receipts {
+		rr := row.clone()
+		rr.set("receipt_index", i)
+		rr.set("receipt_account", string(r.To))
+		rr.set("receipt_type", string(r.Type))
+		rr.setHolding("receipt_asset", "receipt_quantity", r.Amount)
+		rows[i] = rr.values
+	}
+	return rows
+}
+
+func TraceTable(ctx context.Context, entries journal.Entries[*trace.Event]) Table {
+	index := columnIndex(ctx, TraceColumns)
+	t := Table{Columns: TraceColumns}
+	for _, e := range entries {
+		row := newRow(index)
+		row.set("id", string(e.ID))
+		row.set("stamp", formatStamp(e.Stamp))
+		if e.Payload != nil {
+			row.set("op", e.Payload.Op)
+			row.set("note", e.Payload.Note)
+			if len(e.Payload.Args) > 0 {
+				row.setJSON("args", e.Payload.Args)
+			}
+			if len(e.Payload.Result) > 0 {
+				row.setJSON("result", e.Payload.Result)
+			}
+			if e.Payload.Version > 0 {
+				row.set("version", e.Payload.Version)
+			}
+			if e.Payload.Payload != nil {
+				row.setJSON("payload", e.Payload.Payload)
+			}
+		}
+		t.Rows = append(t.Rows, row.values)
+	}
+	return t
+}
+
+// Write encodes the table in the given format.
+func (t Table) Write(ctx context.Context, format Format, w io.Writer) {
+	switch format {
+	case FormatCSV:
+		t.writeCSV(ctx, w)
+	case FormatJSONL:
+		t.writeJSONL(ctx, w)
+	default:
+		must.Errorf(ctx, "unknown export format %q", format)
+	}
+}
+
+func (t Table) writeCSV(ctx context.Context, w io.Writer) {
+	cw := csv.NewWriter(w)
+	must.NoError(ctx, cw.Write(t.Columns))
+	rec := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i, v := range row {
+			rec[i] = formatCSV(v)
+		}
+		must.NoError(ctx, cw.Write(rec))
+	}
+	cw.Flush()
+	must.NoError(ctx, cw.Error())
+}
+
+func (t Table) writeJSONL(ctx context.Context, w io.Writer) {
+	var line bytes.Buffer
+	for _, row := range t.Rows {
+		line.Reset()
+		line.WriteByte('{')
+		for i, v := range row {
+			if i > 0 {
+				line.WriteByte(',')
+			}
+			k, err := json.Marshal(t.Columns[i])
+			must.NoError(ctx, err)
+			x, err := json.Marshal(v)
+			must.NoError(ctx, err)
+			line.Write(k)
+			line.WriteByte(':')
+			line.Write(x)
+		}
+		line.WriteString("}\n")
+		_, err := w.Write(line.Bytes())
+		must.NoError(ctx, err)
+	}
+}
+
+func formatCSV(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case json.RawMessage:
+		return string(x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func formatStamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// columnIndex maps the columns of a schema to their positions.
+func columnIndex(ctx context.Context, schema []string) columns {
+	index := make(map[string]int, len(schema))
+	for i, c := range schema {
+		index[c] = i
+	}
+	return columns{ctx: ctx, index: index, width: len(schema)}
+}
+
+// columns are the positions of the columns of a schema.
+type columns struct {
+	ctx   context.Context
+	index map[string]int
+	width int
+}
+
+// row assigns values to columns by name.
+type row struct {
+	columns
+	values []any
+}
+
+func newRow(c columns) *row {
+	return &row{columns: c, values: make([]any, c.width)}
+}
+
+// set fails if the column is not in the schema, so that misspelled columns do not drop values silently.
+func (r *row) set(column string, v any) {
+	i, ok := r.index[column]
+	must.Assertf(r.ctx, ok, "unknown export column %q", column)
+	if s, ok := v.(string); ok && s == "" {
+		v = nil
+	}
+	r.values[i] = v
+}
+
+func (r *row) setMotion(id metric.MotionID, typ string, policy metric.MotionPolicy) {
+	r.set("motion_id", string(id))
+	r.set("motion_type", typ)
+	r.set("motion_policy", string(policy))
+}
+
+func (r *row) setHolding(assetColumn, quantityColumn string, h metric.Holding) {
+	r.set(assetColumn, string(h.Asset))
+	r.set(quantityColumn, h.Quantity)
+}
+
+func (r *row) setJSON(column string, v any) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		buf, _ = json.Marshal(fmt.Sprint(v))
+	}
+	r.set(column, json.RawMessage(buf))
+}
+
+func (r *row) clone() *row {
+	return &row{columns: r.columns, values: append([]any(nil), r.values...)}
+}
@@ -0,0 +1,105 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/lib4git/must"
+)
+
+var testStamp = time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC)
+
+func TestMetricExport(t *testing.T) {
+	ctx := context.Background()
+	entries := journal.Entries[*metric.Event]{
+		{ID: "1", Stamp: testStamp, Payload: &metric.Event{Join: &metric.JoinEvent{User: "alice"}}},
+		{ID: "2", Stamp: testStamp, Payload: &metric.Event{Vote: &metric.VoteEvent{
+			By:           "alice",
+			Purpose:      metric.VotePurposeConcern,
+			MotionPolicy: "pmp",
+			BallotPolicy: "qv",
+			Receipts: metric.Receipts{
+				{To: "user:alice", Type: metric.ReceiptTypeCharge, Amount: metric.Holding{Asset: "plural", Quantity: 4}},
+				{To: "user:alice", Type: metric.ReceiptTypeRefund, Amount: metric.Holding{Asset: "plural", Quantity: 1.5}},
+			},
+		}}},
+	}
+	table := MetricTable(ctx, entries)
+	if len(table.Rows) != 3 {
+		t.Fatalf("expecting 3 rows, got %v", len(table.Rows))
+	}
+
+	var csv bytes.Buffer
+	table.Write(ctx, FormatCSV, &csv)
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if lines[0] != strings.Join(MetricColumns, ",") {
+		t.Errorf("unexpected header %v", lines[0])
+	}
+	if lines[1] != "1,2024-11-01T12:00:00Z,join,join,alice,,,,,,,,,,,,,,," {
+		t.Errorf("unexpected join row %v", lines[1])
+	}
+	if lines[3] != "2,2024-11-01T12:00:00Z,vote,vote,alice,,,pmp,,qv,concern,,,,,1,user:alice,refund,plural,1.5" {
+		t.Errorf("unexpected receipt row %v", lines[3])
+	}
+
+	var jsonl bytes.Buffer
+	table.Write(ctx, FormatJSONL, &jsonl)
+	rows := strings.Split(strings.TrimSpace(jsonl.String()), "\n")
+	if len(rows) != 3 {
+		t.Fatalf("expecting 3 lines, got %v", len(rows))
+	}
+	if !strings.HasPrefix(rows[1], `{"id":"2","stamp":"2024-11-01T12:00:00Z","kind":"vote"`) {
+		t.Errorf("expecting columns in schema order, got %v", rows[1])
+	}
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(rows[1]), &obj); err != nil {
+		t.Fatal(err)
+	}
+	if len(obj) != len(MetricColumns) || obj["receipt_quantity"] != 4.0 || obj["motion_id"] != nil {
+		t.Errorf("unexpected row %v", obj)
+	}
+}
+
+func TestTraceExport(t *testing.T) {
+	ctx := context.Background()
+	entries := journal.Entries[*trace.Event]{
+		{ID: "1", Stamp: testStamp, Payload: &trace.Event{Op: "user_add", Args: trace.M{"name": "alice"}}},
+		{ID: "2", Stamp: testStamp, Payload: &trace.Event{Op: "test_op", Version: 2, Payload: &testTrace{Name: "bob"}}},
+	}
+	var csv bytes.Buffer
+	TraceTable(ctx, entries).Write(ctx, FormatCSV, &csv)
+	want := "id,stamp,op,note,args,result,version,payload\n" +
+		"1,2024-11-01T12:00:00Z,user_add,,\"{\"\"name\"\":\"\"alice\"\"}\",,,\n" +
+		"2,2024-11-01T12:00:00Z,test_op,,,,2,\"{\"\"name\"\":\"\"bob\"\"}\"\n"
+	if csv.String() != want {
+		t.Errorf("expecting %q, got %q", want, csv.String())
+	}
+
+	var jsonl bytes.Buffer
+	TraceTable(ctx, entries).Write(ctx, FormatJSONL, &jsonl)
+	want = `{"id":"1","stamp":"2024-11-01T12:00:00Z","op":"user_add","note":null,"args":{"name":"alice"},"result":null,"version":null,"payload":null}` + "\n" +
+		`{"id":"2","stamp":"2024-11-01T12:00:00Z","op":"test_op","note":null,"args":null,"result":null,"version":2,"payload":{"name":"bob"}}` + "\n"
+	if jsonl.String() != want {
+		t.Errorf("expecting %q, got %q", want, jsonl.String())
+	}
+}
+
+func TestUnknownColumn(t *testing.T) {
+	ctx := context.Background()
+	if err := must.Try(func() { newRow(columnIndex(ctx, TraceColumns)).set("bogus", "x") }); err == nil {
+		t.Errorf("expecting unknown column to be refused")
+	}
+}
+
+type testTrace struct {
+	Name string `json:"name"`
+}
+
+func (*testTrace) TraceOp() string { return "test_op" }
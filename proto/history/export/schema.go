@@ -0,0 +1,42 @@
+package export
+
+// Export schemas. Columns are only ever appended, so that consumers can rely on their names and order.
+// Missing values are empty in CSV and null in JSONL. Times are in RFC 3339 format, in UTC.
+
+// MetricColumns are the columns of exported metric events.
+// Metric events with receipts (motion closures and cancellations, and votes) produce one row per receipt;
+// other events produce a single row with empty receipt columns.
+var MetricColumns = []string{
+	"id",               // journal entry id; shared by the rows of an event with several receipts
+	"stamp",            // time of the event
+	"kind",             // join, motion, account or vote
+	"action",           // join; open, close or cancel for motions; issue, burn or transfer for accounts; vote
+	"user",             // joining user, or voter
+	"motion_id",        // motion events
+	"motion_type",      // motion events
+	"motion_policy",    // motion events and votes
+	"motion_decision",  // motion closures
+	"ballot_policy",    // votes
+	"vote_purpose",     // votes: unspecified, concern or proposal
+	"from_account",     // account burns and transfers
+	"to_account",       // account issues and transfers
+	"asset",            // account events
+	"quantity",         // account events
+	"receipt_index",    // position of the receipt within the event, starting at 0
+	"receipt_account",  // account receiving or charged by the receipt
+	"receipt_type",     // refund, reward, bounty, charge or donation
+	"receipt_asset",    //
+	"receipt_quantity", //
+}
+
+// TraceColumns are the columns of exported trace events.
+var TraceColumns = []string{
+	"id",      // journal entry id
+	"stamp",   // time of the event
+	"op",      // operation
+	"note",    // free-form note
+	"args",    // arguments of legacy operations, as a JSON object
+	"result",  // result of legacy operations, as a JSON object
+	"version", // payload version; empty for legacy operations
+	"payload", // typed payload of the operation, as a JSON object; legacy operations are decoded when possible
+}
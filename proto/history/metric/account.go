@@ -30,3 +30,23 @@ type AccountTransferEvent struct {
 	To     AccountID `json:"to"`
 	Amount Holding   `json:"amount"`
 }
+
+// BalanceChange returns the change of an account's holding of an asset caused by the event.
+func (x *AccountEvent) BalanceChange(acct AccountID, asset Asset) float64 {
+	d := 0.0
+	if e := x.Issue; e != nil && e.To == acct && e.Amount.Asset == asset {
+		d += e.Amount.Quantity
+	}
+	if e := x.Burn; e != nil && e.From == acct && e.Amount.Asset == asset {
+		d -= e.Amount.Quantity
+	}
+	if e := x.Transfer; e != nil && e.Amount.Asset == asset {
+		if e.From == acct {
+			d -= e.Amount.Quantity
+		}
+		if e.To == acct {
+			d += e.Amount.Quantity
+		}
+	}
+	return d
+}
@@ -13,3 +13,27 @@ type Event struct {
 	Account *AccountEvent `json:"account,omitempty"`
 	Vote    *VoteEvent    `json:"vote,omitempty"`
 }
+
+// Kinds of metric events, used to filter the metric history.
+const (
+	KindJoin    = "join"
+	KindMotion  = "motion"
+	KindAccount = "account"
+	KindVote    = "vote"
+)
+
+func (x *Event) JournalKind() string {
+	switch {
+	case x == nil:
+		return ""
+	case x.Join != nil:
+		return KindJoin
+	case x.Motion != nil:
+		return KindMotion
+	case x.Account != nil:
+		return KindAccount
+	case x.Vote != nil:
+		return KindVote
+	}
+	return ""
+}
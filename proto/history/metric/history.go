@@ -37,7 +37,26 @@ func List_Local(
 	cloned gov.Cloned,
 ) journal.Entries[*Event] {
 
-	return metricHistory.Journal().List_Local(ctx, cloned.Tree())
+	return metricHistory.Journal().List_Local(ctx, cloned.Tree(), journal.All)
+}
+
+func ListFilter(
+	ctx context.Context,
+	addr gov.Address,
+	filter journal.Filter,
+) journal.Entries[*Event] {
+
+	cloned := gov.Clone(ctx, addr)
+	return ListFilter_Local(ctx, cloned, filter)
+}
+
+func ListFilter_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	filter journal.Filter,
+) journal.Entries[*Event] {
+
+	return metricHistory.Journal().List_Local(ctx, cloned.Tree(), filter)
 }
 
 type muteCtxKey struct{}
@@ -1,6 +1,8 @@
 package trace
 
 import (
+	"encoding/json"
+
 	"github.com/gov4git/gov4git/v2/proto/history"
 )
 
@@ -9,11 +11,53 @@ var (
 	traceHistory   = History{Root: traceHistoryNS}
 )
 
+// Event is a trace journal entry.
+// Events carry a typed payload, whose type is determined by the operation and the payload version.
+// Legacy events, logged before typed payloads were introduced, have version 0 and free-form arguments and result;
+// they are decoded into typed payloads when their operation is registered.
 type Event struct {
-	Op     string `json:"op"`
-	Note   string `json:"note"`
-	Args   M      `json:"args,omitempty"`
-	Result M      `json:"result,omitempty"`
+	Op      string  `json:"op"`
+	Version int     `json:"version,omitempty"`
+	Note    string  `json:"note"`
+	Payload Payload `json:"payload,omitempty"`
+	// legacy
+	Args   M `json:"args,omitempty"`
+	Result M `json:"result,omitempty"`
 }
 
 type M = map[string]any
+
+// JournalKind classifies trace events by their operation.
+func (x *Event) JournalKind() string {
+	if x == nil {
+		return ""
+	}
+	return x.Op
+}
+
+type eventJSON struct {
+	Op      string          `json:"op"`
+	Version int             `json:"version,omitempty"`
+	Note    string          `json:"note"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Args    M               `json:"args,omitempty"`
+	Result  M               `json:"result,omitempty"`
+}
+
+func (x *Event) UnmarshalJSON(data []byte) error {
+	var raw eventJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*x = Event{Op: raw.Op, Version: raw.Version, Note: raw.Note, Args: raw.Args, Result: raw.Result}
+	// a payload which does not match its schema should not prevent reading the rest of the journal
+	p, err := decodePayload(raw)
+	if err != nil {
+		p = nil
+		if len(raw.Payload) > 0 {
+			p = &Unknown{Op: raw.Op, Data: raw.Payload}
+		}
+	}
+	x.Payload = p
+	return nil
+}
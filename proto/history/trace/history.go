@@ -20,6 +20,10 @@ func Log_StageOnly(
 	if IsMuted(ctx) {
 		return
 	}
+	if event.Payload != nil {
+		event.Op = event.Payload.TraceOp()
+		event.Version = payloadVersion(event.Op)
+	}
 	traceHistory.Journal().Log_StageOnly(ctx, cloned.Tree(), event)
 }
 	
@@ -37,7 +41,26 @@ func List_Local(
 	cloned gov.Cloned,
 ) journal.Entries[*Event] {
 
-	return traceHistory.Journal().List_Local(ctx, cloned.Tree())
+	return traceHistory.Journal().List_Local(ctx, cloned.Tree(), journal.All)
+}
+
+func ListFilter(
+	ctx context.Context,
+	addr gov.Address,
+	filter journal.Filter,
+) journal.Entries[*Event] {
+
+	cloned := gov.Clone(ctx, addr)
+	return ListFilter_Local(ctx, cloned, filter)
+}
+
+func ListFilter_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	filter journal.Filter,
+) journal.Entries[*Event] {
+
+	return traceHistory.Journal().List_Local(ctx, cloned.Tree(), filter)
 }
 
 type muteCtxKey struct{}
@@ -0,0 +1,141 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gov4git/gov4git/v2/proto/mod"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Payload is the typed body of a trace event. Each operation has its own payload type.
+type Payload interface {
+	TraceOp() string
+}
+
+// Schema describes the payload of an operation.
+type Schema struct {
+	Op      string
+	Version int            // current payload version, starting at 1
+	New     func() Payload // returns a pointer to a zero payload of the current version
+	// Decode decodes payloads of older versions. If nil, all versions are decoded as the current one.
+	Decode func(version int, data []byte) (Payload, error)
+	// Legacy decodes the free-form arguments and result of legacy events.
+	// If nil, the arguments and result are merged and decoded as the current payload.
+	Legacy func(args, result M) (Payload, error)
+}
+
+var schemaRegistry = mod.NewModuleRegistry[string, Schema]()
+
+func Install(ctx context.Context, s Schema) {
+	must.Assertf(ctx, s.Version > 0, "trace payload version must be positive")
+	schemaRegistry.Set(ctx, s.Op, s)
+}
+
+// Register installs a payload type which decodes all versions and legacy events as its current version.
+func Register[P any, PP interface {
+	*P
+	Payload
+}](ctx context.Context, version int) {
+	op := PP(new(P)).TraceOp()
+	Install(ctx, Schema{Op: op, Version: version, New: func() Payload { return PP(new(P)) }})
+}
+
+func GetSchema(ctx context.Context, op string) Schema {
+	return schemaRegistry.Get(ctx, op)
+}
+
+func LookupSchema(op string) (Schema, bool) {
+	return schemaRegistry.Lookup(op)
+}
+
+// payloadVersion returns the current version of the payload of an operation.
+// Payloads of operations which are not registered are logged as the first version, rather than failing the operation,
+// and are read back as Unknown.
+func payloadVersion(op string) int {
+	s, ok := schemaRegistry.Lookup(op)
+	if !ok {
+		base.Infof("trace: payload of operation %v is not registered", op)
+		return 1
+	}
+	return s.Version
+}
+
+func ListSchemas() []Schema {
+	_, ss := schemaRegistry.List()
+	return ss
+}
+
+// Unknown holds the payload of an operation which is not registered, so that it is preserved when re-encoded.
+type Unknown struct {
+	Op   string
+	Data json.RawMessage
+}
+
+func (x *Unknown) TraceOp() string {
+	return x.Op
+}
+
+func (x *Unknown) MarshalJSON() ([]byte, error) {
+	return x.Data, nil
+}
+
+func decodePayload(raw eventJSON) (Payload, error) {
+
+	s, ok := schemaRegistry.Lookup(raw.Op)
+
+	// legacy events
+	if raw.Version == 0 {
+		if !ok || (raw.Args == nil && raw.Result == nil) {
+			return nil, nil
+		}
+		if s.Legacy != nil {
+			return s.Legacy(raw.Args, raw.Result)
+		}
+		merged := M{}
+		for k, v := range raw.Args {
+			merged[k] = v
+		}
+		for k, v := range raw.Result {
+			merged[k] = v
+		}
+		data, err := json.Marshal(merged)
+		if err != nil {
+			return nil, err
+		}
+		return decodeAs(s, data)
+	}
+
+	if len(raw.Payload) == 0 {
+		return nil, nil
+	}
+	if !ok {
+		return &Unknown{Op: raw.Op, Data: raw.Payload}, nil
+	}
+	if s.Decode != nil && raw.Version != s.Version {
+		return s.Decode(raw.Version, raw.Payload)
+	}
+	return decodeAs(s, raw.Payload)
+}
+
+func decodeAs(s Schema, data []byte) (Payload, error) {
+	p := s.New()
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DecodeLegacy decodes the arguments and result of a legacy event into a payload, by round-tripping them through JSON.
+func DecodeLegacy[P any](v any) (*P, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	p := new(P)
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
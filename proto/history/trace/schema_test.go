@@ -0,0 +1,103 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+type testRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (*testRename) TraceOp() string { return "test_rename" }
+
+func init() {
+	ctx := context.Background()
+	Install(ctx, Schema{
+		Op:      "test_rename",
+		Version: 2,
+		New:     func() Payload { return &testRename{} },
+		// version 1 called the fields old and new
+		Decode: func(version int, data []byte) (Payload, error) {
+			if version != 1 {
+				return nil, fmt.Errorf("unknown version %d", version)
+			}
+			var v1 struct {
+				Old string `json:"old"`
+				New string `json:"new"`
+			}
+			if err := json.Unmarshal(data, &v1); err != nil {
+				return nil, err
+			}
+			return &testRename{From: v1.Old, To: v1.New}, nil
+		},
+	})
+}
+
+func decodeEvent(t *testing.T, data string) Event {
+	var e Event
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestDecodeLegacy(t *testing.T) {
+	e := decodeEvent(t, `{"op":"test_rename","note":"n","args":{"from":"a"},"result":{"to":"b"}}`)
+	p, ok := e.Payload.(*testRename)
+	if !ok || p.From != "a" || p.To != "b" {
+		t.Errorf("unexpected payload %#v", e.Payload)
+	}
+	if e.Args["from"] != "a" || e.Note != "n" {
+		t.Errorf("legacy fields not preserved: %v", e)
+	}
+
+	// legacy events of unregistered operations have no payload
+	e = decodeEvent(t, `{"op":"test_unregistered","note":"","args":{"x":1}}`)
+	if e.Payload != nil || e.Args["x"] != 1.0 {
+		t.Errorf("unexpected event %v", e)
+	}
+}
+
+func TestDecodeVersioned(t *testing.T) {
+	data, err := json.Marshal(&Event{Op: "test_rename", Version: 2, Payload: &testRename{From: "a", To: "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := decodeEvent(t, string(data))
+	if p, ok := e.Payload.(*testRename); !ok || p.From != "a" || p.To != "b" || e.Version != 2 {
+		t.Errorf("unexpected event %v", e)
+	}
+
+	// older versions are upgraded
+	e = decodeEvent(t, `{"op":"test_rename","version":1,"note":"","payload":{"old":"a","new":"b"}}`)
+	if p, ok := e.Payload.(*testRename); !ok || p.From != "a" || p.To != "b" {
+		t.Errorf("unexpected payload %#v", e.Payload)
+	}
+}
+
+func TestDecodeUnknown(t *testing.T) {
+	raw := `{"op":"test_future","version":3,"note":"","payload":{"x":1}}`
+	e := decodeEvent(t, raw)
+	u, ok := e.Payload.(*Unknown)
+	if !ok || u.TraceOp() != "test_future" {
+		t.Fatalf("unexpected payload %#v", e.Payload)
+	}
+	// unknown payloads are preserved when re-encoded
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != raw {
+		t.Errorf("expecting %s, got %s", raw, data)
+	}
+
+	// payloads that do not match their schema do not fail decoding
+	e = decodeEvent(t, `{"op":"test_rename","version":2,"note":"","payload":{"from":1}}`)
+	if _, ok := e.Payload.(*Unknown); !ok {
+		t.Errorf("unexpected payload %#v", e.Payload)
+	}
+}
@@ -0,0 +1,178 @@
+package id
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var EncryptedPrivateCredentialsNS = PrivateNS.Append("private_credentials.enc.json")
+
+// PassphraseEnv is the environment variable holding the passphrase of encrypted private credentials.
+const PassphraseEnv = "GOV4GIT_PASSPHRASE"
+
+// PassphraseFunc returns the passphrase used to encrypt and decrypt private credentials.
+type PassphraseFunc func(ctx context.Context) (string, error)
+
+// Passphrase is consulted whenever encrypted private credentials are read or written.
+// It reads the passphrase from the environment by default. Interactive clients can replace it with a prompt.
+var Passphrase PassphraseFunc = PassphraseFromEnv
+
+func PassphraseFromEnv(ctx context.Context) (string, error) {
+	p, ok := os.LookupEnv(PassphraseEnv)
+	if !ok {
+		return "", fmt.Errorf("%w: set %s", ErrNoPassphrase, PassphraseEnv)
+	}
+	return p, nil
+}
+
+const argon2idKDF = "argon2id"
+
+// EncryptedPrivateCredentials holds private credentials encrypted with XChaCha20-Poly1305,
+// under a key derived from a passphrase with Argon2id.
+type EncryptedPrivateCredentials struct {
+	KDF        string     `json:"kdf"`
+	Salt       form.Bytes `json:"salt"`
+	Time       uint32     `json:"time"`
+	Memory     uint32     `json:"memory"`
+	Threads    uint8      `json:"threads"`
+	Nonce      form.Bytes `json:"nonce"`
+	Ciphertext form.Bytes `json:"ciphertext"`
+}
+
+func (x EncryptedPrivateCredentials) key(passphrase string) []byte {
+	return argon2.IDKey([]byte(passphrase), x.Salt, x.Time, x.Memory, x.Threads, chacha20poly1305.KeySize)
+}
+
+func EncryptPrivateCredentials(cred PrivateCredentials, passphrase string) (EncryptedPrivateCredentials, error) {
+	enc := EncryptedPrivateCredentials{
+		KDF:     argon2idKDF,
+		Salt:    make([]byte, 16),
+		Time:    1,
+		Memory:  64 * 1024,
+		Threads: 4,
+		Nonce:   make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	if _, err := rand.Read(enc.Salt); err != nil {
+		return enc, err
+	}
+	if _, err := rand.Read(enc.Nonce); err != nil {
+		return enc, err
+	}
+	plaintext, err := json.Marshal(cred)
+	if err != nil {
+		return enc, err
+	}
+	aead, err := chacha20poly1305.NewX(enc.key(passphrase))
+	if err != nil {
+		return enc, err
+	}
+	enc.Ciphertext = aead.Seal(nil, enc.Nonce, plaintext, nil)
+	return enc, nil
+}
+
+func DecryptPrivateCredentials(enc EncryptedPrivateCredentials, passphrase string) (PrivateCredentials, error) {
+	if enc.KDF != argon2idKDF {
+		return PrivateCredentials{}, fmt.Errorf("unsupported key derivation function %q", enc.KDF)
+	}
+	aead, err := chacha20poly1305.NewX(enc.key(passphrase))
+	if err != nil {
+		return PrivateCredentials{}, err
+	}
+	plaintext, err := aead.Open(nil, enc.Nonce, enc.Ciphertext, nil)
+	if err != nil {
+		return PrivateCredentials{}, ErrWrongPassphrase
+	}
+	var cred PrivateCredentials
+	if err := json.Unmarshal(plaintext, &cred); err != nil {
+		return PrivateCredentials{}, err
+	}
+	return cred, nil
+}
+
+// decrypted caches decrypted credentials by ciphertext, so that key derivation and prompting happen once per process.
+var decrypted sync.Map
+
+func decryptPrivateCredentials(ctx context.Context, enc EncryptedPrivateCredentials) PrivateCredentials {
+	if cred, ok := decrypted.Load(string(enc.Ciphertext)); ok {
+		return cred.(PrivateCredentials)
+	}
+	passphrase, err := Passphrase(ctx)
+	must.NoError(ctx, err)
+	cred, err := DecryptPrivateCredentials(enc, passphrase)
+	must.NoError(ctx, err)
+	decrypted.Store(string(enc.Ciphertext), cred)
+	return cred
+}
+
+func IsEncrypted_Local(ctx context.Context, privateTree *git.Tree) bool {
+	_, err := git.TreeStat(ctx, privateTree, EncryptedPrivateCredentialsNS)
+	return err == nil
+}
+
+// setPrivateCredentials_StageOnly writes private credentials, encrypting them if the private repo is encrypted.
+func setPrivateCredentials_StageOnly(ctx context.Context, privateTree *git.Tree, cred PrivateCredentials) {
+	if !IsEncrypted_Local(ctx, privateTree) {
+		git.ToFileStage(ctx, privateTree, PrivateCredentialsNS, cred)
+		return
+	}
+	passphrase, err := Passphrase(ctx)
+	must.NoError(ctx, err)
+	enc, err := EncryptPrivateCredentials(cred, passphrase)
+	must.NoError(ctx, err)
+	git.ToFileStage(ctx, privateTree, EncryptedPrivateCredentialsNS, enc)
+	decrypted.Store(string(enc.Ciphertext), cred)
+}
+
+// Encrypt replaces the plaintext private credentials of an identity with credentials encrypted under a passphrase.
+// Earlier commits of the private repo still contain the plaintext credentials.
+func Encrypt(ctx context.Context, ownerAddr OwnerAddress) {
+	ownerCloned := CloneOwner(ctx, ownerAddr)
+	Encrypt_Local(ctx, ownerCloned)
+	ownerCloned.Private.Push(ctx)
+}
+
+func Encrypt_Local(ctx context.Context, ownerCloned OwnerCloned) {
+	priv := ownerCloned.Private.Tree()
+	must.Assertf(ctx, !IsEncrypted_Local(ctx, priv), "private credentials are already encrypted")
+	cred := GetPrivateCredentials(ctx, priv)
+
+	passphrase, err := Passphrase(ctx)
+	must.NoError(ctx, err)
+	must.Assertf(ctx, passphrase != "", "passphrase must not be empty")
+	enc, err := EncryptPrivateCredentials(cred, passphrase)
+	must.NoError(ctx, err)
+
+	git.ToFileStage(ctx, priv, EncryptedPrivateCredentialsNS, enc)
+	_, err = git.TreeRemove(ctx, priv, PrivateCredentialsNS)
+	must.NoError(ctx, err)
+	proto.Commit(ctx, priv, git.NewChangeNoResult("Encrypted private credentials.", "id_encrypt"))
+}
+
+// Decrypt replaces the encrypted private credentials of an identity with plaintext credentials.
+func Decrypt(ctx context.Context, ownerAddr OwnerAddress) {
+	ownerCloned := CloneOwner(ctx, ownerAddr)
+	Decrypt_Local(ctx, ownerCloned)
+	ownerCloned.Private.Push(ctx)
+}
+
+func Decrypt_Local(ctx context.Context, ownerCloned OwnerCloned) {
+	priv := ownerCloned.Private.Tree()
+	must.Assertf(ctx, IsEncrypted_Local(ctx, priv), "private credentials are not encrypted")
+	cred := GetPrivateCredentials(ctx, priv)
+
+	git.ToFileStage(ctx, priv, PrivateCredentialsNS, cred)
+	_, err := git.TreeRemove(ctx, priv, EncryptedPrivateCredentialsNS)
+	must.NoError(ctx, err)
+	proto.Commit(ctx, priv, git.NewChangeNoResult("Decrypted private credentials.", "id_decrypt"))
+}
@@ -0,0 +1,57 @@
+package id
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestEncrypt(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	testID := NewTestID(ctx, t, git.MainBranch, false)
+	Init_Local(ctx, testID.OwnerCloned())
+	cred := GetOwnerCredentials(ctx, testID.OwnerCloned())
+
+	defer func(p PassphraseFunc) { Passphrase = p }(Passphrase)
+	Passphrase = func(context.Context) (string, error) { return "correct horse", nil }
+
+	Encrypt_Local(ctx, testID.OwnerCloned())
+	priv := testID.Private.Tree()
+	if !IsEncrypted_Local(ctx, priv) {
+		t.Fatalf("expecting encrypted credentials")
+	}
+	if _, err := git.TreeStat(ctx, priv, PrivateCredentialsNS); err == nil {
+		t.Fatalf("expecting plaintext credentials to be removed")
+	}
+	if got := GetPrivateCredentials(ctx, priv); got.PublicCredentials.ID != cred.PublicCredentials.ID {
+		t.Errorf("expecting %v, got %v", cred.PublicCredentials.ID, got.PublicCredentials.ID)
+	}
+
+	enc := form.FromFile[EncryptedPrivateCredentials](ctx, priv.Filesystem, EncryptedPrivateCredentialsNS)
+	if _, err := DecryptPrivateCredentials(enc, "wrong"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("expecting wrong passphrase, got %v", err)
+	}
+
+	// rotation keeps the credentials encrypted
+	Rotate_Local(ctx, testID.OwnerCloned(), nil)
+	if !IsEncrypted_Local(ctx, priv) {
+		t.Fatalf("expecting encrypted credentials after rotation")
+	}
+	rotated := GetPrivateCredentials(ctx, priv)
+	if rotated.PublicCredentials.ID != GetPublicCredentials(ctx, testID.Public.Tree()).ID {
+		t.Errorf("expecting rotated credentials")
+	}
+
+	Decrypt_Local(ctx, testID.OwnerCloned())
+	if IsEncrypted_Local(ctx, priv) {
+		t.Fatalf("expecting plaintext credentials")
+	}
+	if got := GetPrivateCredentials(ctx, priv); got.PublicCredentials.ID != rotated.PublicCredentials.ID {
+		t.Errorf("expecting %v, got %v", rotated.PublicCredentials.ID, got.PublicCredentials.ID)
+	}
+}
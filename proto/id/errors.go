@@ -0,0 +1,11 @@
+package id
+
+import "errors"
+
+var (
+	ErrUnknownKey        = errors.New("key is not in the identity's key history")
+	ErrKeyRevoked        = errors.New("key has been revoked")
+	ErrInvalidKeyHistory = errors.New("key history is not valid")
+	ErrNoPassphrase      = errors.New("private credentials are encrypted and no passphrase is available")
+	ErrWrongPassphrase   = errors.New("passphrase does not decrypt the private credentials")
+)
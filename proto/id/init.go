@@ -39,6 +39,9 @@ func initPrivate_StageOnly(ctx context.Context, priv *git.Tree, ownerAddr OwnerA
 	if _, err := git.TreeStat(ctx, priv, PrivateCredentialsNS); err == nil {
 		must.Errorf(ctx, "private credentials file already exists")
 	}
+	if IsEncrypted_Local(ctx, priv) {
+		must.Errorf(ctx, "encrypted private credentials file already exists")
+	}
 	cred, err := GenerateCredentials()
 	must.NoError(ctx, err)
 	git.ToFileStage(ctx, priv, PrivateCredentialsNS, cred)
@@ -0,0 +1,208 @@
+package id
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// KeyHistoryNS holds the rotation statements of an identity, oldest first.
+var KeyHistoryNS = PublicNS.Append("key_history.json")
+
+// Rotation is a statement, signed by the From key, which replaces it with the To key.
+type Rotation struct {
+	From PublicCredentials `json:"from"`
+	To   PublicCredentials `json:"to"`
+	Time time.Time         `json:"time"`
+	// RevokedSince, if set, declares the From key compromised since the given time.
+	RevokedSince *time.Time `json:"revoked_since,omitempty"`
+}
+
+type KeyHistory []Signed[Rotation]
+
+// KeyChain is the current credentials of an identity, together with the history of keys which led to them.
+type KeyChain struct {
+	Current PublicCredentials `json:"current"`
+	History KeyHistory        `json:"history"`
+}
+
+// Verify checks that every rotation is signed by its From key, and that the rotations link up to the current credentials.
+func (x KeyChain) Verify(ctx context.Context) error {
+	for i, r := range x.History {
+		if !r.Verify(ctx) {
+			return fmt.Errorf("%w: rotation %d is not signed correctly", ErrInvalidKeyHistory, i)
+		}
+		if !bytes.Equal(r.PublicKeyEd25519, r.Value.From.PublicKeyEd25519) {
+			return fmt.Errorf("%w: rotation %d is not signed by the key it replaces", ErrInvalidKeyHistory, i)
+		}
+		if !r.Value.From.IsValid() || !r.Value.To.IsValid() {
+			return fmt.Errorf("%w: rotation %d has invalid credentials", ErrInvalidKeyHistory, i)
+		}
+		if i > 0 && x.History[i-1].Value.To.ID != r.Value.From.ID {
+			return fmt.Errorf("%w: rotation %d does not follow rotation %d", ErrInvalidKeyHistory, i, i-1)
+		}
+	}
+	if n := len(x.History); n > 0 && x.History[n-1].Value.To.ID != x.Current.ID {
+		return fmt.Errorf("%w: last rotation does not lead to the current credentials", ErrInvalidKeyHistory)
+	}
+	return nil
+}
+
+// RootID returns the ID of the first key of the identity.
+// Unlike the current ID, the root ID does not change when keys are rotated.
+func (x KeyChain) RootID() ID {
+	if len(x.History) == 0 {
+		return x.Current.ID
+	}
+	return x.History[0].Value.From.ID
+}
+
+// IDs returns the IDs of all keys of the identity, current first.
+func (x KeyChain) IDs() []ID {
+	ids := []ID{x.Current.ID}
+	for i := len(x.History) - 1; i >= 0; i-- {
+		ids = append(ids, x.History[i].Value.From.ID)
+	}
+	return ids
+}
+
+func (x KeyChain) Has(id ID) bool {
+	for _, y := range x.IDs() {
+		if y == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckKey returns nil if the public key belongs to the identity and has not been revoked.
+// Revoked keys are accepted for statements received before the revocation, if a receipt time is given.
+// The receipt time must be recorded by the receiver: dates supplied by the signer cannot be trusted,
+// since whoever holds a leaked key can backdate their statements.
+func (x KeyChain) CheckKey(pubKey Ed25519PublicKey, received *time.Time) error {
+	if bytes.Equal(pubKey, x.Current.PublicKeyEd25519) {
+		return nil
+	}
+	for _, r := range x.History {
+		if !bytes.Equal(pubKey, r.Value.From.PublicKeyEd25519) {
+			continue
+		}
+		if r.Value.RevokedSince == nil {
+			return nil
+		}
+		if received != nil && received.Before(*r.Value.RevokedSince) {
+			return nil
+		}
+		return fmt.Errorf("%w: key %v is revoked since %v", ErrKeyRevoked, r.Value.From.ID, r.Value.RevokedSince.Format(time.RFC3339))
+	}
+	return fmt.Errorf("%w: %v", ErrUnknownKey, Ed25519PubKeyToID(pubKey.Bytes()))
+}
+
+// RevokedSince returns the time since which the public key is revoked, or nil if it is not a revoked key of the identity.
+func (x KeyChain) RevokedSince(pubKey Ed25519PublicKey) *time.Time {
+	for _, r := range x.History {
+		if bytes.Equal(pubKey, r.Value.From.PublicKeyEd25519) {
+			return r.Value.RevokedSince
+		}
+	}
+	return nil
+}
+
+// HasRevoked returns true if any key of the identity has been revoked.
+func (x KeyChain) HasRevoked() bool {
+	for _, r := range x.History {
+		if r.Value.RevokedSince != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GetKeyChain returns the verified key chain of the identity whose public repo is t.
+func GetKeyChain(ctx context.Context, t *git.Tree) KeyChain {
+	chain := KeyChain{
+		Current: GetPublicCredentials(ctx, t),
+		History: KeyHistory{},
+	}
+	if history, err := git.TryFromFile[KeyHistory](ctx, t, KeyHistoryNS); err == nil {
+		chain.History = history
+	}
+	must.NoError(ctx, chain.Verify(ctx))
+	return chain
+}
+
+// GetRootID returns the stable ID of the identity whose public repo is t.
+func GetRootID(ctx context.Context, t *git.Tree) ID {
+	return GetKeyChain(ctx, t).RootID()
+}
+
+// Rotate replaces the credentials of an identity with newly generated ones.
+// The rotation is signed by the old key and appended to the key history in the public repo.
+func Rotate(
+	ctx context.Context,
+	ownerAddr OwnerAddress,
+) git.Change[form.None, PublicCredentials] {
+
+	ownerCloned := CloneOwner(ctx, ownerAddr)
+	chg := Rotate_Local(ctx, ownerCloned, nil)
+	ownerCloned.Public.Push(ctx)
+	ownerCloned.Private.Push(ctx)
+	return chg
+}
+
+// Revoke rotates the credentials of an identity and declares the old key compromised since the given time.
+// Messages signed by the old key are no longer accepted, unless they were received before the revocation.
+func Revoke(
+	ctx context.Context,
+	ownerAddr OwnerAddress,
+	since time.Time,
+) git.Change[form.None, PublicCredentials] {
+
+	ownerCloned := CloneOwner(ctx, ownerAddr)
+	chg := Rotate_Local(ctx, ownerCloned, &since)
+	ownerCloned.Public.Push(ctx)
+	ownerCloned.Private.Push(ctx)
+	return chg
+}
+
+func Rotate_Local(
+	ctx context.Context,
+	ownerCloned OwnerCloned,
+	revokedSince *time.Time,
+) git.Change[form.None, PublicCredentials] {
+
+	pub, priv := ownerCloned.Public.Tree(), ownerCloned.Private.Tree()
+	chain := GetKeyChain(ctx, pub)
+	oldCred := GetPrivateCredentials(ctx, priv)
+	must.Assertf(ctx, oldCred.PublicCredentials.ID == chain.Current.ID, "private credentials do not match public credentials")
+
+	newCred, err := GenerateCredentials()
+	must.NoError(ctx, err)
+	rotation := Rotation{
+		From:         oldCred.PublicCredentials,
+		To:           newCred.PublicCredentials,
+		Time:         time.Now(),
+		RevokedSince: revokedSince,
+	}
+	history := append(chain.History, Sign(ctx, oldCred, rotation))
+
+	setPrivateCredentials_StageOnly(ctx, priv, newCred)
+	git.ToFileStage(ctx, pub, PublicCredentialsNS, newCred.PublicCredentials)
+	git.ToFileStage(ctx, pub, KeyHistoryNS, history)
+
+	op, msg := "id_rotate", "Rotated credentials."
+	if revokedSince != nil {
+		op, msg = "id_revoke", fmt.Sprintf("Revoked credentials %v since %v.", oldCred.PublicCredentials.ID, revokedSince.Format(time.RFC3339))
+	}
+	privChg := git.NewChange(msg, op, form.None{}, form.None{}, nil)
+	pubChg := git.NewChange(msg, op, form.None{}, newCred.PublicCredentials, nil)
+	proto.Commit(ctx, priv, privChg)
+	proto.Commit(ctx, pub, pubChg)
+	return pubChg
+}
@@ -0,0 +1,55 @@
+package id
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestRotateRevoke(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	testID := NewTestID(ctx, t, git.MainBranch, false)
+	Init_Local(ctx, testID.OwnerCloned())
+
+	cred0 := GetOwnerCredentials(ctx, testID.OwnerCloned())
+	Rotate_Local(ctx, testID.OwnerCloned(), nil)
+	cred1 := GetOwnerCredentials(ctx, testID.OwnerCloned())
+	since := time.Now().Add(-time.Hour)
+	Rotate_Local(ctx, testID.OwnerCloned(), &since)
+	cred2 := GetOwnerCredentials(ctx, testID.OwnerCloned())
+
+	chain := GetKeyChain(ctx, testID.Public.Tree())
+	if chain.Current.ID != cred2.PublicCredentials.ID {
+		t.Errorf("expecting current id %v, got %v", cred2.PublicCredentials.ID, chain.Current.ID)
+	}
+	if chain.RootID() != cred0.PublicCredentials.ID {
+		t.Errorf("expecting root id %v, got %v", cred0.PublicCredentials.ID, chain.RootID())
+	}
+	if n := len(chain.IDs()); n != 3 {
+		t.Errorf("expecting 3 ids, got %v", n)
+	}
+
+	// the first key was rotated normally
+	if err := chain.CheckKey(cred0.PublicCredentials.PublicKeyEd25519, nil); err != nil {
+		t.Errorf("expecting rotated key to be accepted, got %v", err)
+	}
+
+	// the second key was revoked
+	if err := chain.CheckKey(cred1.PublicCredentials.PublicKeyEd25519, nil); !errors.Is(err, ErrKeyRevoked) {
+		t.Errorf("expecting revoked key, got %v", err)
+	}
+	before := since.Add(-time.Minute)
+	if err := chain.CheckKey(cred1.PublicCredentials.PublicKeyEd25519, &before); err != nil {
+		t.Errorf("expecting revoked key to be accepted before revocation, got %v", err)
+	}
+
+	// unrelated keys are refused
+	other, _ := GenerateCredentials()
+	if err := chain.CheckKey(other.PublicCredentials.PublicKeyEd25519, nil); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expecting unknown key, got %v", err)
+	}
+}
@@ -16,8 +16,14 @@ func GetOwnerCredentials(ctx context.Context, owner OwnerCloned) PrivateCredenti
 	return GetPrivateCredentials(ctx, owner.Private.Tree())
 }
 
+// GetPrivateCredentials reads the private credentials, decrypting them if the private repo is encrypted.
 func GetPrivateCredentials(ctx context.Context, privateTree *git.Tree) PrivateCredentials {
-	cred := form.FromFile[PrivateCredentials](ctx, privateTree.Filesystem, PrivateCredentialsNS)
+	var cred PrivateCredentials
+	if IsEncrypted_Local(ctx, privateTree) {
+		cred = decryptPrivateCredentials(ctx, form.FromFile[EncryptedPrivateCredentials](ctx, privateTree.Filesystem, EncryptedPrivateCredentialsNS))
+	} else {
+		cred = form.FromFile[PrivateCredentials](ctx, privateTree.Filesystem, PrivateCredentialsNS)
+	}
 	must.Assertf(ctx, cred.IsValid(), "credentials are not valid")
 	return cred
 }
@@ -0,0 +1,144 @@
+package invite
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Mint creates a new invitation and returns its code.
+// The code is not recorded anywhere and must be delivered to the invitee privately.
+func Mint(
+	ctx context.Context,
+	addr gov.Address,
+	invitee id.PublicAddress,
+	user member.User,
+	groups []member.Group,
+	credits float64,
+	ttl time.Duration,
+
+) string {
+
+	cloned := gov.Clone(ctx, addr)
+	code, chg := Mint_StageOnly(ctx, cloned, invitee, user, groups, credits, ttl)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+	return code
+}
+
+func Mint_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	invitee id.PublicAddress,
+	user member.User,
+	groups []member.Group,
+	credits float64,
+	ttl time.Duration,
+
+) (string, git.Change[form.Map, CodeHash]) {
+
+	must.Assertf(ctx, ttl > 0, "invitation lifetime must be positive")
+	must.Assertf(ctx, credits >= 0, "starting credits must be non-negative")
+	// starting credits are issued on redemption, so they are subject to the same approval as issuance
+	if credits > 0 {
+		must.NoError(ctx, etc.CheckUnilateral_Local(ctx, cloned, etc.OpAccountIssue))
+	}
+	if !user.IsNone() {
+		must.Assertf(ctx, !member.IsUser_Local(ctx, cloned, user), "user %v already exists", user)
+	}
+	for _, g := range groups {
+		must.Assertf(ctx, member.IsGroup_Local(ctx, cloned, g), "group %v does not exist", g)
+	}
+
+	code := generateCode()
+	now := time.Now()
+	inv := Invitation{
+		Hash:    HashCode(code),
+		Invitee: invitee,
+		User:    user,
+		Groups:  groups,
+		Credits: credits,
+		Minted:  now,
+		Expires: now.Add(ttl),
+	}
+	invitationKV.Set(ctx, invitationNS, cloned.Tree(), inv.Hash, inv)
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &MintTrace{Hash: inv.Hash, Invitee: invitee, User: user, Groups: groups, Credits: credits, Expires: inv.Expires},
+	})
+
+	// the change must not include the code, since it is recorded in the commit message
+	return code, git.NewChange(
+		fmt.Sprintf("Mint invitation for %v", invitee),
+		"invite_mint",
+		form.Map{"invitee": invitee, "user": user, "groups": groups, "credits": credits, "ttl": ttl.String()},
+		inv.Hash,
+		nil,
+	)
+}
+
+func generateCode() string {
+	const w = 128 / 8 // 128 bits, measured in bytes
+	buf := make([]byte, w)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+func List(
+	ctx context.Context,
+	addr gov.Address,
+
+) Invitations {
+
+	return List_Local(ctx, gov.Clone(ctx, addr))
+}
+
+func List_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) Invitations {
+
+	_, invs := invitationKV.ListKeyValues(ctx, invitationNS, cloned.Tree())
+	return invs
+}
+
+func Revoke(
+	ctx context.Context,
+	addr gov.Address,
+	hash CodeHash,
+
+) {
+
+	cloned := gov.Clone(ctx, addr)
+	Revoke_StageOnly(ctx, cloned, hash)
+	proto.Commitf(ctx, cloned, "invite_revoke", "Revoke invitation %v", hash)
+	cloned.Push(ctx)
+}
+
+func Revoke_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	hash CodeHash,
+
+) {
+
+	must.Assertf(ctx, invitationKV.Contains(ctx, invitationNS, cloned.Tree(), hash), "invitation %v not found", hash)
+	invitationKV.Remove(ctx, invitationNS, cloned.Tree(), hash)
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &RevokeTrace{Hash: hash},
+	})
+}
@@ -0,0 +1,150 @@
+package invite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Process fetches redemption requests from the invitees of all pending invitations,
+// and adds the invitees with valid requests to the community.
+func Process(
+	ctx context.Context,
+	govAddr gov.OwnerAddress,
+
+) (git.Change[form.Map, []RedeemResponse], mail.Rejections) {
+
+	base.Infof("fetching invitation redemptions ...")
+
+	govOwner := gov.CloneOwner(ctx, govAddr)
+	chg, rejected := Process_StageOnly(ctx, govOwner)
+	if len(chg.Result) > 0 {
+		proto.Commit(ctx, govOwner.Public.Tree(), chg)
+		govOwner.Public.Push(ctx)
+	}
+	return chg, rejected
+}
+
+func Process_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+
+) (git.Change[form.Map, []RedeemResponse], mail.Rejections) {
+
+	now := time.Now()
+	responses := []RedeemResponse{}
+	rejected := mail.Rejections{}
+	for _, inv := range List_Local(ctx, govOwner.PublicClone()) {
+		if !inv.IsPending(now) {
+			continue
+		}
+		resps, rej, err := processInvitation_StageOnly(ctx, govOwner, inv)
+		if err != nil {
+			base.Infof("fetching redemption requests from %v (%v)", inv.Invitee, err)
+			continue
+		}
+		responses = append(responses, resps...)
+		rejected = append(rejected, rej...)
+	}
+
+	return git.NewChange(
+		fmt.Sprintf("Processed %d invitation redemptions", len(responses)),
+		"invite_process",
+		form.Map{},
+		responses,
+		nil,
+	), rejected
+}
+
+func processInvitation_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	inv Invitation,
+
+) ([]RedeemResponse, mail.Rejections, error) {
+
+	inviteePublic, err := git.TryCloneOne(ctx, git.Address(inv.Invitee))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responses := []RedeemResponse{}
+	var respond mail.Responder[RedeemRequest, RedeemResponse] = func(
+		ctx context.Context,
+		_ mail.SeqNo,
+		req RedeemRequest,
+	) (RedeemResponse, error) {
+
+		var resp RedeemResponse
+		err := must.Try(func() { resp.User = redeem_StageOnly(ctx, govOwner.PublicClone(), inv.Hash, req) })
+		if err != nil {
+			base.Infof("invitation redemption by %v failed (%v)", inv.Invitee, err)
+			resp.Error = err.Error()
+		}
+		responses = append(responses, resp)
+		return resp, nil
+	}
+
+	_, rejected := mail.Respond_StageOnly[RedeemRequest, RedeemResponse](
+		ctx,
+		govOwner.IDOwnerCloned(),
+		inv.Invitee,
+		inviteePublic.Tree(),
+		InviteTopic,
+		respond,
+	)
+	return responses, rejected, nil
+}
+
+func redeem_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	hash CodeHash,
+	req RedeemRequest,
+
+) member.User {
+
+	// reload, since an earlier request may have redeemed the invitation
+	inv := invitationKV.Get(ctx, invitationNS, cloned.Tree(), hash)
+	now := time.Now()
+	must.Assertf(ctx, HashCode(req.Code) == inv.Hash, "invitation code does not match")
+	must.Assertf(ctx, !inv.Redeemed, "invitation already redeemed")
+	must.Assertf(ctx, !inv.IsExpired(now), "invitation expired")
+
+	user := inv.User
+	if user.IsNone() {
+		user = req.User
+	}
+	must.Assertf(ctx, !user.IsNone(), "invitation requires a user alias")
+
+	member.AddUserByPublicAddress_StageOnly(ctx, cloned, user, inv.Invitee)
+	for _, g := range inv.Groups {
+		member.AddMember_StageOnly(ctx, cloned, user, g)
+	}
+	if inv.Credits > 0 {
+		// issuance was checked when the invitation was minted
+		account.Issue_StageOnly(etc.WithApproval(ctx), cloned, member.UserAccountID(user), account.H(account.PluralAsset, inv.Credits), "invitation starting credits")
+	}
+
+	inv.Redeemed = true
+	inv.RedeemedAt = now
+	inv.RedeemedBy = user
+	invitationKV.Set(ctx, invitationNS, cloned.Tree(), hash, inv)
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &RedeemTrace{Hash: hash, Invitee: inv.Invitee, User: user},
+	})
+	return user
+}
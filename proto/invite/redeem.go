@@ -0,0 +1,62 @@
+package invite
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Redeem sends a signed request to join the community, using an invitation code.
+// The invitee is added to the community during the next sync.
+func Redeem(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	code string,
+	user member.User,
+
+) git.Change[form.Map, mail.RequestEnvelope[RedeemRequest]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := Redeem_StageOnly(ctx, userAddr, userOwner, govCloned, code, user)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	userOwner.Public.Push(ctx)
+	return chg
+}
+
+func Redeem_StageOnly(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	code string,
+	user member.User,
+
+) git.Change[form.Map, mail.RequestEnvelope[RedeemRequest]] {
+
+	// fail early, if the invitation is not redeemable from this identity
+	hash := HashCode(code)
+	inv, err := must.Try1(func() Invitation { return invitationKV.Get(ctx, invitationNS, govCloned.Tree(), hash) })
+	must.Assertf(ctx, err == nil, "invitation not found")
+	must.Assertf(ctx, !inv.Redeemed, "invitation already redeemed")
+	must.Assertf(ctx, inv.Invitee == userAddr.Public, "invitation is for a different identity")
+	must.Assertf(ctx, !inv.User.IsNone() || !user.IsNone(), "invitation requires a user alias")
+
+	request := RedeemRequest{Code: code, User: user}
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), InviteTopic, request)
+	return git.NewChange(
+		"Redeem invitation",
+		"invite_redeem",
+		form.Map{"hash": hash, "user": user},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
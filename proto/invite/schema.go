@@ -0,0 +1,62 @@
+// Package invite implements member onboarding with single-use invitation codes.
+package invite
+
+import (
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/form"
+)
+
+const InviteTopic = "invite"
+
+var (
+	invitationNS = proto.RootNS.Append("invitation")
+	invitationKV = kv.KV[CodeHash, Invitation]{}
+)
+
+// CodeHash is the hash of an invitation code. Only hashes of codes are stored in the governance repo.
+type CodeHash string
+
+func HashCode(code string) CodeHash {
+	return CodeHash(form.StringHashForFilename(code))
+}
+
+// Invitation is a single-use, expiring permission for the holder of the code to join the community.
+type Invitation struct {
+	Hash    CodeHash         `json:"hash"`
+	Invitee id.PublicAddress `json:"invitee"` // public identity repo of the invitee
+	User    member.User      `json:"user"`    // pre-assigned user alias, or empty if chosen by the invitee
+	Groups  []member.Group   `json:"groups"`  // pre-assigned groups, in addition to everybody
+	Credits float64          `json:"credits"` // starting credits
+	Minted  time.Time        `json:"minted"`
+	Expires time.Time        `json:"expires"`
+	//
+	Redeemed   bool        `json:"redeemed"`
+	RedeemedAt time.Time   `json:"redeemed_at"`
+	RedeemedBy member.User `json:"redeemed_by"`
+}
+
+func (x Invitation) IsExpired(now time.Time) bool {
+	return now.After(x.Expires)
+}
+
+func (x Invitation) IsPending(now time.Time) bool {
+	return !x.Redeemed && !x.IsExpired(now)
+}
+
+type Invitations []Invitation
+
+// RedeemRequest is sent by the invitee to the community by mail.
+type RedeemRequest struct {
+	Code string      `json:"code"`
+	User member.User `json:"user"` // requested user alias, ignored if the invitation pre-assigns one
+}
+
+type RedeemResponse struct {
+	User  member.User `json:"user"`
+	Error string      `json:"error,omitempty"`
+}
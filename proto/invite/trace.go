@@ -0,0 +1,42 @@
+package invite
+
+import (
+	"context"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+func init() {
+	ctx := context.Background()
+	trace.Register[MintTrace](ctx, 1)
+	trace.Register[RevokeTrace](ctx, 1)
+	trace.Register[RedeemTrace](ctx, 1)
+}
+
+type MintTrace struct {
+	Hash    CodeHash         `json:"hash"`
+	Invitee id.PublicAddress `json:"invitee"`
+	User    member.User      `json:"user"`
+	Groups  []member.Group   `json:"groups"`
+	Credits float64          `json:"credits"`
+	Expires time.Time        `json:"expires"`
+}
+
+func (*MintTrace) TraceOp() string { return "invite_mint" }
+
+type RevokeTrace struct {
+	Hash CodeHash `json:"hash"`
+}
+
+func (*RevokeTrace) TraceOp() string { return "invite_revoke" }
+
+type RedeemTrace struct {
+	Hash    CodeHash         `json:"hash"`
+	Invitee id.PublicAddress `json:"invitee"`
+	User    member.User      `json:"user"`
+}
+
+func (*RedeemTrace) TraceOp() string { return "invite_redeem" }
@@ -0,0 +1,212 @@
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/ns"
+)
+
+// Entries are stored in JSONL segments, one per day and writer, in the order in which they were logged.
+// Each month has manifests, one per writer, which count the entries of each day by kind,
+// so that listings can skip segments without reading them.
+// Writers only ever modify their own segments and manifests, so changes by different writers merge cleanly.
+// Earlier versions stored every entry in its own JSON file within the day directory; such files are still read.
+
+const (
+	segmentPrefix  = "segment"
+	segmentExt     = ".jsonl"
+	manifestPrefix = "manifest"
+	manifestExt    = ".json"
+)
+
+// Writer names the segments and manifests written by this process. It defaults to the host name.
+var Writer = defaultWriter()
+
+var writerUnsafe = regexp.MustCompile(`[^a-z0-9\-]+`)
+
+func defaultWriter() string {
+	host, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	if w := strings.Trim(writerUnsafe.ReplaceAllString(strings.ToLower(host), "-"), "-"); w != "" {
+		return w
+	}
+	return "unknown"
+}
+
+func segmentFilebase(writer string) string {
+	return segmentPrefix + "_" + writer + segmentExt
+}
+
+func manifestFilebase(writer string) string {
+	return manifestPrefix + "_" + writer + manifestExt
+}
+
+// writerOfFilebase returns the writer of a segment or manifest file, given its prefix and extension.
+// Files written before writers were distinguished belong to the empty writer.
+func writerOfFilebase(filebase string, prefix string, ext string) (string, bool) {
+	if !strings.HasPrefix(filebase, prefix) || !strings.HasSuffix(filebase, ext) {
+		return "", false
+	}
+	stem := strings.TrimSuffix(strings.TrimPrefix(filebase, prefix), ext)
+	if stem == "" {
+		return "", true
+	}
+	if !strings.HasPrefix(stem, "_") {
+		return "", false
+	}
+	return stem[1:], true
+}
+
+// Manifest summarizes the segments of a month written by one writer.
+type Manifest struct {
+	Days map[string]DaySummary `json:"days"` // day of month (two digits) -> summary
+}
+
+type DaySummary struct {
+	Count int            `json:"count"`
+	Kinds map[string]int `json:"kinds"` // kind -> number of entries
+}
+
+// HasAnyKind returns true if the day has entries of any of the given kinds.
+// Entries without a kind are always considered a match, since their kind is unknown.
+func (x DaySummary) HasAnyKind(kinds []string) bool {
+	if x.Kinds[""] > 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if x.Kinds[k] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (j Journal[X]) appendSegment_StageOnly(
+	ctx context.Context,
+	t *git.Tree,
+	writer string,
+	entry Entry[X],
+) {
+
+	line, err := json.Marshal(entry)
+	must.NoError(ctx, err)
+	line = append(line, '\n')
+
+	dir := dayNS(j.Root, entry.Stamp)
+	git.TreeMkdirAll(ctx, t, dir)
+	p := dir.Append(segmentFilebase(writer))
+	f, err := t.Filesystem.OpenFile(p.GitPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	must.NoError(ctx, err)
+	_, err = f.Write(line)
+	must.NoError(ctx, err)
+	must.NoError(ctx, f.Close())
+	git.Add(ctx, t, p)
+}
+
+func (j Journal[X]) updateManifest_StageOnly(
+	ctx context.Context,
+	t *git.Tree,
+	writer string,
+	stamp time.Time,
+	kind string,
+) {
+
+	p := monthNS(j.Root, stamp).Append(manifestFilebase(writer))
+	m := j.loadManifest_Local(ctx, t, p)
+	if m == nil {
+		m = &Manifest{}
+	}
+	if m.Days == nil {
+		m.Days = map[string]DaySummary{}
+	}
+	day := fmt.Sprintf("%02d", stamp.Day())
+	s := m.Days[day]
+	if s.Kinds == nil {
+		s.Kinds = map[string]int{}
+	}
+	s.Count++
+	s.Kinds[kind]++
+	m.Days[day] = s
+	git.ToFileStage(ctx, t, p, m)
+}
+
+// loadManifest_Local returns nil if the manifest does not exist.
+func (j Journal[X]) loadManifest_Local(ctx context.Context, t *git.Tree, p ns.NS) *Manifest {
+	m, err := git.TryFromFile[Manifest](ctx, t, p)
+	if err != nil {
+		return nil
+	}
+	return &m
+}
+
+// loadManifests_Local returns the manifests of a month, by writer.
+func (j Journal[X]) loadManifests_Local(ctx context.Context, t *git.Tree, monthNS ns.NS) map[string]*Manifest {
+	infos, err := t.Filesystem.ReadDir(monthNS.GitPath())
+	must.Assert(ctx, err == nil || git.IsNotExist(err), err)
+	ms := map[string]*Manifest{}
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		writer, ok := writerOfFilebase(info.Name(), manifestPrefix, manifestExt)
+		if !ok {
+			continue
+		}
+		if m := j.loadManifest_Local(ctx, t, monthNS.Append(info.Name())); m != nil {
+			ms[writer] = m
+		}
+	}
+	return ms
+}
+
+func (j Journal[X]) readSegment_Local(
+	ctx context.Context,
+	t *git.Tree,
+	p ns.NS,
+	keep func(Entry[X]) bool,
+) Entries[X] {
+
+	f, err := t.Filesystem.Open(p.GitPath())
+	if git.IsNotExist(err) {
+		return nil
+	}
+	must.NoError(ctx, err)
+	defer f.Close()
+
+	es := Entries[X]{}
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			var e Entry[X]
+			must.NoError(ctx, json.Unmarshal(line, &e))
+			if keep(e) {
+				es = append(es, e)
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	return es
+}
+
+func (j Journal[X]) readLegacyEntry_Local(ctx context.Context, t *git.Tree, p ns.NS) Entry[X] {
+	e := form.FromFile[Entry[X]](ctx, t.Filesystem, p)
+	if e.Kind == "" {
+		e.Kind = kindOf(e.Payload)
+	}
+	return e
+}
@@ -3,15 +3,12 @@ package journal
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"sort"
-	"strconv"
 	"time"
 
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
-	"github.com/gov4git/lib4git/must"
 	"github.com/gov4git/lib4git/ns"
 )
 
@@ -22,9 +19,22 @@ type Journal[X form.Form] struct {
 type Entry[X form.Form] struct {
 	ID      id.ID     `json:"id"`
 	Stamp   time.Time `json:"stamp"`
+	Kind    string    `json:"kind,omitempty"`
 	Payload X         `json:"payload"`
 }
 
+// Kinded is implemented by journal payloads which can be classified, so that listings can be filtered by kind.
+type Kinded interface {
+	JournalKind() string
+}
+
+func kindOf(x any) string {
+	if k, ok := x.(Kinded); ok {
+		return k.JournalKind()
+	}
+	return ""
+}
+
 type Entries[X form.Form] []Entry[X]
 
 func (x Entries[X]) Len() int {
@@ -43,92 +53,40 @@ func (x Entries[X]) Sort() {
 	sort.Sort(x)
 }
 
-func (j Journal[X]) List_Local(
+func (j Journal[X]) Log_StageOnly(
 	ctx context.Context,
 	t *git.Tree,
-) Entries[X] {
-
-	es := Entries[X]{}
-
-	root := j.Root.GitPath()
-	yinfos, err := t.Filesystem.ReadDir(root)
-	must.Assert(ctx, err == nil || git.IsNotExist(err), err)
-	// years
-	for _, yinfo := range yinfos {
-		if !yinfo.IsDir() {
-			continue
-		}
-		if _, err := strconv.Atoi(yinfo.Name()); err != nil {
-			continue
-		}
-		yearNS := j.Root.Append(yinfo.Name())
-		minfos, err := t.Filesystem.ReadDir(yearNS.GitPath())
-		must.Assert(ctx, err == nil || git.IsNotExist(err), err)
-		// months
-		for _, minfo := range minfos {
-			if !minfo.IsDir() {
-				continue
-			}
-			if _, err := strconv.Atoi(minfo.Name()); err != nil {
-				continue
-			}
-			monthNS := yearNS.Append(minfo.Name())
-			dinfos, err := t.Filesystem.ReadDir(monthNS.GitPath())
-			must.Assert(ctx, err == nil || git.IsNotExist(err), err)
-			// days
-			for _, dinfo := range dinfos {
-				if !dinfo.IsDir() {
-					continue
-				}
-				if _, err := strconv.Atoi(dinfo.Name()); err != nil {
-					continue
-				}
-				dayNS := monthNS.Append(dinfo.Name())
-				loginfos, err := t.Filesystem.ReadDir(dayNS.GitPath())
-				must.Assert(ctx, err == nil || git.IsNotExist(err), err)
-				// entries
-				for _, loginfo := range loginfos {
-					if loginfo.IsDir() || filepath.Ext(loginfo.Name()) != ".json" {
-						continue
-					}
-					e := form.FromFile[Entry[X]](ctx, t.Filesystem, dayNS.Append(loginfo.Name()))
-					es = append(es, e)
-				}
-			}
-		}
-	}
-	es.Sort()
-	return es
+	x X,
+) {
+
+	j.log_StageOnly(ctx, t, Writer, time.Now(), x)
 }
 
-func (j Journal[X]) Log_StageOnly(
+func (j Journal[X]) log_StageOnly(
 	ctx context.Context,
 	t *git.Tree,
+	writer string,
+	stamp time.Time,
 	x X,
 ) {
 
-	now := time.Now()
-	id := id.GenerateRandomID()
-	dirNS := j.Root.Append(
-		fmt.Sprintf("%04d", now.Year()),
-		fmt.Sprintf("%02d", now.Month()),
-		fmt.Sprintf("%02d", now.Day()),
-	)
-	git.TreeMkdirAll(ctx, t, dirNS)
 	entry := Entry[X]{
-		ID:      id,
-		Stamp:   now,
+		ID:      id.GenerateRandomID(),
+		Stamp:   stamp,
+		Kind:    kindOf(x),
 		Payload: x,
 	}
-	filebase := fmt.Sprintf(
-		"%04d-%02d-%02d_%02d:%02d:%02d_%v.json",
-		now.Year(),
-		now.Month(),
-		now.Day(),
-		now.Hour(),
-		now.Minute(),
-		now.Second(),
-		id,
+	j.appendSegment_StageOnly(ctx, t, writer, entry)
+	j.updateManifest_StageOnly(ctx, t, writer, stamp, entry.Kind)
+}
+
+func dayNS(root ns.NS, t time.Time) ns.NS {
+	return monthNS(root, t).Append(fmt.Sprintf("%02d", t.Day()))
+}
+
+func monthNS(root ns.NS, t time.Time) ns.NS {
+	return root.Append(
+		fmt.Sprintf("%04d", t.Year()),
+		fmt.Sprintf("%02d", t.Month()),
 	)
-	git.ToFileStage[Entry[X]](ctx, t, dirNS.Append(filebase), entry)
 }
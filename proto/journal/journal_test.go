@@ -0,0 +1,92 @@
+package journal
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/ns"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+type testEvent struct {
+	Kind string `json:"kind"`
+	N    int    `json:"n"`
+}
+
+func (x *testEvent) JournalKind() string {
+	return x.Kind
+}
+
+func TestJournal(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	govID := id.NewTestID(ctx, t, git.MainBranch, true)
+	tree := gov.Clone(ctx, gov.Address(govID.PublicAddress())).Tree()
+
+	j := Journal[*testEvent]{Root: ns.NS{"journal"}}
+	stamp := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		j.log_StageOnly(ctx, tree, "w1", stamp.Add(time.Duration(i)*time.Minute), &testEvent{Kind: []string{"a", "b"}[i%2], N: i})
+	}
+
+	// an entry in the legacy layout, one file per entry, logged two days earlier
+	past := stamp.AddDate(0, 0, -2)
+	git.ToFileStage(
+		ctx,
+		tree,
+		dayNS(j.Root, past).Append(fmt.Sprintf("%s_legacy.json", past.Format("2006-01-02_15:04:05"))),
+		Entry[*testEvent]{ID: "legacy", Stamp: past, Payload: &testEvent{Kind: "a", N: -1}},
+	)
+
+	all := j.List_Local(ctx, tree, All)
+	if len(all) != 5 {
+		t.Fatalf("expecting 5 entries, got %v", len(all))
+	}
+	if all[0].ID != "legacy" || all[0].Kind != "a" {
+		t.Errorf("expecting legacy entry first, with its kind, got %v", all[0])
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Payload.N != i-1 {
+			t.Errorf("expecting entries in logging order, got %v at %v", all[i].Payload.N, i)
+		}
+	}
+
+	if as := j.List_Local(ctx, tree, Filter{Kinds: []string{"a"}}); len(as) != 3 {
+		t.Errorf("expecting 3 entries of kind a, got %v", len(as))
+	}
+	if cs := j.List_Local(ctx, tree, Filter{Kinds: []string{"c"}}); len(cs) != 0 {
+		t.Errorf("expecting no entries of kind c, got %v", len(cs))
+	}
+	if recent := j.List_Local(ctx, tree, Filter{Since: past.Add(time.Second)}); len(recent) != 4 {
+		t.Errorf("expecting 4 recent entries, got %v", len(recent))
+	}
+	if old := j.List_Local(ctx, tree, Filter{Until: past}); len(old) != 1 {
+		t.Errorf("expecting 1 old entry, got %v", len(old))
+	}
+	if future := j.List_Local(ctx, tree, Filter{Since: stamp.AddDate(0, 0, 3)}); len(future) != 0 {
+		t.Errorf("expecting no future entries, got %v", len(future))
+	}
+
+	// another writer logs to its own segment and manifest, so that concurrent writers do not conflict
+	j.log_StageOnly(ctx, tree, "w2", stamp.Add(time.Hour), &testEvent{Kind: "b", N: 4})
+	infos, err := tree.Filesystem.ReadDir(dayNS(j.Root, stamp).GitPath())
+	if err != nil || len(infos) != 2 {
+		t.Fatalf("expecting 2 segments, got %v (%v)", len(infos), err)
+	}
+	if ms := j.loadManifests_Local(ctx, tree, monthNS(j.Root, stamp)); len(ms) != 2 || ms["w2"].Days["15"].Kinds["b"] != 1 {
+		t.Errorf("expecting a manifest per writer, got %v", ms)
+	}
+	if bs := j.List_Local(ctx, tree, Filter{Kinds: []string{"b"}}); len(bs) != 3 {
+		t.Errorf("expecting 3 entries of kind b, got %v", len(bs))
+	}
+
+	// listings filtered by kind do not read segments whose manifest shows no matching entries
+	git.StringToFileStage(ctx, tree, dayNS(j.Root, stamp).Append(segmentFilebase("w2")), "not json")
+	if as := j.List_Local(ctx, tree, Filter{Kinds: []string{"a"}}); len(as) != 3 {
+		t.Errorf("expecting 3 entries of kind a, got %v", len(as))
+	}
+}
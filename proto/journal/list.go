@@ -0,0 +1,142 @@
+package journal
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/ns"
+)
+
+// Filter selects journal entries by time and kind.
+// Zero times leave the range open; an empty list of kinds matches all kinds.
+type Filter struct {
+	Since time.Time // inclusive
+	Until time.Time // inclusive
+	Kinds []string
+}
+
+var All = Filter{}
+
+func (f Filter) Match(stamp time.Time, kind string) bool {
+	if !f.Since.IsZero() && stamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && stamp.After(f.Until) {
+		return false
+	}
+	return len(f.Kinds) == 0 || slices.Contains(f.Kinds, kind)
+}
+
+// mayOverlap returns false if no entry logged within the given period can match the filter.
+// Directories are named after the local time of the logger, so periods are widened by a day on each side.
+func (f Filter) mayOverlap(start, end time.Time) bool {
+	const slack = 24 * time.Hour
+	if !f.Since.IsZero() && end.Add(slack).Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && start.Add(-slack).After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// List_Local returns the entries matching the filter, sorted by time.
+// It reads only the segments of days within the time range, which hold entries of the requested kinds.
+func (j Journal[X]) List_Local(
+	ctx context.Context,
+	t *git.Tree,
+	f Filter,
+) Entries[X] {
+
+	es := Entries[X]{}
+	keep := func(e Entry[X]) bool { return f.Match(e.Stamp, e.Kind) }
+
+	// years
+	for _, year := range listNumberedDirs(ctx, t, j.Root) {
+		start := time.Date(year.num, 1, 1, 0, 0, 0, 0, time.UTC)
+		if !f.mayOverlap(start, start.AddDate(1, 0, 0)) {
+			continue
+		}
+		// months
+		for _, month := range listNumberedDirs(ctx, t, year.ns) {
+			start := time.Date(year.num, time.Month(month.num), 1, 0, 0, 0, 0, time.UTC)
+			if !f.mayOverlap(start, start.AddDate(0, 1, 0)) {
+				continue
+			}
+			manifests := j.loadManifests_Local(ctx, t, month.ns)
+			// days
+			for _, day := range listNumberedDirs(ctx, t, month.ns) {
+				start := time.Date(year.num, time.Month(month.num), day.num, 0, 0, 0, 0, time.UTC)
+				if !f.mayOverlap(start, start.AddDate(0, 0, 1)) {
+					continue
+				}
+				es = append(es, j.listDay_Local(ctx, t, day, manifests, f, keep)...)
+			}
+		}
+	}
+	es.Sort()
+	return es
+}
+
+func (j Journal[X]) listDay_Local(
+	ctx context.Context,
+	t *git.Tree,
+	day numberedDir,
+	manifests map[string]*Manifest,
+	f Filter,
+	keep func(Entry[X]) bool,
+) Entries[X] {
+
+	es := Entries[X]{}
+	infos, err := t.Filesystem.ReadDir(day.ns.GitPath())
+	must.Assert(ctx, err == nil || git.IsNotExist(err), err)
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		if writer, ok := writerOfFilebase(info.Name(), segmentPrefix, segmentExt); ok {
+			// skip segments whose manifest shows no entries of the requested kinds
+			if m := manifests[writer]; m != nil && len(f.Kinds) > 0 {
+				if s, ok := m.Days[fmt.Sprintf("%02d", day.num)]; ok && !s.HasAnyKind(f.Kinds) {
+					continue
+				}
+			}
+			es = append(es, j.readSegment_Local(ctx, t, day.ns.Append(info.Name()), keep)...)
+			continue
+		}
+		if filepath.Ext(info.Name()) == ".json" {
+			if e := j.readLegacyEntry_Local(ctx, t, day.ns.Append(info.Name())); keep(e) {
+				es = append(es, e)
+			}
+		}
+	}
+	return es
+}
+
+type numberedDir struct {
+	num int
+	ns  ns.NS
+}
+
+func listNumberedDirs(ctx context.Context, t *git.Tree, parent ns.NS) []numberedDir {
+	infos, err := t.Filesystem.ReadDir(parent.GitPath())
+	must.Assert(ctx, err == nil || git.IsNotExist(err), err)
+	dirs := []numberedDir{}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		n, err := strconv.Atoi(info.Name())
+		if err != nil {
+			continue
+		}
+		dirs = append(dirs, numberedDir{num: n, ns: parent.Append(info.Name())})
+	}
+	return dirs
+}
@@ -0,0 +1,192 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/ns"
+)
+
+const SnapshotFilebase = "snapshot.json"
+
+// Snapshot summarizes the messages removed from a mailbox by compaction.
+// Messages with sequence numbers below Through are no longer present in the mailbox,
+// but remain in the history of the repo. The next.json file of the mailbox is not affected.
+type Snapshot struct {
+	Topic   string    `json:"topic"`
+	Through SeqNo     `json:"through"`
+	Count   int64     `json:"count"`  // number of compacted message files, across all compactions
+	Digest  string    `json:"digest"` // hash chained over the contents of all compacted message files
+	Time    time.Time `json:"time"`
+}
+
+// Compaction is the outcome of compacting a single mailbox.
+type Compaction struct {
+	Box     string `json:"box"` // "sent" or "received"
+	Topic   string `json:"topic"`
+	From    SeqNo  `json:"from"`
+	Through SeqNo  `json:"through"`
+	Removed int64  `json:"removed"`
+}
+
+type Compactions []Compaction
+
+// GetSnapshot_Local returns the snapshot of the mailbox at topicNS, or nil if the mailbox has not been compacted.
+func GetSnapshot_Local(ctx context.Context, t *git.Tree, topicNS ns.NS) *id.Signed[Snapshot] {
+	snap, err := git.TryFromFile[id.Signed[Snapshot]](ctx, t, topicNS.Append(SnapshotFilebase))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	must.NoError(ctx, err)
+	return &snap
+}
+
+func compactedThrough(ctx context.Context, t *git.Tree, topicNS ns.NS) SeqNo {
+	if snap := GetSnapshot_Local(ctx, t, topicNS); snap != nil {
+		return snap.Value.Through
+	}
+	return 0
+}
+
+// Compact compacts the mailboxes in both directions between the owner and the peer, for all topics.
+// The retain most recent messages of each mailbox are kept.
+func Compact(
+	ctx context.Context,
+	ownerAddr id.OwnerAddress,
+	peerAddr id.PublicAddress,
+	retain int,
+) git.Change[form.Map, Compactions] {
+
+	owner := id.CloneOwner(ctx, ownerAddr)
+	peer := git.CloneOne(ctx, git.Address(peerAddr))
+	chg := Compact_StageOnly(ctx, owner, peer.Tree(), retain)
+	if len(chg.Result) > 0 {
+		proto.Commit(ctx, owner.Public.Tree(), chg)
+		owner.Public.Push(ctx)
+	}
+	return chg
+}
+
+// Compact_StageOnly rolls messages, which both sides have seen, into signed snapshots.
+// A sent message is compacted only after the peer has received it.
+// A received message is compacted any time, since the sender keeps its own copy until it compacts.
+func Compact_StageOnly(
+	ctx context.Context,
+	owner id.OwnerCloned,
+	peer *git.Tree,
+	retain int,
+) git.Change[form.Map, Compactions] {
+
+	must.Assertf(ctx, retain >= 0, "retention must be non-negative")
+
+	t := owner.Public.Tree()
+	ownerRootID := id.GetRootID(ctx, t)
+	peerRootID := id.GetRootID(ctx, peer)
+	priv := id.GetOwnerCredentials(ctx, owner)
+	compactions := Compactions{}
+
+	// sent messages
+	for _, topic := range listTopics_Local[SendBoxInfo](ctx, t, SendNS.Append(form.StringHashForFilename(string(peerRootID))), func(x SendBoxInfo) string { return x.Topic }) {
+		topicNS := SendTopicNS(peerRootID, topic)
+		next, _ := git.TryFromFile[SeqNo](ctx, t, topicNS.Append(NextFilebase))
+		received, _ := git.TryFromFile[SeqNo](ctx, peer, ReceiveTopicNS(ownerRootID, topic).Append(NextFilebase))
+		if c, ok := compactBox_StageOnly(ctx, t, priv, topicNS, topic, min(received, next-SeqNo(retain))); ok {
+			c.Box = "sent"
+			compactions = append(compactions, c)
+		}
+	}
+
+	// received messages
+	for _, topic := range listTopics_Local[ReceiveBoxInfo](ctx, t, ReceiveNS.Append(form.StringHashForFilename(string(peerRootID))), func(x ReceiveBoxInfo) string { return x.Topic }) {
+		topicNS := ReceiveTopicNS(peerRootID, topic)
+		next, _ := git.TryFromFile[SeqNo](ctx, t, topicNS.Append(NextFilebase))
+		if c, ok := compactBox_StageOnly(ctx, t, priv, topicNS, topic, next-SeqNo(retain)); ok {
+			c.Box = "received"
+			compactions = append(compactions, c)
+		}
+	}
+
+	return git.NewChange(
+		fmt.Sprintf("Compacted %d mailboxes", len(compactions)),
+		"mail_compact",
+		form.Map{"peer": peerRootID, "retain": retain},
+		compactions,
+		nil,
+	)
+}
+
+func listTopics_Local[Info form.Form](
+	ctx context.Context,
+	t *git.Tree,
+	peerNS ns.NS,
+	topicOf func(Info) string,
+) []string {
+
+	infos, err := git.TreeReadDir(ctx, t, peerNS)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	must.NoError(ctx, err)
+	topics := []string{}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		boxInfo, err := git.TryFromFile[Info](ctx, t, peerNS.Append(info.Name(), BoxInfoFilebase))
+		if err != nil {
+			continue
+		}
+		topics = append(topics, topicOf(boxInfo))
+	}
+	return topics
+}
+
+func compactBox_StageOnly(
+	ctx context.Context,
+	t *git.Tree,
+	priv id.PrivateCredentials,
+	topicNS ns.NS,
+	topic string,
+	through SeqNo,
+) (Compaction, bool) {
+
+	snap := Snapshot{Topic: topic}
+	if signed := GetSnapshot_Local(ctx, t, topicNS); signed != nil {
+		snap = signed.Value
+	}
+	from := snap.Through
+	if through <= from {
+		return Compaction{}, false
+	}
+
+	// chain the digest over the removed messages; receivers may have skipped some sequence numbers
+	digest := snap.Digest
+	removed := int64(0)
+	for i := from; i < through; i++ {
+		msgNS := topicNS.Append(strconv.Itoa(int(i)) + ".json")
+		if _, err := git.TreeStat(ctx, t, msgNS); err != nil {
+			continue
+		}
+		digest = form.StringHashForFilename(digest + string(git.FileToBytes(ctx, t, msgNS)))
+		_, err := git.TreeRemove(ctx, t, msgNS)
+		must.NoError(ctx, err)
+		removed++
+	}
+
+	snap.Through = through
+	snap.Count += removed
+	snap.Digest = digest
+	snap.Time = time.Now()
+	git.ToFileStage(ctx, t, topicNS.Append(SnapshotFilebase), id.Sign(ctx, priv, snap))
+
+	return Compaction{Topic: topic, From: from, Through: through, Removed: removed}, true
+}
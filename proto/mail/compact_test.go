@@ -0,0 +1,75 @@
+package mail
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestCompact(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	testSenderID := id.NewTestID(ctx, t, git.MainBranch, false)
+	testReceiverID := id.NewTestID(ctx, t, git.MainBranch, false)
+	id.Init_Local(ctx, testSenderID.OwnerCloned())
+	id.Init_Local(ctx, testReceiverID.OwnerCloned())
+	sender, receiver := testSenderID.Public.Tree(), testReceiverID.Public.Tree()
+
+	const testTopic = "topic"
+	respond := func(ctx context.Context, seqNo SeqNo, req string) (resp string, err error) {
+		return req, nil
+	}
+	send := func(n int) {
+		for i := 0; i < n; i++ {
+			Send_StageOnly(ctx, sender, receiver, testTopic, "msg")
+		}
+	}
+	receive := func() int {
+		return len(Receive_StageOnly(ctx, receiver, testSenderID.PublicAddress(), sender, testTopic, respond).Result)
+	}
+
+	send(5)
+	receive()
+	send(2)
+
+	// only messages received by the peer are compacted from the sender
+	sc := Compact_StageOnly(ctx, testSenderID.OwnerCloned(), receiver, 1)
+	if len(sc.Result) != 1 || sc.Result[0].Through != 5 || sc.Result[0].Removed != 5 {
+		t.Fatalf("unexpected sender compaction %v", sc.Result)
+	}
+	senderNS := SendTopicNS(id.GetRootID(ctx, receiver), testTopic)
+	for i := 0; i < 7; i++ {
+		_, err := git.TreeStat(ctx, sender, senderNS.Append(strconv.Itoa(i)+".json"))
+		if exists := err == nil; exists != (i >= 5) {
+			t.Errorf("message %d: expecting exists=%v", i, i >= 5)
+		}
+	}
+	snap := GetSnapshot_Local(ctx, sender, senderNS)
+	if snap == nil || !snap.Verify(ctx) || snap.Value.Through != 5 || snap.Value.Count != 5 {
+		t.Fatalf("unexpected snapshot %v", snap)
+	}
+
+	// receiver retains the latest two messages
+	rc := Compact_StageOnly(ctx, testReceiverID.OwnerCloned(), sender, 2)
+	if len(rc.Result) != 1 || rc.Result[0].Through != 3 || rc.Result[0].Removed != 3 {
+		t.Fatalf("unexpected receiver compaction %v", rc.Result)
+	}
+
+	// sequence numbers continue after compaction
+	if n := receive(); n != 2 {
+		t.Fatalf("expecting 2 messages, got %d", n)
+	}
+	confirmed, notConfirmed := Confirm_Local[string, string](ctx, sender, receiver, testTopic)
+	if len(confirmed) != 2 || len(notConfirmed) != 0 {
+		t.Fatalf("expecting 2 confirmed and 0 unconfirmed, got %v and %v", confirmed, notConfirmed)
+	}
+
+	// compaction is idempotent
+	if again := Compact_StageOnly(ctx, testSenderID.OwnerCloned(), receiver, 2); len(again.Result) != 0 {
+		t.Errorf("expecting no compaction, got %v", again.Result)
+	}
+}
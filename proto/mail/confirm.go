@@ -33,8 +33,14 @@ func Confirm_Local[Msg form.Form, Effect form.Form](
 	_, seqnoToSentMsg := ListSent_Local[Msg](ctx, sender, receiver, topic)
 	_, seqnoToReceivedMsgEffect := ListReceived_Local[Msg, Effect](ctx, sender, receiver, topic)
 
+	// messages compacted by the receiver were received, but their effects are no longer available
+	compacted := compactedThrough(ctx, receiver, ReceiveTopicNS(id.GetRootID(ctx, sender), topic))
+
 	// compute confirmed and not confirmed transmissions
 	for seqno, sentMsg := range seqnoToSentMsg {
+		if seqno < compacted {
+			continue
+		}
 		if receivedMsgEffect, ok := seqnoToReceivedMsgEffect[seqno]; ok {
 			confirmed = append(confirmed,
 				MsgEffect[Msg, Effect]{SeqNo: seqno, Msg: sentMsg, Effect: receivedMsgEffect.Effect},
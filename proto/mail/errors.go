@@ -0,0 +1,9 @@
+package mail
+
+import "errors"
+
+var (
+	ErrInvalidSignature  = errors.New("signature not valid")
+	ErrSenderKeyMismatch = errors.New("message is not signed with the sender's registered key")
+	ErrInvalidReplay     = errors.New("replay range not valid")
+)
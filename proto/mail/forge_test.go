@@ -0,0 +1,86 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestReceiveForgedKey(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	testSenderID := id.NewTestID(ctx, t, git.MainBranch, false)
+	testReceiverID := id.NewTestID(ctx, t, git.MainBranch, false)
+	testForgerID := id.NewTestID(ctx, t, git.MainBranch, false)
+	id.Init_Local(ctx, testSenderID.OwnerCloned())
+	id.Init_Local(ctx, testReceiverID.OwnerCloned())
+	id.Init_Local(ctx, testForgerID.OwnerCloned())
+
+	const testTopic = "topic"
+
+	// the forger writes a validly-signed message, using its own key, into the sender's repo
+	forged := id.OwnerCloned{Public: testSenderID.Public, Private: testForgerID.Private}
+	SendSigned_StageOnly(ctx, forged, testReceiverID.Public.Tree(), testTopic, "forged")
+
+	respond := func(ctx context.Context, _ SeqNo, signedReq id.Signed[string]) (resp string, err error) {
+		return signedReq.Value, nil
+	}
+
+	r0, rej0 := ReceiveSigned_StageOnly[string, string](
+		ctx,
+		testReceiverID.OwnerCloned(),
+		testSenderID.PublicAddress(),
+		testSenderID.Public.Tree(),
+		testTopic,
+		respond,
+	)
+	if len(r0.Result) != 0 {
+		t.Fatalf("expecting no received messages, got %v", r0.Result)
+	}
+	if len(rej0) != 1 || rej0[0].SeqNo != 0 {
+		t.Fatalf("expecting one rejection of message 0, got %v", rej0)
+	}
+	if !strings.Contains(rej0[0].Reason, ErrSenderKeyMismatch.Error()) {
+		t.Errorf("expecting sender key mismatch, got %v", rej0[0].Reason)
+	}
+
+	// a genuine message following the forged one is received
+	SendSigned_StageOnly(ctx, testSenderID.OwnerCloned(), testReceiverID.Public.Tree(), testTopic, "genuine")
+	r1, rej1 := ReceiveSigned_StageOnly[string, string](
+		ctx,
+		testReceiverID.OwnerCloned(),
+		testSenderID.PublicAddress(),
+		testSenderID.Public.Tree(),
+		testTopic,
+		respond,
+	)
+	if len(r1.Result) != 1 || r1.Result[0].Effect != "genuine" {
+		t.Fatalf("expecting genuine message, got %v", r1.Result)
+	}
+	if len(rej1) != 1 {
+		t.Fatalf("expecting forged message to be rejected again, got %v", rej1)
+	}
+}
+
+func TestVerifySender(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	testSenderID := id.NewTestID(ctx, t, git.MainBranch, false)
+	testOtherID := id.NewTestID(ctx, t, git.MainBranch, false)
+	id.Init_Local(ctx, testSenderID.OwnerCloned())
+	id.Init_Local(ctx, testOtherID.OwnerCloned())
+
+	senderCred := id.GetPublicCredentials(ctx, testSenderID.Public.Tree())
+	otherCred := id.GetPublicCredentials(ctx, testOtherID.Public.Tree())
+
+	if err := VerifySender_Local(ctx, testSenderID.Public.Tree(), senderCred.ID); err != nil {
+		t.Errorf("expecting no error, got %v", err)
+	}
+	if err := VerifySender_Local(ctx, testSenderID.Public.Tree(), otherCred.ID); !errors.Is(err, ErrSenderKeyMismatch) {
+		t.Errorf("expecting sender key mismatch, got %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/ns"
+)
+
+type BoxKind string
+
+const (
+	SentBox     BoxKind = "sent"
+	ReceivedBox BoxKind = "received"
+)
+
+// Box describes a mailbox, with its topic and peer decoded from the box info.
+type Box struct {
+	Kind       BoxKind              `json:"kind"`
+	Topic      string               `json:"topic"`
+	PeerCred   id.PublicCredentials `json:"peer_credentials"`
+	Path       string               `json:"path"`
+	Next       SeqNo                `json:"next"`                 // next sequence number on this side
+	Compacted  SeqNo                `json:"compacted"`            // messages below this sequence number have been compacted
+	PeerNext   *SeqNo               `json:"peer_next,omitempty"`  // next sequence number on the peer side, if the peer is known
+	Unreceived *int64               `json:"unreceived,omitempty"` // number of sent messages not yet received, if the peer is known
+}
+
+type Boxes []Box
+
+func (x Boxes) Sort() {
+	sort.Slice(x, func(i, j int) bool {
+		if x[i].Kind != x[j].Kind {
+			return x[i].Kind > x[j].Kind
+		}
+		return x[i].Path < x[j].Path
+	})
+}
+
+func ListBoxes(
+	ctx context.Context,
+	ownerAddr id.PublicAddress,
+	peerAddrs ...id.PublicAddress,
+) Boxes {
+
+	peers := make([]*git.Tree, len(peerAddrs))
+	for i, a := range peerAddrs {
+		peers[i] = git.CloneOne(ctx, git.Address(a)).Tree()
+	}
+	return ListBoxes_Local(ctx, git.CloneOne(ctx, git.Address(ownerAddr)).Tree(), peers...)
+}
+
+// ListBoxes_Local lists all mailboxes in the owner's repo.
+// The number of unreceived messages is computed for mailboxes with one of the given peers.
+func ListBoxes_Local(
+	ctx context.Context,
+	owner *git.Tree,
+	peers ...*git.Tree,
+) Boxes {
+
+	ownerRootID := id.GetRootID(ctx, owner)
+	peerByDir := map[string]*git.Tree{}
+	for _, p := range peers {
+		peerByDir[form.StringHashForFilename(string(id.GetRootID(ctx, p)))] = p
+	}
+
+	boxes := Boxes{}
+	for _, peerDir := range listDirs_Local(ctx, owner, SendNS) {
+		peer := peerByDir[peerDir]
+		for _, topicDir := range listDirs_Local(ctx, owner, SendNS.Append(peerDir)) {
+			topicNS := SendNS.Append(peerDir, topicDir)
+			info, err := git.TryFromFile[SendBoxInfo](ctx, owner, topicNS.Append(BoxInfoFilebase))
+			if err != nil {
+				continue
+			}
+			box := newBox_Local(ctx, owner, SentBox, info.Topic, info.ReceiverCred, topicNS)
+			if peer != nil {
+				peerNext, _ := git.TryFromFile[SeqNo](ctx, peer, ReceiveTopicNS(ownerRootID, info.Topic).Append(NextFilebase))
+				box.setPeerNext(peerNext, int64(box.Next-peerNext))
+			}
+			boxes = append(boxes, box)
+		}
+	}
+	for _, peerDir := range listDirs_Local(ctx, owner, ReceiveNS) {
+		peer := peerByDir[peerDir]
+		for _, topicDir := range listDirs_Local(ctx, owner, ReceiveNS.Append(peerDir)) {
+			topicNS := ReceiveNS.Append(peerDir, topicDir)
+			info, err := git.TryFromFile[ReceiveBoxInfo](ctx, owner, topicNS.Append(BoxInfoFilebase))
+			if err != nil {
+				continue
+			}
+			box := newBox_Local(ctx, owner, ReceivedBox, info.Topic, info.SenderCred, topicNS)
+			if peer != nil {
+				peerNext, _ := git.TryFromFile[SeqNo](ctx, peer, SendTopicNS(ownerRootID, info.Topic).Append(NextFilebase))
+				box.setPeerNext(peerNext, int64(peerNext-box.Next))
+			}
+			boxes = append(boxes, box)
+		}
+	}
+	boxes.Sort()
+	return boxes
+}
+
+func newBox_Local(ctx context.Context, t *git.Tree, kind BoxKind, topic string, peerCred id.PublicCredentials, topicNS ns.NS) Box {
+	next, _ := git.TryFromFile[SeqNo](ctx, t, topicNS.Append(NextFilebase))
+	return Box{
+		Kind:      kind,
+		Topic:     topic,
+		PeerCred:  peerCred,
+		Path:      topicNS.GitPath(),
+		Next:      next,
+		Compacted: compactedThrough(ctx, t, topicNS),
+	}
+}
+
+func (x *Box) setPeerNext(peerNext SeqNo, unreceived int64) {
+	x.PeerNext = &peerNext
+	x.Unreceived = &unreceived
+}
+
+func listDirs_Local(ctx context.Context, t *git.Tree, dirNS ns.NS) []string {
+	infos, err := git.TreeReadDir(ctx, t, dirNS)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	must.NoError(ctx, err)
+	dirs := []string{}
+	for _, info := range infos {
+		if info.IsDir() {
+			dirs = append(dirs, info.Name())
+		}
+	}
+	return dirs
+}
+
+// SignatureCheck is the result of verifying a signed message.
+type SignatureCheck struct {
+	PublicKey id.Ed25519PublicKey `json:"ed25519_public_key"`
+	Valid     bool                `json:"valid"`               // the signature verifies against the public key
+	KeyError  string              `json:"key_error,omitempty"` // why the key is not a current key of the signer, if known
+}
+
+// MessageView is a message, as stored in a mailbox, together with the verification of its signatures.
+type MessageView struct {
+	Kind        BoxKind         `json:"kind"`
+	Topic       string          `json:"topic"`
+	SeqNo       SeqNo           `json:"seqno"`
+	Path        string          `json:"path"`
+	Message     any             `json:"message"`
+	MessageSig  *SignatureCheck `json:"message_signature,omitempty"`
+	Effect      any             `json:"effect,omitempty"`
+	EffectSig   *SignatureCheck `json:"effect_signature,omitempty"`
+	IsCompacted bool            `json:"is_compacted"`
+}
+
+func ShowMessage(
+	ctx context.Context,
+	ownerAddr id.PublicAddress,
+	peerAddr id.PublicAddress,
+	kind BoxKind,
+	topic string,
+	seqNo SeqNo,
+) MessageView {
+
+	return ShowMessage_Local(
+		ctx,
+		git.CloneOne(ctx, git.Address(ownerAddr)).Tree(),
+		git.CloneOne(ctx, git.Address(peerAddr)).Tree(),
+		kind,
+		topic,
+		seqNo,
+	)
+}
+
+// ShowMessage_Local returns a message from a mailbox of the owner with the peer.
+// Signatures of messages are checked against the key chain of the sender,
+// and signatures of effects against the key chain of the receiver.
+// Key checks do not take message dates into account, so messages signed before a revocation are reported as revoked.
+func ShowMessage_Local(
+	ctx context.Context,
+	owner *git.Tree,
+	peer *git.Tree,
+	kind BoxKind,
+	topic string,
+	seqNo SeqNo,
+) MessageView {
+
+	var topicNS ns.NS
+	var sender, receiver *git.Tree
+	switch kind {
+	case SentBox:
+		topicNS, sender, receiver = SendTopicNS(id.GetRootID(ctx, peer), topic), owner, peer
+	case ReceivedBox:
+		topicNS, sender, receiver = ReceiveTopicNS(id.GetRootID(ctx, peer), topic), peer, owner
+	default:
+		must.Errorf(ctx, "unknown mailbox kind %q", kind)
+	}
+
+	msgNS := topicNS.Append(strconv.Itoa(int(seqNo)) + ".json")
+	view := MessageView{Kind: kind, Topic: topic, SeqNo: seqNo, Path: msgNS.GitPath()}
+	raw, err := git.TryFromFile[any](ctx, owner, msgNS)
+	if errors.Is(err, os.ErrNotExist) && seqNo < compactedThrough(ctx, owner, topicNS) {
+		view.IsCompacted = true
+		return view
+	}
+	must.Assert(ctx, err == nil, fmt.Errorf("message %d not found in %v (%w)", seqNo, msgNS.GitPath(), err))
+
+	senderChain := id.GetKeyChain(ctx, sender)
+	receiverChain := id.GetKeyChain(ctx, receiver)
+	switch kind {
+	case SentBox:
+		view.Message, view.MessageSig = checkSigned(ctx, raw, senderChain)
+	case ReceivedBox:
+		var msgEffect MsgEffect[any, any]
+		must.NoError(ctx, recode(ctx, raw, &msgEffect))
+		view.Message, view.MessageSig = checkSigned(ctx, msgEffect.Msg, senderChain)
+		view.Effect, view.EffectSig = checkSigned(ctx, msgEffect.Effect, receiverChain)
+	}
+	return view
+}
+
+// checkSigned returns the value of a signed message and its signature check, or the message itself if it is not signed.
+func checkSigned(ctx context.Context, raw any, chain id.KeyChain) (any, *SignatureCheck) {
+	var signed id.Signed[any]
+	if err := recode(ctx, raw, &signed); err != nil || len(signed.Signature) == 0 {
+		return raw, nil
+	}
+	valid, err := must.Try1(func() bool { return signed.Verify(ctx) })
+	check := &SignatureCheck{PublicKey: signed.PublicKeyEd25519, Valid: err == nil && valid}
+	if err := chain.CheckKey(signed.PublicKeyEd25519, nil); err != nil {
+		check.KeyError = err.Error()
+	}
+	return signed.Value, check
+}
+
+func recode(ctx context.Context, from any, into any) error {
+	data, err := form.EncodeBytes(ctx, from)
+	if err != nil {
+		return err
+	}
+	return form.DecodeBytesInto(ctx, data, into)
+}
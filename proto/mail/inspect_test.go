@@ -0,0 +1,68 @@
+package mail
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestInspect(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	testSenderID := id.NewTestID(ctx, t, git.MainBranch, false)
+	testReceiverID := id.NewTestID(ctx, t, git.MainBranch, false)
+	id.Init_Local(ctx, testSenderID.OwnerCloned())
+	id.Init_Local(ctx, testReceiverID.OwnerCloned())
+	sender, receiver := testSenderID.Public.Tree(), testReceiverID.Public.Tree()
+
+	const testTopic = "topic"
+	respond := func(ctx context.Context, _ SeqNo, req string) (resp string, err error) {
+		return req, nil
+	}
+	receive := func() int {
+		chg, _ := Respond_StageOnly[string, string](ctx, testReceiverID.OwnerCloned(), testSenderID.PublicAddress(), sender, testTopic, respond)
+		return len(chg.Result)
+	}
+
+	for _, msg := range []string{"a", "b", "c"} {
+		Request_StageOnly(ctx, testSenderID.OwnerCloned(), receiver, testTopic, msg)
+	}
+	receive()
+	Request_StageOnly(ctx, testSenderID.OwnerCloned(), receiver, testTopic, "d")
+
+	// list the sender's boxes
+	boxes := ListBoxes_Local(ctx, sender, receiver)
+	if len(boxes) != 1 || boxes[0].Kind != SentBox || boxes[0].Topic != testTopic || boxes[0].Next != 4 {
+		t.Fatalf("unexpected sender boxes %v", boxes)
+	}
+	if boxes[0].Unreceived == nil || *boxes[0].Unreceived != 1 {
+		t.Errorf("expecting 1 unreceived message, got %v", boxes[0].Unreceived)
+	}
+
+	// list the receiver's boxes, without knowing the peer
+	boxes = ListBoxes_Local(ctx, receiver)
+	if len(boxes) != 1 || boxes[0].Kind != ReceivedBox || boxes[0].Next != 3 || boxes[0].Unreceived != nil {
+		t.Fatalf("unexpected receiver boxes %v", boxes)
+	}
+
+	// show a sent message
+	sent := ShowMessage_Local(ctx, sender, receiver, SentBox, testTopic, 1)
+	if sent.MessageSig == nil || !sent.MessageSig.Valid || sent.MessageSig.KeyError != "" {
+		t.Errorf("expecting valid message signature, got %v", sent.MessageSig)
+	}
+
+	// show a received message
+	received := ShowMessage_Local(ctx, receiver, sender, ReceivedBox, testTopic, 2)
+	if received.MessageSig == nil || !received.MessageSig.Valid || received.Effect == nil {
+		t.Errorf("unexpected received message %v", received)
+	}
+
+	// rewind and receive again
+	Rewind_StageOnly(ctx, receiver, sender, testTopic, 1, 3)
+	if n := receive(); n != 2 {
+		t.Errorf("expecting 2 replayed messages, got %d", n)
+	}
+}
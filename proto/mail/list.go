@@ -19,8 +19,7 @@ func ListSent_Local[Msg form.Form](
 ) (SentMsgs[Msg], map[SeqNo]Msg) {
 
 	// prep
-	receiverCred := id.GetPublicCredentials(ctx, receiver)
-	senderTopicNS := SendTopicNS(receiverCred.ID, topic)
+	senderTopicNS := SendTopicNS(id.GetRootID(ctx, receiver), topic)
 
 	// read all sent messages (at the sender)
 	sentMsgs := SentMsgs[Msg]{}
@@ -56,8 +55,7 @@ func ListReceived_Local[Msg form.Form, Effect form.Form](
 ) (MsgEffects[Msg, Effect], map[SeqNo]MsgEffect[Msg, Effect]) {
 
 	// prep
-	senderCred := id.GetPublicCredentials(ctx, sender)
-	receiverTopicNS := ReceiveTopicNS(senderCred.ID, topic)
+	receiverTopicNS := ReceiveTopicNS(id.GetRootID(ctx, sender), topic)
 
 	// read all received messages and the resulting effects (at the receiver)
 	seqnoToMsgEffect := map[SeqNo]MsgEffect[Msg, Effect]{}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/lib4git/base"
@@ -41,10 +42,9 @@ func Receive_StageOnly[Msg form.Form, Effect form.Form](
 ) git.Change[form.Map, []MsgEffect[Msg, Effect]] {
 
 	// prep
-	receiverCred := id.GetPublicCredentials(ctx, receiver)
 	senderCred := id.GetPublicCredentials(ctx, sender)
-	senderTopicNS := SendTopicNS(receiverCred.ID, topic)
-	receiverTopicNS := ReceiveTopicNS(senderCred.ID, topic)
+	senderTopicNS := SendTopicNS(id.GetRootID(ctx, receiver), topic)
+	receiverTopicNS := ReceiveTopicNS(id.GetRootID(ctx, sender), topic)
 	receiverNextNS := receiverTopicNS.Append(NextFilebase)
 	senderNextNS := senderTopicNS.Append(NextFilebase)
 	receiverInfoNS := receiverTopicNS.Append(BoxInfoFilebase)
@@ -93,12 +93,26 @@ func Receive_StageOnly[Msg form.Form, Effect form.Form](
 	)
 }
 
+// Rejection records a message that was refused at receipt, because it could not be attributed to its sender.
+type Rejection struct {
+	Sender id.PublicAddress `json:"sender"`
+	Topic  string           `json:"topic"`
+	SeqNo  SeqNo            `json:"seqno"`
+	Reason string           `json:"reason"`
+}
+
+type Rejections []Rejection
+
 type SignedReceiver[Msg form.Form, Effect form.Form] func(
 	ctx context.Context,
 	seqNo SeqNo,
 	signedMsg id.Signed[Msg],
 ) (effect Effect, err error)
 
+// ReceiveSigned_StageOnly receives messages whose signatures verify against the key chain
+// published in the sender's repo at the time of receipt.
+// Messages signed by a revoked key are accepted only if they are received before the revocation.
+// Messages that fail verification are not passed to the receiver and are returned as rejections.
 func ReceiveSigned_StageOnly[Msg form.Form, Effect form.Form](
 	ctx context.Context,
 	receiverCloned id.OwnerCloned,
@@ -106,16 +120,27 @@ func ReceiveSigned_StageOnly[Msg form.Form, Effect form.Form](
 	senderPublic *git.Tree,
 	topic string,
 	receive SignedReceiver[Msg, Effect],
-) git.Change[form.Map, []MsgEffect[Msg, Effect]] {
+) (git.Change[form.Map, []MsgEffect[Msg, Effect]], Rejections) {
 
 	receiverPrivCred := id.GetOwnerCredentials(ctx, receiverCloned)
+	senderChain := id.GetKeyChain(ctx, senderPublic)
+	rejected := Rejections{}
+	reject := func(seqNo SeqNo, err error) error {
+		rejected = append(rejected, Rejection{Sender: senderAddr, Topic: topic, SeqNo: seqNo, Reason: err.Error()})
+		return err
+	}
+
 	var receiver Receiver[id.Signed[Msg], id.Signed[Effect]] = func(
 		ctx context.Context,
 		seqNo SeqNo,
 		signedReq id.Signed[Msg],
 	) (signedResp id.Signed[Effect], err error) {
 		if !signedReq.Verify(ctx) {
-			return signedResp, fmt.Errorf("signature not valid")
+			return signedResp, reject(seqNo, ErrInvalidSignature)
+		}
+		received := time.Now()
+		if err := senderChain.CheckKey(signedReq.PublicKeyEd25519, &received); err != nil {
+			return signedResp, reject(seqNo, fmt.Errorf("%w: message %d (%w)", ErrSenderKeyMismatch, seqNo, err))
 		}
 		effect, err := receive(ctx, seqNo, signedReq)
 		if err != nil {
@@ -138,5 +163,39 @@ func ReceiveSigned_StageOnly[Msg form.Form, Effect form.Form](
 		form.Map{"topic": topic},
 		msgEffects,
 		form.Forms{recvOnly},
-	)
+	), rejected
+}
+
+// VerifySender_Local returns an error if the key chain published in the sender's repo
+// does not include the credentials the sender is registered with.
+func VerifySender_Local(
+	ctx context.Context,
+	senderPublic *git.Tree,
+	registeredID id.ID,
+) error {
+
+	senderChain := id.GetKeyChain(ctx, senderPublic)
+	if !senderChain.Has(registeredID) {
+		return fmt.Errorf("%w: sender repo has credentials %v, expecting %v", ErrSenderKeyMismatch, senderChain.Current.ID, registeredID)
+	}
+	return nil
+}
+
+// RejectPending_Local returns rejections for all messages the sender has sent, which the receiver has not yet received.
+func RejectPending_Local(
+	ctx context.Context,
+	receiver *git.Tree,
+	senderAddr id.PublicAddress,
+	sender *git.Tree,
+	topic string,
+	reason error,
+) Rejections {
+
+	receiverNextSeqNo, _ := git.TryFromFile[SeqNo](ctx, receiver, ReceiveTopicNS(id.GetRootID(ctx, sender), topic).Append(NextFilebase))
+	senderNextSeqNo, _ := git.TryFromFile[SeqNo](ctx, sender, SendTopicNS(id.GetRootID(ctx, receiver), topic).Append(NextFilebase))
+	rejected := Rejections{}
+	for i := receiverNextSeqNo; i < senderNextSeqNo; i++ {
+		rejected = append(rejected, Rejection{Sender: senderAddr, Topic: topic, SeqNo: i, Reason: reason.Error()})
+	}
+	return rejected
 }
@@ -0,0 +1,36 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Rewind_StageOnly rewinds the mailbox of topic between the sender and the receiver,
+// so that the next receive processes the sent messages with sequence numbers in [from, to).
+// Rewinding rewrites both repos and is meant to be applied only to disposable clones.
+func Rewind_StageOnly(
+	ctx context.Context,
+	receiver *git.Tree,
+	sender *git.Tree,
+	topic string,
+	from SeqNo,
+	to SeqNo,
+) {
+
+	senderTopicNS := SendTopicNS(id.GetRootID(ctx, receiver), topic)
+	receiverTopicNS := ReceiveTopicNS(id.GetRootID(ctx, sender), topic)
+
+	senderNext, _ := git.TryFromFile[SeqNo](ctx, sender, senderTopicNS.Append(NextFilebase))
+	must.Assert(ctx, 0 <= from && from <= to && to <= senderNext,
+		fmt.Errorf("%w: range [%d, %d) is outside of the sent messages [0, %d)", ErrInvalidReplay, from, to, senderNext))
+	compacted := compactedThrough(ctx, sender, senderTopicNS)
+	must.Assert(ctx, from >= compacted,
+		fmt.Errorf("%w: messages below %d have been compacted by the sender", ErrInvalidReplay, compacted))
+
+	git.ToFileStage(ctx, sender, senderTopicNS.Append(NextFilebase), to)
+	git.ToFileStage(ctx, receiver, receiverTopicNS.Append(NextFilebase), from)
+}
@@ -29,7 +29,7 @@ func TestReqResp(t *testing.T) {
 		return req, nil
 	}
 
-	r0 := Respond_StageOnly[string, string](
+	r0, _ := Respond_StageOnly[string, string](
 		ctx,
 		testReceiverID.OwnerCloned(),
 		testSenderID.PublicAddress(),
@@ -47,7 +47,7 @@ func TestReqResp(t *testing.T) {
 	Request_StageOnly(ctx, testSenderID.OwnerCloned(), testReceiverID.Public.Tree(), testTopic, testMsg[1])
 	Request_StageOnly(ctx, testSenderID.OwnerCloned(), testReceiverID.Public.Tree(), testTopic, testMsg[2])
 
-	r12 := Respond_StageOnly[string, string](
+	r12, _ := Respond_StageOnly[string, string](
 		ctx,
 		testReceiverID.OwnerCloned(),
 		testSenderID.PublicAddress(),
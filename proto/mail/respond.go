@@ -23,7 +23,7 @@ func Respond_StageOnly[Req form.Form, Resp form.Form](
 	senderPublic *git.Tree,
 	topic string,
 	respond Responder[Req, Resp],
-) git.Change[form.Map, []ResponseEnvelope[Resp]] {
+) (git.Change[form.Map, []ResponseEnvelope[Resp]], Rejections) {
 
 	var signedReceive SignedReceiver[RequestEnvelope[Req], ResponseEnvelope[Resp]] = func(
 		ctx context.Context,
@@ -43,7 +43,7 @@ func Respond_StageOnly[Req form.Form, Resp form.Form](
 		}, nil
 	}
 
-	chg := ReceiveSigned_StageOnly[RequestEnvelope[Req], ResponseEnvelope[Resp]](ctx, receiverCloned, senderAddr, senderPublic, topic, signedReceive)
+	chg, rejected := ReceiveSigned_StageOnly[RequestEnvelope[Req], ResponseEnvelope[Resp]](ctx, receiverCloned, senderAddr, senderPublic, topic, signedReceive)
 	respEnvs := make([]ResponseEnvelope[Resp], len(chg.Result))
 	for i, msgEffect := range chg.Result {
 		respEnvs[i] = msgEffect.Effect
@@ -55,5 +55,5 @@ func Respond_StageOnly[Req form.Form, Resp form.Form](
 		form.Map{"topic": topic},
 		respEnvs,
 		form.Forms{chg},
-	)
+	), rejected
 }
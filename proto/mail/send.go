@@ -19,9 +19,9 @@ func SendMakeMsg_StageOnly[Msg form.Form](
 	mkMsg func(context.Context, SeqNo) Msg,
 ) git.Change[form.Map, SentMsg[Msg]] {
 
-	// fetch receiver id
+	// fetch receiver id; boxes are keyed by the receiver's root id, which is stable across key rotations
 	receiverCred := id.GetPublicCredentials(ctx, receiver)
-	topicNS := SendTopicNS(receiverCred.ID, topic)
+	topicNS := SendTopicNS(id.GetRootID(ctx, receiver), topic)
 
 	// write receiver id + topic in send box file
 	infoValue := SendBoxInfo{ReceiverCred: receiverCred, Topic: topic}
@@ -92,7 +92,7 @@ func TestSendReceiveSigned(t *testing.T) {
 		return signedReq.Value, nil
 	}
 
-	r0 := ReceiveSigned_StageOnly[string, string](
+	r0, _ := ReceiveSigned_StageOnly[string, string](
 		ctx,
 		testReceiverID.OwnerCloned(),
 		testSenderID.PublicAddress(),
@@ -117,7 +117,7 @@ func TestSendReceiveSigned(t *testing.T) {
 		t.Fatalf("expecting %v, got %v", 2, s2.Result)
 	}
 
-	r12 := ReceiveSigned_StageOnly[string, string](
+	r12, _ := ReceiveSigned_StageOnly[string, string](
 		ctx,
 		testReceiverID.OwnerCloned(),
 		testSenderID.PublicAddress(),
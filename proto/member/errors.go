@@ -0,0 +1,9 @@
+package member
+
+import "errors"
+
+var (
+	ErrUnknownCapability = errors.New("unknown capability")
+	ErrNotPermitted      = errors.New("not permitted")
+	ErrGroupCycle        = errors.New("group nesting would create a cycle")
+)
@@ -15,8 +15,8 @@ func FindClonedUser_Local(
 
 ) User {
 
-	voterCred := id.GetPublicCredentials(ctx, userCloned.Public.Tree())
-	users := LookupUserByID_Local(ctx, cloned, voterCred.ID)
+	userChain := id.GetKeyChain(ctx, userCloned.Public.Tree())
+	users := LookupUserByID_Local(ctx, cloned, userChain.IDs()...)
 	must.Assertf(ctx, len(users) > 0, "user not found in community")
 	return users[0]
 }
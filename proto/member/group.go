@@ -3,10 +3,12 @@ package member
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/kv"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
 	"github.com/gov4git/lib4git/must"
@@ -47,9 +49,7 @@ func AddGroup_StageOnly(ctx context.Context, cloned gov.Cloned, name Group) git.
 
 	// log
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "group_add",
-		Args:   trace.M{"name": name},
-		Result: nil,
+		Payload: &AddGroupTrace{Name: name},
 	})
 
 	return chg
@@ -64,14 +64,99 @@ func RemoveGroup(ctx context.Context, addr gov.Address, name Group) {
 
 func RemoveGroup_StageOnly(ctx context.Context, cloned gov.Cloned, name Group) git.ChangeNoResult {
 	groupsKV.Remove(ctx, groupsNS, cloned.Tree(), name)
-	groupUsersKKV.RemovePrimary(ctx, groupUsersNS, cloned.Tree(), name) // remove memberships
+	if groupUsersKKV.Primary().Contains(ctx, groupUsersNS, cloned.Tree(), name) {
+		groupUsersKKV.RemovePrimary(ctx, groupUsersNS, cloned.Tree(), name) // remove memberships
+	}
+	if groupSubgroupsKKV.Primary().Contains(ctx, groupSubgroupsNS, cloned.Tree(), name) {
+		groupSubgroupsKKV.RemovePrimary(ctx, groupSubgroupsNS, cloned.Tree(), name) // remove nested groups
+	}
+	for _, parent := range listGroups_Local(ctx, cloned) {
+		if slices.Contains(ListSubgroups_Local(ctx, cloned, parent), name) {
+			groupSubgroupsKKV.Remove(ctx, groupSubgroupsNS, cloned.Tree(), parent, name) // remove from parent groups
+		}
+	}
+	for _, cap := range ListGroupCapabilities_Local(ctx, cloned, name) {
+		capabilityGroupsKKV.Remove(ctx, capabilityGroupsNS, cloned.Tree(), cap, name)
+	}
 
 	// log
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "group_remove",
-		Args:   trace.M{"name": name},
-		Result: nil,
+		Payload: &RemoveGroupTrace{Name: name},
 	})
 
 	return git.NewChangeNoResult(fmt.Sprintf("Remove group %v", name), "member_remove_group")
 }
+
+// group props
+
+const (
+	// OpenGroupProp is a boolean group property, which allows users to join and leave the group through the bureau.
+	OpenGroupProp = "open"
+)
+
+func SetGroupProp[V form.Form](ctx context.Context, addr gov.Address, group Group, key string, value V) {
+	cloned := gov.Clone(ctx, addr)
+	chg := SetGroupProp_StageOnly(ctx, cloned, group, key, value)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+}
+
+func SetGroupProp_StageOnly[V form.Form](
+	ctx context.Context,
+	cloned gov.Cloned,
+	group Group,
+	key string,
+	value V,
+) git.ChangeNoResult {
+	must.Assertf(ctx, IsGroup_Local(ctx, cloned, group), "%v is not a group", group)
+	propKV := kv.KV[string, V]{}
+	return propKV.Set(ctx, groupsKV.KeyNS(groupsNS, group), cloned.Tree(), key, value)
+}
+
+func GetGroupProp_Local[V form.Form](ctx context.Context, cloned gov.Cloned, group Group, key string) V {
+	must.Assertf(ctx, IsGroup_Local(ctx, cloned, group), "%v is not a group", group)
+	propKV := kv.KV[string, V]{}
+	return propKV.Get(ctx, groupsKV.KeyNS(groupsNS, group), cloned.Tree(), key)
+}
+
+func GetGroupPropOrDefault_Local[V form.Form](
+	ctx context.Context,
+	cloned gov.Cloned,
+	group Group,
+	key string,
+	default_ V,
+) V {
+	must.Assertf(ctx, IsGroup_Local(ctx, cloned, group), "%v is not a group", group)
+	v, err := must.Try1(func() V { return GetGroupProp_Local[V](ctx, cloned, group, key) })
+	if err != nil {
+		return default_
+	}
+	return v
+}
+
+func SetGroupOpen(ctx context.Context, addr gov.Address, group Group, open bool) {
+	cloned := gov.Clone(ctx, addr)
+	chg := SetGroupOpen_StageOnly(ctx, cloned, group, open)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+}
+
+func SetGroupOpen_StageOnly(ctx context.Context, cloned gov.Cloned, group Group, open bool) git.ChangeNoResult {
+	must.Assertf(ctx, group != Everybody, "group %v cannot be opened", Everybody)
+	SetGroupProp_StageOnly(ctx, cloned, group, OpenGroupProp, open)
+
+	// log
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &SetGroupOpenTrace{Name: group, Open: open},
+	})
+
+	return git.NewChangeNoResult(fmt.Sprintf("Set group %v open to %v", group, open), "member_set_group_open")
+}
+
+func IsGroupOpen(ctx context.Context, addr gov.Address, group Group) bool {
+	return IsGroupOpen_Local(ctx, gov.Clone(ctx, addr), group)
+}
+
+func IsGroupOpen_Local(ctx context.Context, cloned gov.Cloned, group Group) bool {
+	return GetGroupPropOrDefault_Local[bool](ctx, cloned, group, OpenGroupProp, false)
+}
@@ -46,9 +46,7 @@ func AddMember_StageOnly(ctx context.Context, cloned gov.Cloned, user User, grou
 
 	// log
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "add_user_to_group",
-		Args:   trace.M{"user": user, "group": group},
-		Result: nil,
+		Payload: &AddMemberTrace{User: user, Group: group},
 	})
 
 	return git.NewChangeNoResult(fmt.Sprintf("Added user %v to group %v", user, group), "member_add_member")
@@ -58,7 +56,18 @@ func IsMember(ctx context.Context, addr gov.Address, user User, group Group) boo
 	return IsMember_Local(ctx, gov.Clone(ctx, addr), user, group)
 }
 
+// IsMember_Local returns true if the user is a member of the group, directly or through a nested group.
 func IsMember_Local(ctx context.Context, cloned gov.Cloned, user User, group Group) bool {
+	for _, g := range listGroupClosure_Local(ctx, cloned, group) {
+		if IsDirectMember_Local(ctx, cloned, user, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDirectMember_Local returns true if the user has been added to the group itself.
+func IsDirectMember_Local(ctx context.Context, cloned gov.Cloned, user User, group Group) bool {
 	var userHasGroup, groupHasUser bool
 	must.Try(
 		func() { userHasGroup = userGroupsKKV.Get(ctx, userGroupsNS, cloned.Tree(), user, group) },
@@ -82,9 +91,7 @@ func RemoveMember_StageOnly(ctx context.Context, cloned gov.Cloned, user User, g
 
 	// log
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "remove_user_from_group",
-		Args:   trace.M{"user": user, "group": group},
-		Result: nil,
+		Payload: &RemoveMemberTrace{User: user, Group: group},
 	})
 
 	return git.NewChangeNoResult(fmt.Sprintf("Removed user %v from group %v", user, group), "member_remove_member")
@@ -94,7 +101,28 @@ func ListUserGroups(ctx context.Context, addr gov.Address, user User) []Group {
 	return ListUserGroups_Local(ctx, gov.Clone(ctx, addr), user)
 }
 
+// ListUserGroups_Local returns the groups the user is a member of, directly or through nested groups.
 func ListUserGroups_Local(ctx context.Context, cloned gov.Cloned, user User) []Group {
+	direct := ListDirectUserGroups_Local(ctx, cloned, user)
+	seen := map[Group]bool{}
+	groups := []Group{}
+	for _, g := range direct {
+		seen[g] = true
+		groups = append(groups, g)
+	}
+	for _, g := range direct {
+		for _, a := range listGroupAncestors_Local(ctx, cloned, g) {
+			if !seen[a] {
+				seen[a] = true
+				groups = append(groups, a)
+			}
+		}
+	}
+	return groups
+}
+
+// ListDirectUserGroups_Local returns the groups the user has been added to.
+func ListDirectUserGroups_Local(ctx context.Context, cloned gov.Cloned, user User) []Group {
 	return userGroupsKKV.ListSecondaryKeys(ctx, userGroupsNS, cloned.Tree(), user)
 }
 
@@ -102,6 +130,22 @@ func ListGroupUsers(ctx context.Context, addr gov.Address, group Group) []User {
 	return ListGroupUsers_Local(ctx, gov.Clone(ctx, addr), group)
 }
 
+// ListGroupUsers_Local returns the members of the group, including the members of its nested groups.
 func ListGroupUsers_Local(ctx context.Context, cloned gov.Cloned, group Group) []User {
+	seen := map[User]bool{}
+	users := []User{}
+	for _, g := range listGroupClosure_Local(ctx, cloned, group) {
+		for _, u := range ListDirectGroupUsers_Local(ctx, cloned, g) {
+			if !seen[u] {
+				seen[u] = true
+				users = append(users, u)
+			}
+		}
+	}
+	return users
+}
+
+// ListDirectGroupUsers_Local returns the users who have been added to the group itself.
+func ListDirectGroupUsers_Local(ctx context.Context, cloned gov.Cloned, group Group) []User {
 	return groupUsersKKV.ListSecondaryKeys(ctx, groupUsersNS, cloned.Tree(), group)
 }
@@ -0,0 +1,121 @@
+package member
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+func AddSubgroup(ctx context.Context, addr gov.Address, parent Group, child Group) {
+	cloned := gov.Clone(ctx, addr)
+	chg := AddSubgroup_StageOnly(ctx, cloned, parent, child)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+}
+
+// AddSubgroup_StageOnly nests the child group inside the parent group,
+// so that members of the child group are also members of the parent group.
+// Nesting which would make a group a member of itself is refused.
+func AddSubgroup_StageOnly(ctx context.Context, cloned gov.Cloned, parent Group, child Group) git.ChangeNoResult {
+	must.Assertf(ctx, IsGroup_Local(ctx, cloned, parent), "%v is not a group", parent)
+	must.Assertf(ctx, IsGroup_Local(ctx, cloned, child), "%v is not a group", child)
+	if slices.Contains(listGroupClosure_Local(ctx, cloned, child), parent) {
+		must.Panic(ctx, fmt.Errorf("%w: %v is already nested in %v", ErrGroupCycle, parent, child))
+	}
+	groupSubgroupsKKV.Set(ctx, groupSubgroupsNS, cloned.Tree(), parent, child, true)
+
+	// log
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &AddSubgroupTrace{Name: parent, Subgroup: child},
+	})
+
+	return git.NewChangeNoResult(fmt.Sprintf("Nest group %v in group %v", child, parent), "member_add_subgroup")
+}
+
+func RemoveSubgroup(ctx context.Context, addr gov.Address, parent Group, child Group) {
+	cloned := gov.Clone(ctx, addr)
+	chg := RemoveSubgroup_StageOnly(ctx, cloned, parent, child)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+}
+
+func RemoveSubgroup_StageOnly(ctx context.Context, cloned gov.Cloned, parent Group, child Group) git.ChangeNoResult {
+	must.Assertf(ctx, slices.Contains(ListSubgroups_Local(ctx, cloned, parent), child), "%v is not nested in %v", child, parent)
+	groupSubgroupsKKV.Remove(ctx, groupSubgroupsNS, cloned.Tree(), parent, child)
+
+	// log
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &RemoveSubgroupTrace{Name: parent, Subgroup: child},
+	})
+
+	return git.NewChangeNoResult(fmt.Sprintf("Remove group %v from group %v", child, parent), "member_remove_subgroup")
+}
+
+func ListSubgroups(ctx context.Context, addr gov.Address, group Group) []Group {
+	return ListSubgroups_Local(ctx, gov.Clone(ctx, addr), group)
+}
+
+// ListSubgroups_Local returns the groups nested directly in the group.
+func ListSubgroups_Local(ctx context.Context, cloned gov.Cloned, group Group) []Group {
+	subgroups, err := must.Try1(func() []Group {
+		return groupSubgroupsKKV.ListSecondaryKeys(ctx, groupSubgroupsNS, cloned.Tree(), group)
+	})
+	if err != nil {
+		return nil
+	}
+	return subgroups
+}
+
+// listGroupClosure_Local returns the group, followed by all groups nested in it, directly or indirectly.
+func listGroupClosure_Local(ctx context.Context, cloned gov.Cloned, group Group) []Group {
+	seen := map[Group]bool{group: true}
+	closure := []Group{group}
+	for i := 0; i < len(closure); i++ {
+		for _, sub := range ListSubgroups_Local(ctx, cloned, closure[i]) {
+			if !seen[sub] {
+				seen[sub] = true
+				closure = append(closure, sub)
+			}
+		}
+	}
+	return closure
+}
+
+// listGroupAncestors_Local returns all groups in which the group is nested, directly or indirectly.
+func listGroupAncestors_Local(ctx context.Context, cloned gov.Cloned, group Group) []Group {
+	parents := map[Group][]Group{}
+	for _, g := range listGroups_Local(ctx, cloned) {
+		for _, sub := range ListSubgroups_Local(ctx, cloned, g) {
+			parents[sub] = append(parents[sub], g)
+		}
+	}
+	seen := map[Group]bool{group: true}
+	ancestors := []Group{}
+	queue := []Group{group}
+	for len(queue) > 0 {
+		g := queue[0]
+		queue = queue[1:]
+		for _, p := range parents[g] {
+			if !seen[p] {
+				seen[p] = true
+				ancestors = append(ancestors, p)
+				queue = append(queue, p)
+			}
+		}
+	}
+	return ancestors
+}
+
+func listGroups_Local(ctx context.Context, cloned gov.Cloned) []Group {
+	groups, err := must.Try1(func() []Group { return groupsKV.ListKeys(ctx, groupsNS, cloned.Tree()) })
+	if err != nil {
+		return nil
+	}
+	return groups
+}
@@ -0,0 +1,61 @@
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestNestedGroups(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+
+	govID := id.NewTestID(ctx, t, git.MainBranch, true)
+	cloned := gov.Clone(ctx, gov.Address(govID.PublicAddress()))
+
+	u1, u2 := User("user1"), User("user2")
+	AddUser_StageOnly(ctx, cloned, u1, UserProfile{})
+	AddUser_StageOnly(ctx, cloned, u2, UserProfile{})
+	for _, g := range []Group{"contributors", "core", "leads"} {
+		AddGroup_StageOnly(ctx, cloned, g)
+	}
+	AddSubgroup_StageOnly(ctx, cloned, "contributors", "core")
+	AddSubgroup_StageOnly(ctx, cloned, "core", "leads")
+	AddMember_StageOnly(ctx, cloned, u1, "leads")
+	AddMember_StageOnly(ctx, cloned, u2, "contributors")
+
+	// membership resolves transitively
+	if users := ListGroupUsers_Local(ctx, cloned, "contributors"); len(users) != 2 {
+		t.Errorf("expecting 2 contributors, got %v", users)
+	}
+	if !IsMember_Local(ctx, cloned, u1, "contributors") || IsDirectMember_Local(ctx, cloned, u1, "contributors") {
+		t.Errorf("expecting indirect membership")
+	}
+	if IsMember_Local(ctx, cloned, u2, "core") {
+		t.Errorf("expecting no membership in core")
+	}
+	if groups := ListUserGroups_Local(ctx, cloned, u1); len(groups) != 4 {
+		t.Errorf("expecting 4 groups, got %v", groups)
+	}
+
+	// cycles are refused
+	for _, g := range []Group{"leads", "contributors"} {
+		if err := must.Try(func() { AddSubgroup_StageOnly(ctx, cloned, "leads", g) }); !errors.Is(err, ErrGroupCycle) {
+			t.Errorf("expecting cycle error nesting %v in leads, got %v", g, err)
+		}
+	}
+
+	// removing a group removes it from its parents
+	RemoveGroup_StageOnly(ctx, cloned, "core")
+	if subs := ListSubgroups_Local(ctx, cloned, "contributors"); len(subs) != 0 {
+		t.Errorf("expecting no subgroups, got %v", subs)
+	}
+	if IsMember_Local(ctx, cloned, u1, "contributors") {
+		t.Errorf("expecting no membership after removal of core")
+	}
+}
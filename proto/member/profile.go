@@ -0,0 +1,98 @@
+package member
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/lib4git/must"
+)
+
+// PropView is a user property, typed according to the profile schema.
+// Properties which are stored, but not declared in the schema, have no type.
+type PropView struct {
+	Key            string             `json:"key"`
+	Type           etc.PropType       `json:"type,omitempty"`
+	Visibility     etc.PropVisibility `json:"visibility"`
+	MemberEditable bool               `json:"member_editable"`
+	Description    string             `json:"description,omitempty"`
+	IsSet          bool               `json:"is_set"`
+	Value          any                `json:"value,omitempty"`
+}
+
+type UserView struct {
+	User    User        `json:"user"`
+	Profile UserProfile `json:"profile"`
+	Groups  []Group     `json:"groups"`
+	Props   []PropView  `json:"props"`
+}
+
+func ShowUser(ctx context.Context, addr gov.Address, user User, withOrganizerProps bool) UserView {
+	return ShowUser_Local(ctx, gov.Clone(ctx, addr), user, withOrganizerProps)
+}
+
+// ShowUser_Local returns the profile of a user, with the properties declared in the profile schema in schema order.
+// Organizer-only properties, and stored properties which are not declared in a non-empty schema, are included only if withOrganizerProps is set.
+func ShowUser_Local(ctx context.Context, cloned gov.Cloned, user User, withOrganizerProps bool) UserView {
+	settings := etc.GetSettings_StageOnly(ctx, cloned)
+	schema := settings.ProfileSchema
+	view := UserView{
+		User:    user,
+		Profile: GetUser_Local(ctx, cloned, user),
+		Groups:  ListUserGroups_Local(ctx, cloned, user),
+		Props:   []PropView{},
+	}
+
+	stored := listUserPropKeys_Local(ctx, cloned, user)
+	for _, p := range schema {
+		if p.Visibility == etc.PropOrganizer && !withOrganizerProps {
+			continue
+		}
+		pv := PropView{
+			Key:            p.Key,
+			Type:           p.Type,
+			Visibility:     p.Visibility,
+			MemberEditable: settings.IsMemberEditableUserProp(p.Key),
+			Description:    p.Description,
+		}
+		if slices.Contains(stored, p.Key) {
+			pv.IsSet, pv.Value = true, GetUserProp_Local[any](ctx, cloned, user, p.Key)
+		}
+		view.Props = append(view.Props, pv)
+	}
+
+	// without a schema, all properties are public; with a schema, undeclared properties are shown to organizers
+	if !schema.IsEmpty() && !withOrganizerProps {
+		return view
+	}
+	for _, key := range stored {
+		if _, ok := schema.Lookup(key); ok {
+			continue
+		}
+		visibility := etc.PropPublic
+		if !schema.IsEmpty() {
+			visibility = etc.PropOrganizer
+		}
+		view.Props = append(view.Props, PropView{
+			Key:            key,
+			Visibility:     visibility,
+			MemberEditable: settings.IsMemberEditableUserProp(key),
+			IsSet:          true,
+			Value:          GetUserProp_Local[any](ctx, cloned, user, key),
+		})
+	}
+	return view
+}
+
+func listUserPropKeys_Local(ctx context.Context, cloned gov.Cloned, user User) []string {
+	keys, err := must.Try1(func() []string {
+		return kv.KV[string, any]{}.ListKeys(ctx, usersKV.KeyNS(usersNS, user), cloned.Tree())
+	})
+	if err != nil {
+		return nil
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,186 @@
+package member
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Capability is a permission to perform a class of organizer actions, granted to groups.
+type Capability string
+
+const (
+	CapOpenBallots   Capability = "open_ballots"   // may open ballots
+	CapIssueCredits  Capability = "issue_credits"  // may issue credits and move credits between users
+	CapFreezeMotions Capability = "freeze_motions" // may freeze and unfreeze motions and ballots
+	CapApproveJoins  Capability = "approve_joins"  // may add users and approve join requests
+	CapManageMotions Capability = "manage_motions" // may open and close motions
+	CapManageGroups  Capability = "manage_groups"  // may change groups, their members and their capabilities
+)
+
+var Capabilities = []Capability{
+	CapOpenBallots,
+	CapIssueCredits,
+	CapFreezeMotions,
+	CapApproveJoins,
+	CapManageMotions,
+	CapManageGroups,
+}
+
+// OrganizerCapabilities are held by the organizer, in addition to the groups they are granted to.
+// They are restricted from the start, so that until they are granted to a group, only the organizer holds them.
+var OrganizerCapabilities = []Capability{
+	CapManageGroups,
+}
+
+func (x Capability) Validate() error {
+	if !slices.Contains(Capabilities, x) {
+		return fmt.Errorf("%w: %q", ErrUnknownCapability, x)
+	}
+	return nil
+}
+
+func GrantCapability(ctx context.Context, addr gov.Address, group Group, cap Capability) {
+	cloned := gov.Clone(ctx, addr)
+	chg := GrantCapability_StageOnly(ctx, cloned, group, cap)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+}
+
+// GrantCapability_StageOnly grants a capability to the members of a group.
+// Once a capability is granted to any group, it is restricted to the members of the groups it is granted to.
+func GrantCapability_StageOnly(ctx context.Context, cloned gov.Cloned, group Group, cap Capability) git.ChangeNoResult {
+	must.NoError(ctx, cap.Validate())
+	must.Assertf(ctx, IsGroup_Local(ctx, cloned, group), "%v is not a group", group)
+	capabilityGroupsKKV.Set(ctx, capabilityGroupsNS, cloned.Tree(), cap, group, true)
+
+	// log
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &GrantTrace{Name: group, Capability: cap},
+	})
+
+	return git.NewChangeNoResult(fmt.Sprintf("Grant capability %v to group %v", cap, group), "member_grant_capability")
+}
+
+func RevokeCapability(ctx context.Context, addr gov.Address, group Group, cap Capability) {
+	cloned := gov.Clone(ctx, addr)
+	chg := RevokeCapability_StageOnly(ctx, cloned, group, cap)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+}
+
+// RevokeCapability_StageOnly revokes a capability from a group.
+// Once a capability is revoked from all groups, it is no longer restricted, unless it is an organizer capability.
+func RevokeCapability_StageOnly(ctx context.Context, cloned gov.Cloned, group Group, cap Capability) git.ChangeNoResult {
+	must.NoError(ctx, cap.Validate())
+	must.Assertf(ctx, slices.Contains(ListCapabilityGroups_Local(ctx, cloned, cap), group), "capability %v is not granted to group %v", cap, group)
+	capabilityGroupsKKV.Remove(ctx, capabilityGroupsNS, cloned.Tree(), cap, group)
+
+	// log
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &RevokeTrace{Name: group, Capability: cap},
+	})
+
+	return git.NewChangeNoResult(fmt.Sprintf("Revoke capability %v from group %v", cap, group), "member_revoke_capability")
+}
+
+// ListCapabilityGroups_Local returns the groups, which have been granted a capability.
+func ListCapabilityGroups_Local(ctx context.Context, cloned gov.Cloned, cap Capability) []Group {
+	groups, err := must.Try1(func() []Group {
+		return capabilityGroupsKKV.ListSecondaryKeys(ctx, capabilityGroupsNS, cloned.Tree(), cap)
+	})
+	if err != nil {
+		return nil
+	}
+	return groups
+}
+
+func ListGroupCapabilities(ctx context.Context, addr gov.Address, group Group) []Capability {
+	return ListGroupCapabilities_Local(ctx, gov.Clone(ctx, addr), group)
+}
+
+func ListGroupCapabilities_Local(ctx context.Context, cloned gov.Cloned, group Group) []Capability {
+	caps := []Capability{}
+	for _, cap := range Capabilities {
+		if slices.Contains(ListCapabilityGroups_Local(ctx, cloned, cap), group) {
+			caps = append(caps, cap)
+		}
+	}
+	return caps
+}
+
+// IsCapabilityRestricted_Local returns true if the capability is an organizer capability or has been granted to any group.
+// Other capabilities, which have not been granted to any group, are available to all organizers.
+func IsCapabilityRestricted_Local(ctx context.Context, cloned gov.Cloned, cap Capability) bool {
+	return slices.Contains(OrganizerCapabilities, cap) || len(ListCapabilityGroups_Local(ctx, cloned, cap)) > 0
+}
+
+// HasCapability_Local returns true if the user is a member of a group, which has been granted the capability.
+func HasCapability_Local(ctx context.Context, cloned gov.Cloned, user User, cap Capability) bool {
+	for _, group := range ListCapabilityGroups_Local(ctx, cloned, cap) {
+		if IsMember_Local(ctx, cloned, user, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCapability_Local returns an error if the capability is restricted and the user does not have it.
+func CheckCapability_Local(ctx context.Context, cloned gov.Cloned, user User, cap Capability) error {
+	if !IsCapabilityRestricted_Local(ctx, cloned, cap) || HasCapability_Local(ctx, cloned, user, cap) {
+		return nil
+	}
+	return fmt.Errorf("%w: user %q does not have capability %v", ErrNotPermitted, user, cap)
+}
+
+// CheckIdentityCapability returns an error if the capability is restricted
+// and the identity at actorAddr is not registered as a user who has it.
+func CheckIdentityCapability(ctx context.Context, govAddr gov.Address, actorAddr id.PublicAddress, cap Capability) error {
+	return CheckIdentityCapability_Local(ctx, gov.Clone(ctx, govAddr), actorAddr, cap)
+}
+
+func CheckIdentityCapability_Local(ctx context.Context, cloned gov.Cloned, actorAddr id.PublicAddress, cap Capability) error {
+	if !IsCapabilityRestricted_Local(ctx, cloned, cap) {
+		return nil
+	}
+	actor, err := git.TryCloneOne(ctx, git.Address(actorAddr))
+	if err != nil {
+		return fmt.Errorf("%w: identity %v cannot be read (%v)", ErrNotPermitted, actorAddr.Repo, err)
+	}
+	chain, err := must.Try1(func() id.KeyChain { return id.GetKeyChain(ctx, actor.Tree()) })
+	if err != nil {
+		return fmt.Errorf("%w: identity %v cannot be read (%v)", ErrNotPermitted, actorAddr.Repo, err)
+	}
+	for _, user := range LookupUserByID_Local(ctx, cloned, chain.IDs()...) {
+		if HasCapability_Local(ctx, cloned, user, cap) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: identity %v does not have capability %v", ErrNotPermitted, actorAddr.Repo, cap)
+}
+
+// CheckOwnerCapability returns an error if the capability is restricted and neither the identity at actorAddr has it,
+// nor, for organizer capabilities, the caller owns the community's private credentials at govOwner.
+func CheckOwnerCapability(ctx context.Context, govOwner gov.OwnerAddress, actorAddr id.PublicAddress, cap Capability) error {
+	if slices.Contains(OrganizerCapabilities, cap) && isOrganizer(ctx, govOwner) {
+		return nil
+	}
+	return CheckIdentityCapability(ctx, gov.Address(govOwner.Public), actorAddr, cap)
+}
+
+// isOrganizer returns true if the private credentials at govOwner can be read and belong to the community's identity.
+func isOrganizer(ctx context.Context, govOwner gov.OwnerAddress) bool {
+	ok, err := must.Try1(func() bool {
+		ownerCloned := gov.CloneOwner(ctx, govOwner)
+		priv := id.GetOwnerCredentials(ctx, ownerCloned.IDOwnerCloned())
+		return priv.PublicCredentials.ID == id.GetPublicCredentials(ctx, ownerCloned.Public.Tree()).ID
+	})
+	return err == nil && ok
+}
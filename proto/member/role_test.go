@@ -0,0 +1,55 @@
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestCapability(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+
+	govID := id.NewTestID(ctx, t, git.MainBranch, true)
+	cloned := gov.Clone(ctx, gov.Address(govID.PublicAddress()))
+
+	u1, u2 := User("user1"), User("user2")
+	AddUser_StageOnly(ctx, cloned, u1, UserProfile{})
+	AddUser_StageOnly(ctx, cloned, u2, UserProfile{})
+	AddGroup_StageOnly(ctx, cloned, "treasurers")
+	AddMember_StageOnly(ctx, cloned, u1, "treasurers")
+
+	// capabilities which are not granted to any group are not restricted
+	if err := CheckCapability_Local(ctx, cloned, u2, CapIssueCredits); err != nil {
+		t.Fatalf("expecting unrestricted capability, got %v", err)
+	}
+
+	GrantCapability_StageOnly(ctx, cloned, "treasurers", CapIssueCredits)
+	if err := CheckCapability_Local(ctx, cloned, u1, CapIssueCredits); err != nil {
+		t.Errorf("expecting permitted, got %v", err)
+	}
+	if err := CheckCapability_Local(ctx, cloned, u2, CapIssueCredits); !errors.Is(err, ErrNotPermitted) {
+		t.Errorf("expecting not permitted, got %v", err)
+	}
+	if err := CheckCapability_Local(ctx, cloned, u2, CapOpenBallots); err != nil {
+		t.Errorf("expecting other capabilities to remain unrestricted, got %v", err)
+	}
+	if caps := ListGroupCapabilities_Local(ctx, cloned, "treasurers"); len(caps) != 1 || caps[0] != CapIssueCredits {
+		t.Errorf("unexpected capabilities %v", caps)
+	}
+
+	// unknown capabilities cannot be granted
+	if err := must.Try(func() { GrantCapability_StageOnly(ctx, cloned, "treasurers", "fly") }); !errors.Is(err, ErrUnknownCapability) {
+		t.Errorf("expecting unknown capability, got %v", err)
+	}
+
+	RevokeCapability_StageOnly(ctx, cloned, "treasurers", CapIssueCredits)
+	if err := CheckCapability_Local(ctx, cloned, u2, CapIssueCredits); err != nil {
+		t.Errorf("expecting unrestricted capability after revocation, got %v", err)
+	}
+}
@@ -22,6 +22,12 @@ var (
 
 	groupUsersNS  = membersNS.Append("group_users")
 	groupUsersKKV = kv.KKV[Group, User, bool]{}
+
+	groupSubgroupsNS  = membersNS.Append("group_subgroups")
+	groupSubgroupsKKV = kv.KKV[Group, Group, bool]{}
+
+	capabilityGroupsNS  = membersNS.Append("capability_groups")
+	capabilityGroupsKKV = kv.KKV[Capability, Group, bool]{}
 )
 
 type UserProfile struct {
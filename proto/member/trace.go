@@ -0,0 +1,97 @@
+package member
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+)
+
+func init() {
+	ctx := context.Background()
+	trace.Register[RemoveUserTrace](ctx, 1)
+	trace.Register[SetUserProfileTrace](ctx, 1)
+	trace.Register[AddGroupTrace](ctx, 1)
+	trace.Register[RemoveGroupTrace](ctx, 1)
+	trace.Register[SetGroupOpenTrace](ctx, 1)
+	trace.Register[AddMemberTrace](ctx, 1)
+	trace.Register[RemoveMemberTrace](ctx, 1)
+	trace.Register[AddSubgroupTrace](ctx, 1)
+	trace.Register[RemoveSubgroupTrace](ctx, 1)
+	trace.Register[GrantTrace](ctx, 1)
+	trace.Register[RevokeTrace](ctx, 1)
+}
+
+type RemoveUserTrace struct {
+	Name User `json:"name"`
+}
+
+func (*RemoveUserTrace) TraceOp() string { return "user_remove" }
+
+type SetUserProfileTrace struct {
+	Name User        `json:"name"`
+	Old  UserProfile `json:"old"`
+	New  UserProfile `json:"new"`
+}
+
+func (*SetUserProfileTrace) TraceOp() string { return "user_set_profile" }
+
+type AddGroupTrace struct {
+	Name Group `json:"name"`
+}
+
+func (*AddGroupTrace) TraceOp() string { return "group_add" }
+
+type RemoveGroupTrace struct {
+	Name Group `json:"name"`
+}
+
+func (*RemoveGroupTrace) TraceOp() string { return "group_remove" }
+
+type SetGroupOpenTrace struct {
+	Name Group `json:"name"`
+	Open bool  `json:"open"`
+}
+
+func (*SetGroupOpenTrace) TraceOp() string { return "group_set_open" }
+
+type AddMemberTrace struct {
+	User  User  `json:"user"`
+	Group Group `json:"group"`
+}
+
+func (*AddMemberTrace) TraceOp() string { return "add_user_to_group" }
+
+type RemoveMemberTrace struct {
+	User  User  `json:"user"`
+	Group Group `json:"group"`
+}
+
+func (*RemoveMemberTrace) TraceOp() string { return "remove_user_from_group" }
+
+type AddSubgroupTrace struct {
+	Name     Group `json:"name"`
+	Subgroup Group `json:"subgroup"`
+}
+
+func (*AddSubgroupTrace) TraceOp() string { return "group_add_subgroup" }
+
+type RemoveSubgroupTrace struct {
+	Name     Group `json:"name"`
+	Subgroup Group `json:"subgroup"`
+}
+
+func (*RemoveSubgroupTrace) TraceOp() string { return "group_remove_subgroup" }
+
+type GrantTrace struct {
+	Name       Group      `json:"name"`
+	Capability Capability `json:"capability"`
+}
+
+func (*GrantTrace) TraceOp() string { return "group_grant" }
+
+type RevokeTrace struct {
+	Name       Group      `json:"name"`
+	Capability Capability `json:"capability"`
+}
+
+func (*RevokeTrace) TraceOp() string { return "group_revoke" }
@@ -3,9 +3,11 @@ package member
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/etc"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/history/metric"
 	"github.com/gov4git/gov4git/v2/proto/history/trace"
@@ -88,9 +90,10 @@ func RemoveUser(ctx context.Context, addr gov.Address, name User) {
 }
 
 func RemoveUser_StageOnly(ctx context.Context, cloned gov.Cloned, name User) git.ChangeNoResult {
+	must.NoError(ctx, etc.CheckUnilateral_Local(ctx, cloned, etc.OpMemberRemoveUser))
 	must.Assertf(ctx, IsUser_Local(ctx, cloned, name), "%v is not a name", name)
 	// remove all group memberships of the user
-	for _, g := range ListUserGroups_Local(ctx, cloned, name) {
+	for _, g := range ListDirectUserGroups_Local(ctx, cloned, name) {
 		RemoveMember_StageOnly(ctx, cloned, name, g)
 	}
 	// remove user record
@@ -99,14 +102,32 @@ func RemoveUser_StageOnly(ctx context.Context, cloned gov.Cloned, name User) git
 
 	// log
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "user_remove",
-		Args:   trace.M{"name": name},
-		Result: nil,
+		Payload: &RemoveUserTrace{Name: name},
 	})
 
 	return chg
 }
 
+func SetUserProfile(ctx context.Context, addr gov.Address, name User, profile UserProfile) {
+	cloned := gov.Clone(ctx, addr)
+	chg := SetUserProfile_StageOnly(ctx, cloned, name, profile)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+}
+
+// SetUserProfile_StageOnly replaces the profile of an existing user, e.g. when the user moves to a new public repo.
+func SetUserProfile_StageOnly(ctx context.Context, cloned gov.Cloned, name User, profile UserProfile) git.ChangeNoResult {
+	old := GetUser_Local(ctx, cloned, name)
+	usersKV.Set(ctx, usersNS, cloned.Tree(), name, profile)
+
+	// log
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &SetUserProfileTrace{Name: name, Old: old, New: profile},
+	})
+
+	return git.NewChangeNoResult(fmt.Sprintf("Set profile of user %v", name), "member_set_user_profile")
+}
+
 // set prop
 
 func SetUserProp[V form.Form](ctx context.Context, addr gov.Address, user User, key string, value V) {
@@ -124,6 +145,7 @@ func SetUserProp_StageOnly[V form.Form](
 	value V,
 ) git.ChangeNoResult {
 	must.Assertf(ctx, IsUser_Local(ctx, cloned, user), "%v is not a user", user)
+	must.NoError(ctx, etc.GetSettings_StageOnly(ctx, cloned).ProfileSchema.ValidateUserProp(key, value))
 	propKV := kv.KV[string, V]{}
 	return propKV.Set(ctx, usersKV.KeyNS(usersNS, user), cloned.Tree(), key, value)
 }
@@ -177,16 +199,18 @@ func LookupUserByAddress_Local(ctx context.Context, cloned gov.Cloned, userAddr
 	return r
 }
 
-func LookupUserByID(ctx context.Context, govAddr gov.Address, userID id.ID) []User {
-	return LookupUserByID_Local(ctx, gov.Clone(ctx, govAddr), userID)
+func LookupUserByID(ctx context.Context, govAddr gov.Address, userIDs ...id.ID) []User {
+	return LookupUserByID_Local(ctx, gov.Clone(ctx, govAddr), userIDs...)
 }
 
-func LookupUserByID_Local(ctx context.Context, cloned gov.Cloned, userID id.ID) []User {
+// LookupUserByID_Local returns the users registered with any of the given ids.
+// To follow key rotations, pass all ids of an identity's key chain, as returned by id.KeyChain.IDs.
+func LookupUserByID_Local(ctx context.Context, cloned gov.Cloned, userIDs ...id.ID) []User {
 	us := usersKV.ListKeys(ctx, usersNS, cloned.Tree())
 	r := []User{}
 	for _, u := range us {
 		acct := GetUser_Local(ctx, cloned, u)
-		if acct.ID == userID {
+		if slices.Contains(userIDs, acct.ID) {
 			r = append(r, u)
 		}
 	}
@@ -1,9 +1,15 @@
 package metrics
 
+import (
+	"github.com/gov4git/gov4git/v2/proto/alert"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+)
+
 type AssetURLCalculator func(assetRepoPath string) (url string)
 
 type ReportAssets struct {
 	Series   *ReportSeries
+	Alerts   journal.Entries[*alert.Alert] // alerts raised within the last 30 days, most recent first
 	ReportMD string
 	Assets   map[string][]byte // path in git repo assets branch -> content
 }
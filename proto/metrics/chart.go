@@ -0,0 +1,252 @@
+package metrics
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"time"
+)
+
+// barChart is a stacked bar chart of daily series, rendered natively as SVG or PNG.
+type barChart struct {
+	Title  string
+	XLabel string
+	YLabel string
+	X      []time.Time
+	Stacks []barStack // drawn bottom to top
+}
+
+type barStack struct {
+	Label string
+	Color string // hex RGB, e.g. "#55cc88"
+	Y     []float64
+}
+
+// chart geometry, in logical units
+const (
+	chartWidth  = 900
+	chartHeight = 500
+
+	chartLeft   = 80
+	chartRight  = 880
+	chartTop    = 45
+	chartBottom = 430
+
+	chartTitleSize = 15
+	chartLabelSize = 12
+	chartTickSize  = 10
+
+	chartTickLength = 5
+	chartBarWidth   = 0.8 // fraction of the daily slot
+	chartMaxYTicks  = 8
+)
+
+type textAnchor string
+
+const (
+	anchorStart  textAnchor = "start"
+	anchorMiddle textAnchor = "middle"
+	anchorEnd    textAnchor = "end"
+)
+
+// canvas is implemented by the SVG and PNG renderers.
+// Coordinates are logical, with the origin at the top-left corner. Lines are horizontal or vertical.
+type canvas interface {
+	fillRect(x, y, w, h float64, c color.RGBA)
+	line(x1, y1, x2, y2 float64, c color.RGBA)
+	text(x, y float64, s string, size float64, anchor textAnchor, vertical bool)
+}
+
+var (
+	chartWhite     = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	chartBlack     = color.RGBA{0x00, 0x00, 0x00, 0xff}
+	chartFrameGray = color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+)
+
+func (c *barChart) draw(cv canvas) {
+
+	cv.fillRect(0, 0, chartWidth, chartHeight, chartWhite)
+
+	n := len(c.X)
+	lo, hi := c.yRange()
+	ticks := niceTicks(lo, hi, chartMaxYTicks)
+	lo, hi = math.Min(lo, ticks[0]), math.Max(hi, ticks[len(ticks)-1])
+
+	yOf := func(v float64) float64 {
+		return chartBottom - (v-lo)/(hi-lo)*(chartBottom-chartTop)
+	}
+	slot := float64(chartRight-chartLeft) / math.Max(float64(n), 1)
+	xOf := func(i int) float64 {
+		return chartLeft + (float64(i)+0.5)*slot
+	}
+
+	// bars
+	for i := 0; i < n; i++ {
+		pos, neg := 0.0, 0.0
+		for _, s := range c.Stacks {
+			v := stackValue(s, i)
+			var from, to float64
+			if v >= 0 {
+				from, to = pos, pos+v
+				pos = to
+			} else {
+				from, to = neg, neg+v
+				neg = to
+			}
+			if v == 0 {
+				continue
+			}
+			y1, y2 := yOf(math.Max(from, to)), yOf(math.Min(from, to))
+			w := slot * chartBarWidth
+			cv.fillRect(xOf(i)-w/2, y1, w, y2-y1, parseHexColor(s.Color))
+		}
+	}
+
+	// frame
+	cv.line(chartLeft, chartTop, chartRight, chartTop, chartBlack)
+	cv.line(chartLeft, chartBottom, chartRight, chartBottom, chartBlack)
+	cv.line(chartLeft, chartTop, chartLeft, chartBottom, chartBlack)
+	cv.line(chartRight, chartTop, chartRight, chartBottom, chartBlack)
+
+	// y ticks
+	dec := tickDecimals(ticks)
+	for _, t := range ticks {
+		y := yOf(t)
+		cv.line(chartLeft-chartTickLength, y, chartLeft, y, chartBlack)
+		cv.text(chartLeft-chartTickLength-3, y+chartTickSize*0.35, strconv.FormatFloat(t, 'f', dec, 64), chartTickSize, anchorEnd, false)
+	}
+
+	// x ticks
+	skip := xTickSkipDates(n)
+	for i := 0; i < n; i += skip {
+		x := xOf(i)
+		cv.line(x, chartBottom, x, chartBottom+chartTickLength, chartBlack)
+		cv.text(x, chartBottom+chartTickLength+chartTickSize+3, c.X[i].Format("2006-01-02"), chartTickSize, anchorMiddle, false)
+	}
+
+	// labels
+	cv.text((chartLeft+chartRight)/2, chartTop-15, c.Title, chartTitleSize, anchorMiddle, false)
+	cv.text((chartLeft+chartRight)/2, chartHeight-30, c.XLabel, chartLabelSize, anchorMiddle, false)
+	cv.text(45, (chartTop+chartBottom)/2, c.YLabel, chartLabelSize, anchorMiddle, true)
+
+	// legend, drawn top to bottom in the order of the stacks
+	c.drawLegend(cv)
+}
+
+func (c *barChart) drawLegend(cv canvas) {
+
+	if len(c.Stacks) == 0 {
+		return
+	}
+	const (
+		pad    = 8
+		swatch = 12
+		row    = 18
+	)
+	w := 0.0
+	for _, s := range c.Stacks {
+		w = math.Max(w, textWidth(s.Label, chartTickSize))
+	}
+	w += 2*pad + swatch + 6
+	h := float64(len(c.Stacks))*row + 2*pad - (row - swatch)
+	x, y := float64(chartLeft+10), float64(chartTop+10)
+
+	cv.fillRect(x, y, w, h, chartWhite)
+	cv.line(x, y, x+w, y, chartFrameGray)
+	cv.line(x, y+h, x+w, y+h, chartFrameGray)
+	cv.line(x, y, x, y+h, chartFrameGray)
+	cv.line(x+w, y, x+w, y+h, chartFrameGray)
+	for i, s := range c.Stacks {
+		ry := y + pad + float64(i)*row
+		cv.fillRect(x+pad, ry, swatch, swatch, parseHexColor(s.Color))
+		cv.text(x+pad+swatch+6, ry+swatch-1, s.Label, chartTickSize, anchorStart, false)
+	}
+}
+
+// yRange returns the extent of the stacked bars, always including zero.
+func (c *barChart) yRange() (lo, hi float64) {
+	for i := range c.X {
+		pos, neg := 0.0, 0.0
+		for _, s := range c.Stacks {
+			if v := stackValue(s, i); v >= 0 {
+				pos += v
+			} else {
+				neg += v
+			}
+		}
+		hi, lo = math.Max(hi, pos), math.Min(lo, neg)
+	}
+	if hi == lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
+
+// stackValue returns the i-th value of a stack, truncated to an integer as in the original dashboard charts.
+func stackValue(s barStack, i int) float64 {
+	if i >= len(s.Y) {
+		return 0
+	}
+	return math.Trunc(s.Y[i])
+}
+
+// niceTicks returns evenly spaced round tick values covering [lo, hi].
+func niceTicks(lo, hi float64, maxTicks int) []float64 {
+	step := niceNum((hi - lo) / float64(maxTicks-1))
+	first := math.Floor(lo/step) * step
+	last := math.Ceil(hi/step) * step
+	ticks := []float64{}
+	for i := 0; ; i++ {
+		t := first + float64(i)*step
+		if t > last+step/2 {
+			break
+		}
+		ticks = append(ticks, t)
+	}
+	return ticks
+}
+
+func niceNum(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	exp := math.Floor(math.Log10(x))
+	f := x / math.Pow(10, exp)
+	var nf float64
+	switch {
+	case f <= 1:
+		nf = 1
+	case f <= 2:
+		nf = 2
+	case f <= 5:
+		nf = 5
+	default:
+		nf = 10
+	}
+	return nf * math.Pow(10, exp)
+}
+
+func tickDecimals(ticks []float64) int {
+	if len(ticks) < 2 {
+		return 0
+	}
+	step := ticks[1] - ticks[0]
+	if step >= 1 {
+		return 0
+	}
+	return int(math.Ceil(-math.Log10(step)))
+}
+
+// textWidth approximates the width of a text in logical units, using the metrics of the bitmap font.
+func textWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * glyphAdvance * size / glyphSize
+}
+
+func parseHexColor(s string) color.RGBA {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return chartBlack
+	}
+	return color.RGBA{r, g, b, 0xff}
+}
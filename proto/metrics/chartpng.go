@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"github.com/gov4git/lib4git/must"
+)
+
+// chartPNGScale is the number of pixels per logical unit in PNG charts.
+const chartPNGScale = 2
+
+// PNG renders the chart as a PNG image, using a built-in bitmap font for text.
+func (c *barChart) PNG(ctx context.Context) []byte {
+	cv := &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, chartWidth*chartPNGScale, chartHeight*chartPNGScale))}
+	c.draw(cv)
+	var w bytes.Buffer
+	must.NoError(ctx, png.Encode(&w, cv.img))
+	return w.Bytes()
+}
+
+type pngCanvas struct {
+	img *image.RGBA
+}
+
+func pngPx(v float64) int {
+	return int(math.Round(v * chartPNGScale))
+}
+
+func (cv *pngCanvas) fillPx(x0, y0, x1, y1 int, c color.RGBA) {
+	draw.Draw(cv.img, image.Rect(x0, y0, x1, y1), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+func (cv *pngCanvas) fillRect(x, y, w, h float64, c color.RGBA) {
+	cv.fillPx(pngPx(x), pngPx(y), pngPx(x+w), pngPx(y+h), c)
+}
+
+func (cv *pngCanvas) line(x1, y1, x2, y2 float64, c color.RGBA) {
+	const thickness = chartPNGScale / 2
+	px1, py1, px2, py2 := pngPx(x1), pngPx(y1), pngPx(x2), pngPx(y2)
+	if px1 > px2 {
+		px1, px2 = px2, px1
+	}
+	if py1 > py2 {
+		py1, py2 = py2, py1
+	}
+	cv.fillPx(px1-thickness, py1-thickness, px2+thickness, py2+thickness, c)
+}
+
+// text draws s with its baseline at y. Vertical text reads from bottom to top.
+func (cv *pngCanvas) text(x, y float64, s string, size float64, anchor textAnchor, vertical bool) {
+	k := int(math.Max(1, math.Round(size*chartPNGScale/glyphSize))) // pixels per font dot
+	runes := []rune(s)
+	w := (len(runes)*glyphAdvance - 1) * k
+	offset := 0
+	switch anchor {
+	case anchorMiddle:
+		offset = -w / 2
+	case anchorEnd:
+		offset = -w
+	}
+	ox, oy := pngPx(x), pngPx(y)
+	for i, r := range runes {
+		g := lookupGlyph(r)
+		for row, line := range g {
+			for col, dot := range line {
+				if dot != '#' {
+					continue
+				}
+				along := offset + (i*glyphAdvance+col)*k // distance along the text direction
+				across := (row - glyphAscent) * k        // distance below the baseline
+				if vertical {
+					cv.fillPx(ox+across, oy-along-k, ox+across+k, oy-along, chartBlack)
+				} else {
+					cv.fillPx(ox+along, oy+across, ox+along+k, oy+across+k, chartBlack)
+				}
+			}
+		}
+	}
+}
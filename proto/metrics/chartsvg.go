@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+)
+
+// SVG renders the chart as a standalone SVG document.
+func (c *barChart) SVG() []byte {
+	cv := &svgCanvas{}
+	fmt.Fprintf(&cv.w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="DejaVu Sans, Helvetica, Arial, sans-serif">`+"\n",
+		chartWidth, chartHeight, chartWidth, chartHeight)
+	c.draw(cv)
+	cv.w.WriteString("</svg>\n")
+	return cv.w.Bytes()
+}
+
+type svgCanvas struct {
+	w bytes.Buffer
+}
+
+func (cv *svgCanvas) fillRect(x, y, w, h float64, c color.RGBA) {
+	fmt.Fprintf(&cv.w, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+		svgNum(x), svgNum(y), svgNum(w), svgNum(h), svgColor(c))
+}
+
+func (cv *svgCanvas) line(x1, y1, x2, y2 float64, c color.RGBA) {
+	fmt.Fprintf(&cv.w, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="1"/>`+"\n",
+		svgNum(x1), svgNum(y1), svgNum(x2), svgNum(y2), svgColor(c))
+}
+
+func (cv *svgCanvas) text(x, y float64, s string, size float64, anchor textAnchor, vertical bool) {
+	var esc bytes.Buffer
+	xml.EscapeText(&esc, []byte(s))
+	transform := ""
+	if vertical {
+		transform = fmt.Sprintf(` transform="rotate(-90 %s %s)"`, svgNum(x), svgNum(y))
+	}
+	fmt.Fprintf(&cv.w, `<text x="%s" y="%s" font-size="%s" text-anchor="%s"%s>%s</text>`+"\n",
+		svgNum(x), svgNum(y), svgNum(size), anchor, transform, esc.String())
+}
+
+func svgNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
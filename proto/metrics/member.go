@@ -0,0 +1,325 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_0"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_1"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/waimea"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/must"
+)
+
+// MemberReport summarizes the contributions and voting of a single community member.
+type MemberReport struct {
+	User    member.User `json:"user"`
+	Balance float64     `json:"balance"`
+	// motions
+	Authored    []AuthoredMotion `json:"authored"`
+	NumAuthored int              `json:"num_authored"`
+	NumAccepted int              `json:"num_accepted"`
+	// credits received in motion closures and cancellations, by receipt type (bounty, reward, refund)
+	Received map[metric.ReceiptType]float64 `json:"received"`
+	// votes and credits spent on votes, by purpose
+	NumVotes map[metric.VotePurpose]int     `json:"num_votes"`
+	Spent    map[metric.VotePurpose]float64 `json:"spent"`
+	// reviews are votes on the approval polls of decided proposals;
+	// a review is aligned if it is on the side of the decision
+	NumReviews        int     `json:"num_reviews"`
+	NumAlignedReviews int     `json:"num_aligned_reviews"`
+	AlignmentRate     float64 `json:"alignment_rate"`
+	// balance at the end of each day on which it changed
+	BalanceHistory []DailyBalance `json:"balance_history"`
+	//
+	ReportMD string `json:"report_md"`
+}
+
+type AuthoredMotion struct {
+	ID        motionproto.MotionID   `json:"id"`
+	Type      motionproto.MotionType `json:"type"`
+	Title     string                 `json:"title"`
+	OpenedAt  time.Time              `json:"opened_at"`
+	Closed    bool                   `json:"closed"`
+	Cancelled bool                   `json:"cancelled"`
+	Accepted  bool                   `json:"accepted"`
+}
+
+type DailyBalance struct {
+	Day     time.Time `json:"day"`
+	Balance float64   `json:"balance"`
+}
+
+// approvalPollNames maps proposal policies to the names of their approval polls.
+var approvalPollNames = map[motion.PolicyName]func(motionproto.MotionID) ballotproto.BallotID{
+	pmp_0.ProposalPolicyName:  pmp_0.ProposalApprovalPollName,
+	pmp_1.ProposalPolicyName:  pmp_1.ProposalApprovalPollName,
+	waimea.ProposalPolicyName: waimea.ProposalApprovalPollName,
+}
+
+func AssembleMemberReport(
+	ctx context.Context,
+	addr gov.Address,
+	user member.User,
+
+) *MemberReport {
+
+	cloned := gov.Clone(ctx, addr)
+	return AssembleMemberReport_Local(ctx, cloned, user)
+}
+
+func AssembleMemberReport_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	user member.User,
+
+) *MemberReport {
+
+	must.Assertf(ctx, member.IsUser_Local(ctx, cloned, user), "user %v is not a community member", user)
+	return AssembleMemberReports_Local(ctx, cloned, []member.User{user})[user]
+}
+
+// AssembleMemberReports_Local assembles the reports of several members,
+// reading the metric history and the approval poll tallies only once.
+func AssembleMemberReports_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	users []member.User,
+
+) map[member.User]*MemberReport {
+
+	balances := map[member.User]float64{}
+	for _, user := range users {
+		balances[user] = account.Get_Local(ctx, cloned, member.UserAccountID(user)).Balance(account.PluralAsset).Quantity
+	}
+	entries := metric.ListFilter_Local(ctx, cloned, journal.All)
+	motions := motionapi.ListMotions_Local(ctx, cloned.Tree())
+
+	// tallies of the approval polls of proposals
+	tallies := map[motionproto.MotionID]ballotproto.Tally{}
+	for _, m := range motions {
+		pollName, ok := approvalPollNames[m.Policy]
+		if !m.IsProposal() || m.Cancelled || !ok {
+			continue
+		}
+		tally, err := must.Try1(func() ballotproto.Tally { return ballotapi.Show_Local(ctx, cloned, pollName(m.ID)).Tally })
+		if err != nil {
+			continue
+		}
+		tallies[m.ID] = tally
+	}
+
+	return assembleMemberReports(entries, motions, tallies, balances)
+}
+
+// assembleMemberReports builds the reports of the users in balances in a single pass over the metric history.
+func assembleMemberReports(
+	entries journal.Entries[*metric.Event],
+	motions motionproto.Motions,
+	tallies map[motionproto.MotionID]ballotproto.Tally,
+	balances map[member.User]float64,
+
+) map[member.User]*MemberReport {
+
+	reports := map[member.User]*MemberReport{}
+	byAccount := map[metric.AccountID]*MemberReport{}
+	byVoter := map[metric.User]*MemberReport{}
+	dailyChanges := map[member.User]DailyBuckets{}
+	for user, balance := range balances {
+		r := &MemberReport{
+			User:     user,
+			Balance:  balance,
+			Received: map[metric.ReceiptType]float64{},
+			NumVotes: map[metric.VotePurpose]int{},
+			Spent:    map[metric.VotePurpose]float64{},
+		}
+		reports[user] = r
+		byAccount[user.MetricAccountID()] = r
+		byVoter[user.MetricUser()] = r
+		dailyChanges[user] = DailyBuckets{}
+	}
+
+	// scan the metric history
+	decisions := map[motionproto.MotionID]metric.MotionDecision{}
+	for _, e := range entries {
+		switch {
+		case e.Payload.Motion != nil && e.Payload.Motion.Close != nil:
+			c := e.Payload.Motion.Close
+			decisions[motionproto.MotionID(c.ID)] = c.Decision
+			addReceived(byAccount, c.Receipts)
+		case e.Payload.Motion != nil && e.Payload.Motion.Cancel != nil:
+			addReceived(byAccount, e.Payload.Motion.Cancel.Receipts)
+		case e.Payload.Vote != nil && byVoter[e.Payload.Vote.By] != nil:
+			v := e.Payload.Vote
+			r := byVoter[v.By]
+			r.NumVotes[v.Purpose]++
+			for _, rcpt := range v.Receipts {
+				if rcpt.Type == metric.ReceiptTypeCharge {
+					r.Spent[v.Purpose] += rcpt.Amount.Quantity
+				}
+			}
+		case e.Payload.Account != nil:
+			for acct, r := range byAccount {
+				if d := e.Payload.Account.BalanceChange(acct, account.PluralAsset.MetricAsset()); d != 0 {
+					dailyChanges[r.User].Add(e.Stamp, d)
+				}
+			}
+		}
+	}
+
+	// motions and reviews
+	for _, m := range motions {
+		decision, decided := decisions[m.ID]
+		accepted := decided && m.Closed && !m.Cancelled && decision == metric.MotionDecision(motionproto.Accept)
+		if r := reports[m.Author]; r != nil {
+			r.Authored = append(r.Authored, AuthoredMotion{
+				ID:        m.ID,
+				Type:      m.Type,
+				Title:     m.Title,
+				OpenedAt:  m.OpenedAt,
+				Closed:    m.Closed,
+				Cancelled: m.Cancelled,
+				Accepted:  accepted,
+			})
+			if accepted {
+				r.NumAccepted++
+			}
+		}
+		tally, ok := tallies[m.ID]
+		if !decided || !ok {
+			continue
+		}
+		for user, r := range reports {
+			score := tally.ScoresByUser[user][pmp_0.ProposalBallotChoice].Score
+			if score == 0 {
+				continue
+			}
+			r.NumReviews++
+			if (score > 0) == accepted {
+				r.NumAlignedReviews++
+			}
+		}
+	}
+
+	for user, r := range reports {
+		r.BalanceHistory = balanceHistory(dailyChanges[user], r.Balance)
+		r.NumAuthored = len(r.Authored)
+		if r.NumReviews > 0 {
+			r.AlignmentRate = float64(r.NumAlignedReviews) / float64(r.NumReviews)
+		}
+		r.ReportMD = r.markdown()
+	}
+	return reports
+}
+
+// addReceived credits receipts to the reports of the receiving accounts.
+func addReceived(byAccount map[metric.AccountID]*MemberReport, receipts metric.Receipts) {
+	for _, rcpt := range receipts {
+		if r := byAccount[rcpt.To]; r != nil {
+			r.Received[rcpt.Type] += rcpt.Amount.Quantity
+		}
+	}
+}
+
+// balanceHistory reconstructs end-of-day balances backwards from the current balance,
+// so that it is correct even if the history does not go back to the creation of the account.
+func balanceHistory(dailyChange DailyBuckets, current float64) []DailyBalance {
+	days := make([]time.Time, 0, len(dailyChange))
+	for day := range dailyChange {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+
+	h := make([]DailyBalance, len(days))
+	balance := current
+	for i := len(days) - 1; i >= 0; i-- {
+		h[i] = DailyBalance{Day: days[i], Balance: balance}
+		balance -= dailyChange[days[i]]
+	}
+	return h
+}
+
+func (r *MemberReport) markdown() string {
+
+	var w bytes.Buffer
+
+	fmt.Fprintf(&w, "## Member report for @%s\n\n", r.User)
+
+	fmt.Fprintf(&w, "Currently, @%s has `%0.6f` credits.\n\n", r.User, r.Balance)
+
+	fmt.Fprintf(&w, "### Contributions\n\n")
+
+	writeIndicatorsMD(&w, "All time aggregate", [][]indicator{r.contributionIndicators()})
+
+	if len(r.Authored) > 0 {
+		fmt.Fprintf(&w, "| Motion | Type | Title | Status |\n")
+		fmt.Fprintf(&w, "|  :--- |  :--- |  :--- |  :--- |\n")
+		for _, m := range r.Authored {
+			fmt.Fprintf(&w, "| %s | %s | %s | %s |\n", m.ID, m.Type, m.Title, m.status())
+		}
+		fmt.Fprintln(&w)
+	}
+
+	fmt.Fprintf(&w, "### Voting\n\n")
+
+	writeIndicatorsMD(&w, "All time aggregate", [][]indicator{r.votingIndicators()})
+
+	if len(r.BalanceHistory) > 0 {
+		fmt.Fprintf(&w, "### Balance over time\n\n")
+		fmt.Fprintf(&w, "| Day|  Balance |\n")
+		fmt.Fprintf(&w, "|  :--- |  :--- |\n")
+		for _, b := range r.BalanceHistory {
+			fmt.Fprintf(&w, "| %s |  %0.6f |\n", b.Day.Format(time.DateOnly), b.Balance)
+		}
+		fmt.Fprintln(&w)
+	}
+
+	return w.String()
+}
+
+func (r *MemberReport) contributionIndicators() []indicator {
+	return []indicator{
+		{"Number of authored issues/PRs", fmt.Sprintf("%d", r.NumAuthored)},
+		{"Number of accepted issues/PRs", fmt.Sprintf("%d", r.NumAccepted)},
+		{"Credits received in bounties", fmt.Sprintf("%0.6f", r.Received[metric.ReceiptTypeBounty])},
+		{"Credits received in rewards", fmt.Sprintf("%0.6f", r.Received[metric.ReceiptTypeReward])},
+		{"Credits received in refunds", fmt.Sprintf("%0.6f", r.Received[metric.ReceiptTypeRefund])},
+	}
+}
+
+func (r *MemberReport) votingIndicators() []indicator {
+	return []indicator{
+		{"Number of votes on issues", fmt.Sprintf("%d", r.NumVotes[metric.VotePurposeConcern])},
+		{"Number of votes on PRs", fmt.Sprintf("%d", r.NumVotes[metric.VotePurposeProposal])},
+		{"Number of votes on other", fmt.Sprintf("%d", r.NumVotes[metric.VotePurposeUnspecified])},
+		{"Credits spent on issue votes", fmt.Sprintf("%0.6f", r.Spent[metric.VotePurposeConcern])},
+		{"Credits spent on PR votes", fmt.Sprintf("%0.6f", r.Spent[metric.VotePurposeProposal])},
+		{"Credits spent on other votes", fmt.Sprintf("%0.6f", r.Spent[metric.VotePurposeUnspecified])},
+		{"Reviews of decided PRs", fmt.Sprintf("%d", r.NumReviews)},
+		{"Review alignment rate", fmt.Sprintf("%0.1f%%", 100*r.AlignmentRate)},
+	}
+}
+
+func (m AuthoredMotion) status() string {
+	switch {
+	case m.Cancelled:
+		return "cancelled"
+	case m.Accepted:
+		return "accepted"
+	case m.Closed:
+		return "closed"
+	}
+	return "open"
+}
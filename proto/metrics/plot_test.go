@@ -1,43 +1,104 @@
 package metrics
 
 import (
+	"bytes"
 	"context"
-	"math/rand"
+	"flag"
+	"image"
+	"image/png"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
 
-func TestSeriesPlot(t *testing.T) {
-	t.SkipNow()
+var updateGolden = flag.Bool("update", false, "update golden chart images in testdata")
 
+func TestSeriesPlot(t *testing.T) {
 	ctx := context.Background()
-	os.Setenv("PATH", "/opt/homebrew/bin")
 
-	pngMotions := plotDailyMotionsPNG(ctx, testSeries)
-	os.WriteFile("plotDailyMotionsPNG.png", pngMotions, 0644)
+	charts := map[string]*barChart{
+		"daily_motions": dailyMotionsChart(testSeries),
+		"daily_credits": dailyCreditsChart(testSeries),
+		"daily_cleared": dailyClearedChart(testSeries),
+		"daily_votes":   dailyVotesChart(testSeries),
+		"daily_charges": dailyChargesChart(testSeries),
+		"daily_joins":   dailyJoinsChart(testSeries),
+	}
 
-	pngCredits := plotDailyCreditsPNG(ctx, testSeries)
-	os.WriteFile("plotDailyCreditsPNG.png", pngCredits, 0644)
+	for name, chart := range charts {
+		svgPath := filepath.Join("testdata", name+".svg")
+		pngPath := filepath.Join("testdata", name+".png")
+		gotSVG, gotPNG := chart.SVG(), chart.PNG(ctx)
 
-	pngCleared := plotDailyClearedPNG(ctx, testSeries)
-	os.WriteFile("plotDailyClearedPNG.png", pngCleared, 0644)
+		if *updateGolden {
+			os.MkdirAll("testdata", 0755)
+			os.WriteFile(svgPath, gotSVG, 0644)
+			os.WriteFile(pngPath, gotPNG, 0644)
+			continue
+		}
 
-	pngVotes := plotDailyVotesPNG(ctx, testSeries)
-	os.WriteFile("plotDailyVotesPNG.png", pngVotes, 0644)
+		wantSVG, err := os.ReadFile(svgPath)
+		if err != nil {
+			t.Fatalf("reading golden %v (%v)", svgPath, err)
+		}
+		if !bytes.Equal(gotSVG, wantSVG) {
+			t.Errorf("%v does not match golden image; rerun with -update after verifying the change", svgPath)
+		}
 
-	pngCharges := plotDailyChargesPNG(ctx, testSeries)
-	os.WriteFile("plotDailyChargesPNG.png", pngCharges, 0644)
+		// compare pixels rather than bytes, since png encoding may vary across Go versions
+		wantPNG, err := os.ReadFile(pngPath)
+		if err != nil {
+			t.Fatalf("reading golden %v (%v)", pngPath, err)
+		}
+		if !samePixels(t, gotPNG, wantPNG) {
+			t.Errorf("%v does not match golden image; rerun with -update after verifying the change", pngPath)
+		}
+	}
+}
+
+func TestPlotEmptySeries(t *testing.T) {
+	ctx := context.Background()
+	empty := &Series{}
+	if len(dailyVotesChart(empty).SVG()) == 0 || len(plotDailyVotesPNG(ctx, empty)) == 0 {
+		t.Errorf("expecting a chart for an empty series")
+	}
+}
 
-	pngJoins := plotDailyJoinsPNG(ctx, testSeries)
-	os.WriteFile("plotDailyJoinsPNG.png", pngJoins, 0644)
+func samePixels(t *testing.T, got, want []byte) bool {
+	gotImg, err := png.Decode(bytes.NewReader(got))
+	if err != nil {
+		t.Fatalf("decoding png (%v)", err)
+	}
+	wantImg, err := png.Decode(bytes.NewReader(want))
+	if err != nil {
+		t.Fatalf("decoding png (%v)", err)
+	}
+	if gotImg.Bounds() != wantImg.Bounds() {
+		return false
+	}
+	b := gotImg.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if !sameColor(gotImg, wantImg, x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
 
+func sameColor(a, b image.Image, x, y int) bool {
+	r1, g1, b1, a1 := a.At(x, y).RGBA()
+	r2, g2, b2, a2 := b.At(x, y).RGBA()
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
 }
 
-func testRandFloatArray(n int) []float64 {
+// testFloatArray returns a fixed pseudo-random sequence around 3, so that golden images are reproducible.
+func testFloatArray(seed, n int) []float64 {
 	r := make([]float64, n)
 	for i := range r {
-		r[i] = rand.NormFloat64() + 3
+		r[i] = float64((seed*7+i*5)%6) + 0.5
 	}
 	return r
 }
@@ -59,67 +120,67 @@ var (
 	testSeries = &Series{
 		DailyNumJoins: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(1, len(testDates)),
 		},
 		DailyNumMotionOpen: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(2, len(testDates)),
 		},
 		DailyNumMotionClose: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(3, len(testDates)),
 		},
 		DailyNumMotionCancel: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(4, len(testDates)),
 		},
 		DailyCreditsIssued: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(5, len(testDates)),
 		},
 		DailyCreditsBurned: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(6, len(testDates)),
 		},
 		DailyCreditsTransferred: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(7, len(testDates)),
 		},
 		DailyClearedBounties: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(8, len(testDates)),
 		},
 		DailyClearedRewards: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(9, len(testDates)),
 		},
 		DailyClearedRefunds: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(10, len(testDates)),
 		},
 		DailyNumConcernVotes: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(11, len(testDates)),
 		},
 		DailyNumProposalVotes: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(12, len(testDates)),
 		},
 		DailyNumOtherVotes: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(13, len(testDates)),
 		},
 		DailyConcernVoteCharges: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(14, len(testDates)),
 		},
 		DailyProposalVoteCharges: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(15, len(testDates)),
 		},
 		DailyOtherVoteCharges: DailySeries{
 			X: testDates,
-			Y: testRandFloatArray(len(testDates)),
+			Y: testFloatArray(16, len(testDates)),
 		},
 	}
 )
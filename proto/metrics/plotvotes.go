@@ -1,15 +1,6 @@
 package metrics
 
-import (
-	"bytes"
-	"context"
-	"fmt"
-	"os"
-	"path/filepath"
-
-	"github.com/gov4git/gov4git/v2/runtime"
-	"github.com/gov4git/lib4git/must"
-)
+import "context"
 
 func plotDailyVotesPNG(
 	ctx context.Context,
@@ -17,62 +8,19 @@ func plotDailyVotesPNG(
 
 ) []byte {
 
-	// prepare py plotting program
-
-	var w bytes.Buffer
-
-	w.WriteString(`
-import matplotlib.pyplot as plt
-import numpy as np
-from datetime import date
-
-x = `)
-	writePyDateArray(&w, series.DailyNumConcernVotes.X)
-	fmt.Fprintln(&w)
-
-	w.WriteString(`y1 = np.array(`)
-	writePyIntArray(&w, series.DailyNumConcernVotes.Y)
-	fmt.Fprintln(&w, ")")
-
-	w.WriteString(`y2 = np.array(`)
-	writePyIntArray(&w, series.DailyNumProposalVotes.Y)
-	fmt.Fprintln(&w, ")")
-
-	w.WriteString(`y3 = np.array(`)
-	writePyIntArray(&w, series.DailyNumOtherVotes.Y)
-	fmt.Fprintln(&w, ")")
-
-	w.WriteString(
-		`fig, ax = plt.subplots(figsize=(9, 5))
-ax.bar(x, y1, color='#bbccff')
-ax.bar(x, y2, bottom=y1, color='#ccffbb')
-ax.bar(x, y3, bottom=y1+y2, color='#dddddd')
-`)
-
-	w.WriteString(
-		`ax.set_xlabel("Days")
-ax.set_ylabel("Count")
-ax.legend(["Issues", "PRs", "Other", ])
-ax.set_title("Daily vote counts")
-`)
-
-	n := series.DailyNumConcernVotes.Len()
-	fmt.Fprintf(&w, "ax.set_xticks(x[0::%d])\n", xTickSkipDates(n))
-
-	fp := filepath.Join(os.TempDir(), generateRandomID()+".png")
-	fmt.Fprintf(&w, "fig.savefig(%q, dpi=200, bbox_inches = 'tight')\n", fp)
-
-	py := w.String()
-	fmt.Println(py)
-
-	// call python
-	outerr, err := runtime.RunPython(ctx, py)
-	fmt.Println(string(outerr))
-	must.NoError(ctx, err)
-
-	// retrieve png plot
-	pngData, err := os.ReadFile(fp)
-	must.NoError(ctx, err)
+	return dailyVotesChart(series).PNG(ctx)
+}
 
-	return pngData
+func dailyVotesChart(series *Series) *barChart {
+	return &barChart{
+		Title:  "Daily vote counts",
+		XLabel: "Days",
+		YLabel: "Count",
+		X:      series.DailyNumConcernVotes.X,
+		Stacks: []barStack{
+			{Label: "Issues", Color: "#bbccff", Y: series.DailyNumConcernVotes.Y},
+			{Label: "PRs", Color: "#ccffbb", Y: series.DailyNumProposalVotes.Y},
+			{Label: "Other", Color: "#dddddd", Y: series.DailyNumOtherVotes.Y},
+		},
+	}
 }
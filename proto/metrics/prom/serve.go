@@ -0,0 +1,126 @@
+package prom
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/must"
+)
+
+const (
+	DefaultListen  = "localhost:9464"
+	DefaultRefresh = 5 * time.Minute
+	MetricsPath    = "/metrics"
+)
+
+// Server serves the latest snapshot of the community metrics.
+// Snapshots are refreshed by cloning the governance repo; a failed refresh keeps serving the previous snapshot.
+type Server struct {
+	addr gov.Address
+
+	lock         sync.Mutex
+	snapshot     *Snapshot
+	numRefreshes int
+	numErrors    int
+}
+
+func NewServer(addr gov.Address) *Server {
+	return &Server{addr: addr}
+}
+
+// Refresh clones the governance repo and replaces the served snapshot.
+func (x *Server) Refresh(ctx context.Context) error {
+	var s *Snapshot
+	err := must.Try(func() { s = Collect(ctx, x.addr) })
+
+	x.lock.Lock()
+	defer x.lock.Unlock()
+	x.numRefreshes++
+	if err != nil {
+		x.numErrors++
+		base.Infof("refreshing metrics from %v failed (%v)", x.addr, err)
+		return err
+	}
+	x.snapshot = s
+	return nil
+}
+
+func (x *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	x.lock.Lock()
+	s, fams := x.snapshot, x.serverFamilies()
+	x.lock.Unlock()
+
+	var buf bytes.Buffer
+	if s != nil {
+		fams = append(s.families(), fams...)
+	}
+	if err := writeFamilies(&buf, fams); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Write(buf.Bytes())
+}
+
+func (x *Server) serverFamilies() []family {
+	return []family{
+		{
+			Name:    "gov4git_refreshes_total",
+			Help:    "Number of attempts to refresh the metrics from the governance repo.",
+			Type:    counter,
+			Samples: single(float64(x.numRefreshes)),
+		},
+		{
+			Name:    "gov4git_refresh_errors_total",
+			Help:    "Number of failed attempts to refresh the metrics from the governance repo.",
+			Type:    counter,
+			Samples: single(float64(x.numErrors)),
+		},
+	}
+}
+
+// Serve serves the community metrics at http://listen/metrics, refreshing them every refresh period.
+// It returns when the context is cancelled or the listener fails.
+func Serve(
+	ctx context.Context,
+	addr gov.Address,
+	listen string,
+	refresh time.Duration,
+
+) {
+
+	must.Assertf(ctx, refresh > 0, "refresh period must be positive")
+	x := NewServer(addr)
+	x.Refresh(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, x)
+	srv := &http.Server{Addr: listen, Handler: mux}
+
+	go func() {
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				srv.Close()
+				return
+			case <-ticker.C:
+				x.Refresh(ctx)
+			}
+		}
+	}()
+
+	base.Infof("serving metrics at http://%v%v", listen, MetricsPath)
+	err := srv.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return
+	}
+	must.NoError(ctx, err)
+}
@@ -0,0 +1,72 @@
+package prom
+
+import (
+	"context"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/cron"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/gov4git/v2/proto/metrics"
+	"github.com/gov4git/gov4git/v2/proto/motion"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_0"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/git"
+)
+
+// Snapshot holds the values of the exported metrics, as computed from a single clone of the governance repo.
+type Snapshot struct {
+	Stamp        time.Time                 // time of collection
+	OpenMotions  map[motion.PolicyName]int // number of open motions, by policy
+	AllTime      *metrics.Series           // daily series since the beginning of the community
+	Today        *metrics.Series           // daily series for the current (UTC) day
+	MatchingPool float64                   // credits in the matching pool
+	Treasury     map[string]float64        // balances of the treasury accounts, by name
+	CapTable     metrics.CapTable
+	LastCron     time.Time // zero, if cron has never run
+}
+
+var treasuryAccounts = map[string]account.AccountID{
+	"issue": account.IssueAccountID,
+	"burn":  account.BurnAccountID,
+}
+
+func Collect(ctx context.Context, addr gov.Address) *Snapshot {
+	cloned := gov.Clone(ctx, addr)
+	return Collect_Local(ctx, cloned)
+}
+
+func Collect_Local(ctx context.Context, cloned gov.Cloned) *Snapshot {
+
+	now := time.Now()
+	today := metrics.Dailify(now)
+
+	entries := metric.ListFilter_Local(ctx, cloned, journal.Filter{Since: metrics.TimeDailyLowerBound})
+
+	s := &Snapshot{
+		Stamp:        now,
+		OpenMotions:  map[motion.PolicyName]int{},
+		AllTime:      metrics.ComputeSeries(entries, metrics.TimeDailyLowerBound, today),
+		Today:        metrics.ComputeSeries(entries, today, today),
+		MatchingPool: account.Get_Local(ctx, cloned, pmp_0.MatchingPoolAccountID).Balance(account.PluralAsset).Quantity,
+		Treasury:     map[string]float64{},
+		CapTable:     metrics.GetCapTable_Local(ctx, cloned),
+	}
+
+	for _, m := range motionproto.SelectOpenMotions(motionapi.ListMotions_Local(ctx, cloned.Tree())) {
+		s.OpenMotions[m.Policy]++
+	}
+
+	for name, id := range treasuryAccounts {
+		s.Treasury[name] = account.Get_Local(ctx, cloned, id).Balance(account.PluralAsset).Quantity
+	}
+
+	if latest, err := git.TryFromFile[cron.LatestChange](ctx, cloned.Tree(), cron.LatestChangeMetaNS); err == nil {
+		s.LastCron = latest.Stamp
+	}
+
+	return s
+}
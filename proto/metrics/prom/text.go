@@ -0,0 +1,229 @@
+package prom
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/metrics"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type metricType string
+
+const (
+	gauge   metricType = "gauge"
+	counter metricType = "counter"
+)
+
+type label struct {
+	Name  string
+	Value string
+}
+
+type sample struct {
+	Labels []label
+	Value  float64
+}
+
+type family struct {
+	Name    string
+	Help    string
+	Type    metricType
+	Samples []sample
+}
+
+func single(v float64) []sample {
+	return []sample{{Value: v}}
+}
+
+func byPurpose(concern, proposal, other metrics.DailySeries) []sample {
+	return []sample{
+		{Labels: []label{{"purpose", string(metric.VotePurposeConcern)}}, Value: concern.Total()},
+		{Labels: []label{{"purpose", string(metric.VotePurposeProposal)}}, Value: proposal.Total()},
+		{Labels: []label{{"purpose", "other"}}, Value: other.Total()},
+	}
+}
+
+func (s *Snapshot) families() []family {
+
+	fams := []family{
+		{
+			Name:    "gov4git_snapshot_timestamp_seconds",
+			Help:    "Time when the governance repo was last read.",
+			Type:    gauge,
+			Samples: single(unixSeconds(s.Stamp)),
+		},
+	}
+
+	if !s.LastCron.IsZero() {
+		fams = append(fams, family{
+			Name:    "gov4git_last_cron_timestamp_seconds",
+			Help:    "Time of the last cron job that changed the governance repo.",
+			Type:    gauge,
+			Samples: single(unixSeconds(s.LastCron)),
+		})
+	}
+
+	open := family{Name: "gov4git_motions_open", Help: "Number of open motions, by policy.", Type: gauge}
+	for policy, n := range s.OpenMotions {
+		open.Samples = append(open.Samples, sample{Labels: []label{{"policy", string(policy)}}, Value: float64(n)})
+	}
+	fams = append(fams, open)
+
+	a, t := s.AllTime, s.Today
+	fams = append(fams,
+		family{
+			Name: "gov4git_motion_events_total",
+			Help: "Number of motions opened, closed and cancelled.",
+			Type: counter,
+			Samples: []sample{
+				{Labels: []label{{"event", "open"}}, Value: a.DailyNumMotionOpen.Total()},
+				{Labels: []label{{"event", "close"}}, Value: a.DailyNumMotionClose.Total()},
+				{Labels: []label{{"event", "cancel"}}, Value: a.DailyNumMotionCancel.Total()},
+			},
+		},
+		family{
+			Name:    "gov4git_joins_total",
+			Help:    "Number of members who joined the community.",
+			Type:    counter,
+			Samples: single(a.DailyNumJoins.Total()),
+		},
+		family{
+			Name:    "gov4git_votes_total",
+			Help:    "Number of votes cast, by purpose.",
+			Type:    counter,
+			Samples: byPurpose(a.DailyNumConcernVotes, a.DailyNumProposalVotes, a.DailyNumOtherVotes),
+		},
+		family{
+			Name:    "gov4git_votes_today",
+			Help:    "Number of votes cast during the current UTC day, by purpose.",
+			Type:    gauge,
+			Samples: byPurpose(t.DailyNumConcernVotes, t.DailyNumProposalVotes, t.DailyNumOtherVotes),
+		},
+		family{
+			Name:    "gov4git_vote_charges_credits_total",
+			Help:    "Credits charged for votes, by purpose.",
+			Type:    counter,
+			Samples: byPurpose(a.DailyConcernVoteCharges, a.DailyProposalVoteCharges, a.DailyOtherVoteCharges),
+		},
+		family{
+			Name: "gov4git_credits_total",
+			Help: "Credits issued, burned and transferred.",
+			Type: counter,
+			Samples: []sample{
+				{Labels: []label{{"flow", "issued"}}, Value: a.DailyCreditsIssued.Total()},
+				{Labels: []label{{"flow", "burned"}}, Value: a.DailyCreditsBurned.Total()},
+				{Labels: []label{{"flow", "transferred"}}, Value: a.DailyCreditsTransferred.Total()},
+			},
+		},
+		family{
+			Name: "gov4git_cleared_credits_total",
+			Help: "Credits cleared when motions were closed or cancelled.",
+			Type: counter,
+			Samples: []sample{
+				{Labels: []label{{"kind", "bounty"}}, Value: a.DailyClearedBounties.Total()},
+				{Labels: []label{{"kind", "reward"}}, Value: a.DailyClearedRewards.Total()},
+				{Labels: []label{{"kind", "refund"}}, Value: a.DailyClearedRefunds.Total()},
+			},
+		},
+		family{
+			Name:    "gov4git_matching_pool_credits",
+			Help:    "Credits in the matching pool.",
+			Type:    gauge,
+			Samples: single(s.MatchingPool),
+		},
+	)
+
+	treasury := family{Name: "gov4git_treasury_credits", Help: "Balances of the treasury accounts.", Type: gauge}
+	for name, v := range s.Treasury {
+		treasury.Samples = append(treasury.Samples, sample{Labels: []label{{"account", name}}, Value: v})
+	}
+	fams = append(fams, treasury)
+
+	members := family{Name: "gov4git_member_credits", Help: "Credit balances of community members.", Type: gauge}
+	for _, c := range s.CapTable {
+		members.Samples = append(members.Samples, sample{Labels: []label{{"user", string(c.Name)}}, Value: c.Cap})
+	}
+	fams = append(fams, members)
+
+	return fams
+}
+
+// WriteText writes the snapshot in the Prometheus text exposition format.
+func (s *Snapshot) WriteText(w io.Writer) error {
+	return writeFamilies(w, s.families())
+}
+
+func (s *Snapshot) Text() []byte {
+	var buf bytes.Buffer
+	s.WriteText(&buf)
+	return buf.Bytes()
+}
+
+func writeFamilies(w io.Writer, fams []family) error {
+	var buf bytes.Buffer
+	for _, f := range fams {
+		sortSamples(f.Samples)
+		fmt.Fprintf(&buf, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+		fmt.Fprintf(&buf, "# TYPE %s %s\n", f.Name, f.Type)
+		for _, s := range f.Samples {
+			buf.WriteString(f.Name)
+			if len(s.Labels) > 0 {
+				buf.WriteByte('{')
+				for i, l := range s.Labels {
+					if i > 0 {
+						buf.WriteByte(',')
+					}
+					fmt.Fprintf(&buf, "%s=\"%s\"", l.Name, escapeLabelValue(l.Value))
+				}
+				buf.WriteByte('}')
+			}
+			buf.WriteByte(' ')
+			buf.WriteString(formatValue(s.Value))
+			buf.WriteByte('\n')
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// sortSamples orders samples by their label values, so that the output is deterministic.
+func sortSamples(samples []sample) {
+	key := func(s sample) string {
+		var k []string
+		for _, l := range s.Labels {
+			k = append(k, l.Value)
+		}
+		return strings.Join(k, "\x00")
+	}
+	sort.SliceStable(samples, func(i, j int) bool { return key(samples[i]) < key(samples[j]) })
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"slices"
 	"time"
 
 	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/alert"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/history/metric"
 	"github.com/gov4git/gov4git/v2/proto/journal"
@@ -49,8 +52,21 @@ func AssembleReport_Local(
 
 	capTable := GetCapTable_Local(ctx, cloned)
 
+	alerts := loadAlerts_Local(ctx, cloned, latest.AddDate(0, -1, 0), latest)
+
 	var w bytes.Buffer
 
+	if len(alerts) > 0 {
+		fmt.Fprintf(&w, "## Alerts\n\n")
+		fmt.Fprintf(&w, "Anomalous voting or treasury activity in the last 30 days:\n\n")
+		fmt.Fprintf(&w, "| Time (UTC) | Alert | Details |\n")
+		fmt.Fprintf(&w, "|  :--- |  :--- |  :--- |\n")
+		for _, a := range alerts {
+			fmt.Fprintf(&w, "| %s | %s | %s |\n", a.Stamp.UTC().Format(time.DateTime), a.Payload.Rule, a.Payload.Message)
+		}
+		fmt.Fprintln(&w)
+	}
+
 	fmt.Fprintf(&w, "## Community economics\n\n")
 
 	fmt.Fprintf(&w, "Currently, there are `%0.6f` credits in the __matching fund__.\n\n", matchFunds)
@@ -59,78 +75,17 @@ func AssembleReport_Local(
 
 	fmt.Fprintf(&w, "### Aggregates\n\n")
 
-	fmt.Fprintf(&w, "| Indicator|  30-day aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Number of opened issues/PRs | %d |\n", int(last30DaysSeries.DailyNumMotionOpen.Total()))
-	fmt.Fprintf(&w, "| Number of closed issues/PRs | %d |\n", int(last30DaysSeries.DailyNumMotionClose.Total()))
-	fmt.Fprintf(&w, "| Number of cancelled issues/PRs | %d |\n\n", int(last30DaysSeries.DailyNumMotionCancel.Total()))
-
-	fmt.Fprintf(&w, "| Indicator|  30-day aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Number of votes on issues | %d |\n", int(last30DaysSeries.DailyNumConcernVotes.Total()))
-	fmt.Fprintf(&w, "| Number of votes on PRs | %d |\n", int(last30DaysSeries.DailyNumProposalVotes.Total()))
-	fmt.Fprintf(&w, "| Number of votes on other | %d |\n", int(last30DaysSeries.DailyNumOtherVotes.Total()))
-	fmt.Fprintf(&w, "| Credits spent on issue votes | %0.6f |\n", last30DaysSeries.DailyConcernVoteCharges.Total())
-	fmt.Fprintf(&w, "| Credits spent on PR votes | %0.6f |\n", last30DaysSeries.DailyProposalVoteCharges.Total())
-	fmt.Fprintf(&w, "| Credits spent on other votes | %0.6f |\n\n", last30DaysSeries.DailyOtherVoteCharges.Total())
-
-	fmt.Fprintf(&w, "| Indicator|  30-day aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Credits cleared in bounties | %0.6f |\n", last30DaysSeries.DailyClearedBounties.Total())
-	fmt.Fprintf(&w, "| Credits cleared in rewards | %0.6f |\n", last30DaysSeries.DailyClearedRewards.Total())
-	fmt.Fprintf(&w, "| Credits cleared in refunds | %0.6f |\n\n", last30DaysSeries.DailyClearedRefunds.Total())
-
-	fmt.Fprintf(&w, "| Indicator|  30-day aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Number of new members | %d |\n\n", int(last30DaysSeries.DailyNumJoins.Total()))
-
-	fmt.Fprintf(&w, "| Indicator|  30-day aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Credits issued | %0.6f |\n", last30DaysSeries.DailyCreditsIssued.Total())
-	fmt.Fprintf(&w, "| Credits burned | %0.6f |\n", last30DaysSeries.DailyCreditsBurned.Total())
-	fmt.Fprintf(&w, "| Credits transferred | %0.6f |\n\n", last30DaysSeries.DailyCreditsTransferred.Total())
+	writeIndicatorsMD(&w, "30-day aggregate", seriesIndicators(last30DaysSeries))
 
 	fmt.Printf("### Daily breakdown\n\n")
 
-	fmt.Fprintf(&w, "<img alt=%q src=%s width=650 />\n\n", "Daily issues/PRs opened/closed/cancelled", urlCalc("daily_motions.png"))
-	fmt.Fprintf(&w, "<img alt=%q src=%s width=650 />\n\n", "Daily vote counts", urlCalc("daily_votes.png"))
-	fmt.Fprintf(&w, "<img alt=%q src=%s width=650 />\n\n", "Daily vote charges", urlCalc("daily_charges.png"))
-	fmt.Fprintf(&w, "<img alt=%q src=%s width=650 />\n\n", "Daily credits cleared in bounties/rewards/refunds", urlCalc("daily_cleared.png"))
-	fmt.Fprintf(&w, "<img alt=%q src=%s width=650 />\n\n", "Daily new community members", urlCalc("daily_joins.png"))
-	fmt.Fprintf(&w, "<img alt=%q src=%s width=650 />\n\n", "Daily credits issued/burned/transferred", urlCalc("daily_credits.png"))
+	for _, c := range reportCharts {
+		fmt.Fprintf(&w, "<img alt=%q src=%s width=650 />\n\n", c.Alt, urlCalc(c.Path))
+	}
 
 	fmt.Fprintf(&w, "## All time\n\n")
 
-	fmt.Fprintf(&w, "| Indicator|  All time aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Number of opened issues/PRs | %d |\n", int(allTimeSeries.DailyNumMotionOpen.Total()))
-	fmt.Fprintf(&w, "| Number of closed issues/PRs | %d |\n", int(allTimeSeries.DailyNumMotionClose.Total()))
-	fmt.Fprintf(&w, "| Number of cancelled issues/PRs | %d |\n\n", int(allTimeSeries.DailyNumMotionCancel.Total()))
-
-	fmt.Fprintf(&w, "| Indicator|  All time aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Number of votes on issues | %d |\n", int(allTimeSeries.DailyNumConcernVotes.Total()))
-	fmt.Fprintf(&w, "| Number of votes on PRs | %d |\n", int(allTimeSeries.DailyNumProposalVotes.Total()))
-	fmt.Fprintf(&w, "| Number of votes on other | %d |\n", int(allTimeSeries.DailyNumOtherVotes.Total()))
-	fmt.Fprintf(&w, "| Credits spent on issue votes | %0.6f |\n", allTimeSeries.DailyConcernVoteCharges.Total())
-	fmt.Fprintf(&w, "| Credits spent on PR votes | %0.6f |\n", allTimeSeries.DailyProposalVoteCharges.Total())
-	fmt.Fprintf(&w, "| Credits spent on other votes | %0.6f |\n\n", allTimeSeries.DailyOtherVoteCharges.Total())
-
-	fmt.Fprintf(&w, "| Indicator|  All time aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Credits cleared in bounties | %0.6f |\n", allTimeSeries.DailyClearedBounties.Total())
-	fmt.Fprintf(&w, "| Credits cleared in rewards | %0.6f |\n", allTimeSeries.DailyClearedRewards.Total())
-	fmt.Fprintf(&w, "| Credits cleared in refunds | %0.6f |\n\n", allTimeSeries.DailyClearedRefunds.Total())
-
-	fmt.Fprintf(&w, "| Indicator|  All time aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Number of new members | %d |\n\n", int(allTimeSeries.DailyNumJoins.Total()))
-
-	fmt.Fprintf(&w, "| Indicator|  All time aggregate |\n")
-	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
-	fmt.Fprintf(&w, "| Credits issued | %0.6f |\n", allTimeSeries.DailyCreditsIssued.Total())
-	fmt.Fprintf(&w, "| Credits burned | %0.6f |\n", allTimeSeries.DailyCreditsBurned.Total())
-	fmt.Fprintf(&w, "| Credits transferred | %0.6f |\n\n", allTimeSeries.DailyCreditsTransferred.Total())
+	writeIndicatorsMD(&w, "All time aggregate", seriesIndicators(allTimeSeries))
 
 	if len(capTable) > 0 {
 		fmt.Printf("### Capitalization table\n\n")
@@ -147,6 +102,7 @@ func AssembleReport_Local(
 			Last30Days: last30DaysSeries,
 			AllTime:    allTimeSeries,
 		},
+		Alerts:   alerts,
 		ReportMD: w.String(),
 		Assets: map[string][]byte{
 			"daily_motions.png": plotDailyMotionsPNG(ctx, last30DaysSeries),
@@ -159,6 +115,72 @@ func AssembleReport_Local(
 	}
 }
 
+type reportChart struct {
+	Path string // asset path
+	Alt  string
+}
+
+// reportCharts are the daily charts of the report, in the order they are displayed.
+var reportCharts = []reportChart{
+	{"daily_motions.png", "Daily issues/PRs opened/closed/cancelled"},
+	{"daily_votes.png", "Daily vote counts"},
+	{"daily_charges.png", "Daily vote charges"},
+	{"daily_cleared.png", "Daily credits cleared in bounties/rewards/refunds"},
+	{"daily_joins.png", "Daily new community members"},
+	{"daily_credits.png", "Daily credits issued/burned/transferred"},
+}
+
+// indicator is an aggregate value of a series, formatted for display.
+type indicator struct {
+	Label string
+	Value string
+}
+
+// seriesIndicators returns the aggregate indicators of a series, in groups that are displayed together.
+func seriesIndicators(s *Series) [][]indicator {
+	count := func(x float64) string { return fmt.Sprintf("%d", int(x)) }
+	credits := func(x float64) string { return fmt.Sprintf("%0.6f", x) }
+	return [][]indicator{
+		{
+			{"Number of opened issues/PRs", count(s.DailyNumMotionOpen.Total())},
+			{"Number of closed issues/PRs", count(s.DailyNumMotionClose.Total())},
+			{"Number of cancelled issues/PRs", count(s.DailyNumMotionCancel.Total())},
+		},
+		{
+			{"Number of votes on issues", count(s.DailyNumConcernVotes.Total())},
+			{"Number of votes on PRs", count(s.DailyNumProposalVotes.Total())},
+			{"Number of votes on other", count(s.DailyNumOtherVotes.Total())},
+			{"Credits spent on issue votes", credits(s.DailyConcernVoteCharges.Total())},
+			{"Credits spent on PR votes", credits(s.DailyProposalVoteCharges.Total())},
+			{"Credits spent on other votes", credits(s.DailyOtherVoteCharges.Total())},
+		},
+		{
+			{"Credits cleared in bounties", credits(s.DailyClearedBounties.Total())},
+			{"Credits cleared in rewards", credits(s.DailyClearedRewards.Total())},
+			{"Credits cleared in refunds", credits(s.DailyClearedRefunds.Total())},
+		},
+		{
+			{"Number of new members", count(s.DailyNumJoins.Total())},
+		},
+		{
+			{"Credits issued", credits(s.DailyCreditsIssued.Total())},
+			{"Credits burned", credits(s.DailyCreditsBurned.Total())},
+			{"Credits transferred", credits(s.DailyCreditsTransferred.Total())},
+		},
+	}
+}
+
+func writeIndicatorsMD(w io.Writer, aggregate string, groups [][]indicator) {
+	for _, g := range groups {
+		fmt.Fprintf(w, "| Indicator|  %s |\n", aggregate)
+		fmt.Fprintf(w, "|  ---:|  :--- |\n")
+		for _, x := range g {
+			fmt.Fprintf(w, "| %s | %s |\n", x.Label, x.Value)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
 func loadHistory_Local(
 	ctx context.Context,
 	cloned gov.Cloned,
@@ -167,12 +189,24 @@ func loadHistory_Local(
 
 ) journal.Entries[*metric.Event] {
 
-	all := metric.List_Local(ctx, cloned)
-	s := journal.Entries[*metric.Event]{}
-	for _, entry := range all {
-		if isNotBefore(entry.Stamp, earliest) && isNotAfter(entry.Stamp, latest) {
-			s = append(s, entry)
-		}
+	return metric.ListFilter_Local(ctx, cloned, journal.Filter{Since: earliest, Until: latest})
+}
+
+// MaxReportAlerts is the number of most recent alerts included in the report.
+const MaxReportAlerts = 20
+
+func loadAlerts_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	earliest time.Time,
+	latest time.Time,
+
+) journal.Entries[*alert.Alert] {
+
+	alerts := alert.List_Local(ctx, cloned, journal.Filter{Since: earliest, Until: latest})
+	slices.Reverse(alerts)
+	if len(alerts) > MaxReportAlerts {
+		alerts = alerts[:MaxReportAlerts]
 	}
-	return s
+	return alerts
 }
@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gov4git/gov4git/v2"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_0"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/ns"
+)
+
+// Site is a static website presenting the community dashboard.
+// It consists of the dashboard report with its charts, motion leaderboards and a page for each member.
+type Site struct {
+	Files map[string][]byte // path relative to the site root -> content
+}
+
+// LeaderboardSize is the number of motions listed in each motion leaderboard.
+const LeaderboardSize = 20
+
+func AssembleSite(
+	ctx context.Context,
+	addr gov.Address,
+	earliest time.Time,
+	latest time.Time,
+
+) *Site {
+
+	cloned := gov.Clone(ctx, addr)
+	return AssembleSite_Local(ctx, cloned, earliest, latest)
+}
+
+func AssembleSite_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	earliest time.Time,
+	latest time.Time,
+
+) *Site {
+
+	// charts are placed next to the index page
+	report := AssembleReport_Local(ctx, cloned, func(path string) string { return path }, earliest, latest)
+
+	common := sitePage{
+		Community: string(cloned.Address().Repo),
+		Until:     latest.UTC().Format(time.DateTime),
+		Version:   gov4git.GetVersionInfo().Version,
+	}
+	site := &Site{Files: map[string][]byte{}}
+	for path, content := range report.Assets {
+		site.Files[path] = content
+	}
+
+	// members
+	users := member.ListGroupUsers_Local(ctx, cloned, member.Everybody)
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+	reports := AssembleMemberReports_Local(ctx, cloned, users)
+	contributors := []siteContributor{}
+	for _, user := range users {
+		r := reports[user]
+		page := common
+		page.Root = "../"
+		page.Title = "Member @" + string(user)
+		site.Files[memberPagePath(user)] = renderSitePage(ctx, siteMemberTmpl, siteMemberPage{sitePage: page, Report: r})
+		if r.NumAccepted > 0 {
+			contributors = append(contributors, siteContributor{User: user, Page: memberPagePath(user), Accepted: r.NumAccepted, Authored: r.NumAuthored})
+		}
+	}
+	sort.SliceStable(contributors, func(i, j int) bool { return contributors[i].Accepted > contributors[j].Accepted })
+
+	// index
+	capTable := GetCapTable_Local(ctx, cloned)
+	index := siteIndexPage{
+		sitePage:      common,
+		Alerts:        report.Alerts,
+		MatchingFunds: account.Get_Local(ctx, cloned, pmp_0.MatchingPoolAccountID).Balance(account.PluralAsset).Quantity,
+		Last30Days:    seriesIndicators(report.Series.Last30Days),
+		AllTime:       seriesIndicators(report.Series.AllTime),
+		Charts:        reportCharts,
+	}
+	index.Title = "Community dashboard"
+	for _, uc := range capTable {
+		index.CapTable = append(index.CapTable, siteUserCap{UserCap: uc, Page: memberPagePath(uc.Name)})
+	}
+	site.Files["index.html"] = renderSitePage(ctx, siteIndexTmpl, index)
+
+	// leaderboards
+	motions := siteMotionsPage{sitePage: common}
+	motions.Title = "Motions"
+	for _, m := range motionapi.ListMotions_Local(ctx, cloned.Tree()) {
+		if m.Closed || m.Cancelled {
+			continue
+		}
+		switch {
+		case m.IsConcern():
+			motions.Concerns = append(motions.Concerns, m)
+		case m.IsProposal():
+			motions.Proposals = append(motions.Proposals, m)
+		}
+	}
+	motions.Concerns = topMotions(motions.Concerns)
+	motions.Proposals = topMotions(motions.Proposals)
+	motions.Contributors = contributors
+	if len(motions.Contributors) > LeaderboardSize {
+		motions.Contributors = motions.Contributors[:LeaderboardSize]
+	}
+	site.Files["motions.html"] = renderSitePage(ctx, siteMotionsTmpl, motions)
+
+	site.Files["style.css"] = []byte(siteCSS)
+	return site
+}
+
+// topMotions returns the motions with the highest attention scores.
+func topMotions(ms motionproto.Motions) motionproto.Motions {
+	sort.SliceStable(ms, func(i, j int) bool { return ms[i].Score.Attention > ms[j].Score.Attention })
+	if len(ms) > LeaderboardSize {
+		ms = ms[:LeaderboardSize]
+	}
+	return ms
+}
+
+func memberPagePath(user member.User) string {
+	return "members/" + url.PathEscape(string(user)) + ".html"
+}
+
+// WriteDir writes the site to a directory on the local file system.
+func (s *Site) WriteDir(ctx context.Context, dir string) {
+	for path, content := range s.Files {
+		p := filepath.Join(dir, filepath.FromSlash(path))
+		must.NoError(ctx, os.MkdirAll(filepath.Dir(p), 0755))
+		must.NoError(ctx, os.WriteFile(p, content, 0644))
+	}
+}
+
+// PublishSite replaces the contents of a git branch with the site, so that it can be served by a static web host.
+// Nothing is committed if the site is unchanged.
+func PublishSite(
+	ctx context.Context,
+	addr git.Address,
+	site *Site,
+
+) {
+
+	cloned := git.CloneOne(ctx, addr)
+	t := cloned.Tree()
+
+	// remove files of the previous version, such as the pages of former members
+	files, err := git.ListFilesRecursively(t, ns.NS{})
+	must.NoError(ctx, err)
+	for _, f := range files {
+		if _, ok := site.Files[f.GitPath()]; !ok {
+			_, err := git.TreeRemove(ctx, t, f)
+			must.NoError(ctx, err)
+		}
+	}
+
+	for path, content := range site.Files {
+		git.BytesToFileStage(ctx, t, ns.ParseFromGitPath(path), content)
+	}
+
+	status, err := t.Status()
+	must.NoError(ctx, err)
+	if status.IsClean() {
+		base.Infof("dashboard site on %v is unchanged", addr)
+		return
+	}
+	git.Commit(ctx, t, "Publish community dashboard")
+	cloned.Push(ctx)
+}
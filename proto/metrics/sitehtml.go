@@ -0,0 +1,220 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/materials"
+	"github.com/gov4git/gov4git/v2/proto/alert"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/must"
+)
+
+// sitePage holds the fields shared by all pages of the site.
+type sitePage struct {
+	Title     string
+	Root      string // relative path from the page to the site root
+	Community string
+	Until     string
+	Version   string
+}
+
+func (sitePage) Gov4GitURL() string {
+	return materials.Gov4GitWebsiteURL
+}
+
+func (sitePage) AvatarURL() string {
+	return materials.Gov4GitAvatarURL
+}
+
+type siteIndexPage struct {
+	sitePage
+	Alerts        journal.Entries[*alert.Alert]
+	MatchingFunds float64
+	Last30Days    [][]indicator
+	AllTime       [][]indicator
+	Charts        []reportChart
+	CapTable      []siteUserCap
+}
+
+type siteUserCap struct {
+	UserCap
+	Page string
+}
+
+type siteMotionsPage struct {
+	sitePage
+	Concerns     motionproto.Motions
+	Proposals    motionproto.Motions
+	Contributors []siteContributor
+}
+
+type siteContributor struct {
+	User     member.User
+	Page     string
+	Accepted int
+	Authored int
+}
+
+type siteMemberPage struct {
+	sitePage
+	Report *MemberReport
+}
+
+func (x siteMemberPage) Contributions() []indicator {
+	return x.Report.contributionIndicators()
+}
+
+func (x siteMemberPage) Voting() []indicator {
+	return x.Report.votingIndicators()
+}
+
+var siteFuncs = template.FuncMap{
+	"credits": func(x float64) string { return fmt.Sprintf("%0.6f", x) },
+	"day":     func(t time.Time) string { return t.Format(time.DateOnly) },
+	"stamp":   func(t time.Time) string { return t.UTC().Format(time.DateTime) },
+	"status":  func(m AuthoredMotion) string { return m.status() },
+}
+
+func renderSitePage(ctx context.Context, tmpl *template.Template, page any) []byte {
+	var w bytes.Buffer
+	must.NoError(ctx, tmpl.ExecuteTemplate(&w, "page", page))
+	return w.Bytes()
+}
+
+func parseSiteTemplate(body string) *template.Template {
+	return template.Must(template.Must(template.New("").Funcs(siteFuncs).Parse(siteLayout)).Parse(body))
+}
+
+var (
+	siteIndexTmpl   = parseSiteTemplate(siteIndexBody)
+	siteMotionsTmpl = parseSiteTemplate(siteMotionsBody)
+	siteMemberTmpl  = parseSiteTemplate(siteMemberBody)
+)
+
+const siteLayout = `{{define "page"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · Gov4Git</title>
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<header>
+<a href="{{.Gov4GitURL}}"><img src="{{.AvatarURL}}" alt="This project is governed with Gov4Git." width="65"></a>
+<div>
+<h1>{{.Title}}</h1>
+<p class="meta">{{.Community}} · data until {{.Until}} UTC · Gov4Git {{.Version}}</p>
+</div>
+</header>
+<nav><a href="{{.Root}}index.html">Dashboard</a> <a href="{{.Root}}motions.html">Motions</a></nav>
+<main>
+{{template "body" .}}
+</main>
+</body>
+</html>
+{{end}}`
+
+const siteIndexBody = `{{define "body"}}
+{{if .Alerts}}<h2>Alerts</h2>
+<p>Anomalous voting or treasury activity in the last 30 days:</p>
+<table class="alerts">
+<tr><th>Time (UTC)</th><th>Alert</th><th>Details</th></tr>
+{{range .Alerts}}<tr><td>{{stamp .Stamp}}</td><td>{{.Payload.Rule}}</td><td>{{.Payload.Message}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Community economics</h2>
+<p>Currently, there are <code>{{credits .MatchingFunds}}</code> credits in the <strong>matching fund</strong>.</p>
+
+<h2>Last 30 days</h2>
+{{range .Last30Days}}<table class="indicators">
+<tr><th>Indicator</th><th>30-day aggregate</th></tr>
+{{range .}}<tr><td>{{.Label}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{end}}
+<h3>Daily breakdown</h3>
+{{range .Charts}}<img class="chart" alt="{{.Alt}}" src="{{.Path}}">
+{{end}}
+<h2>All time</h2>
+{{range .AllTime}}<table class="indicators">
+<tr><th>Indicator</th><th>All time aggregate</th></tr>
+{{range .}}<tr><td>{{.Label}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .CapTable}}<h3>Capitalization table</h3>
+<table>
+<tr><th>User</th><th>Capitalization</th></tr>
+{{range .CapTable}}<tr><td><a href="{{.Page}}">@{{.Name}}</a></td><td>{{credits .Cap}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}`
+
+const siteMotionsBody = `{{define "body"}}
+<h2>Top open issues</h2>
+{{if .Concerns}}<table>
+<tr><th>Issue</th><th>Title</th><th>Author</th><th>Attention</th></tr>
+{{range .Concerns}}<tr><td>{{if .TrackerURL}}<a href="{{.TrackerURL}}">{{.ID}}</a>{{else}}{{.ID}}{{end}}</td><td>{{.Title}}</td><td>{{if .Author}}@{{.Author}}{{end}}</td><td>{{credits .Score.Attention}}</td></tr>
+{{end}}</table>
+{{else}}<p>There are no open issues.</p>
+{{end}}
+<h2>Top open PRs</h2>
+{{if .Proposals}}<table>
+<tr><th>PR</th><th>Title</th><th>Author</th><th>Attention</th></tr>
+{{range .Proposals}}<tr><td>{{if .TrackerURL}}<a href="{{.TrackerURL}}">{{.ID}}</a>{{else}}{{.ID}}{{end}}</td><td>{{.Title}}</td><td>{{if .Author}}@{{.Author}}{{end}}</td><td>{{credits .Score.Attention}}</td></tr>
+{{end}}</table>
+{{else}}<p>There are no open PRs.</p>
+{{end}}
+<h2>Top contributors</h2>
+{{if .Contributors}}<table>
+<tr><th>User</th><th>Accepted issues/PRs</th><th>Authored issues/PRs</th></tr>
+{{range .Contributors}}<tr><td><a href="{{.Page}}">@{{.User}}</a></td><td>{{.Accepted}}</td><td>{{.Authored}}</td></tr>
+{{end}}</table>
+{{else}}<p>No issues or PRs have been accepted yet.</p>
+{{end}}
+{{end}}`
+
+const siteMemberBody = `{{define "body"}}
+<p>Currently, @{{.Report.User}} has <code>{{credits .Report.Balance}}</code> credits.</p>
+
+<h2>Contributions</h2>
+<table class="indicators">
+<tr><th>Indicator</th><th>All time aggregate</th></tr>
+{{range .Contributions}}<tr><td>{{.Label}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{if .Report.Authored}}<table>
+<tr><th>Motion</th><th>Type</th><th>Title</th><th>Status</th></tr>
+{{range .Report.Authored}}<tr><td>{{.ID}}</td><td>{{.Type}}</td><td>{{.Title}}</td><td>{{status .}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Voting</h2>
+<table class="indicators">
+<tr><th>Indicator</th><th>All time aggregate</th></tr>
+{{range .Voting}}<tr><td>{{.Label}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{if .Report.BalanceHistory}}<h2>Balance over time</h2>
+<table>
+<tr><th>Day</th><th>Balance</th></tr>
+{{range .Report.BalanceHistory}}<tr><td>{{day .Day}}</td><td>{{credits .Balance}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}`
+
+const siteCSS = `body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2328; max-width: 960px; margin: 0 auto; padding: 1em; }
+header { display: flex; align-items: center; gap: 1em; }
+header h1 { margin: 0; }
+.meta { color: #656d76; margin: 0.25em 0 0 0; }
+nav { margin: 1em 0; padding: 0.5em 0; border-top: 1px solid #d0d7de; border-bottom: 1px solid #d0d7de; }
+nav a { margin-right: 1em; }
+a { color: #0969da; text-decoration: none; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d0d7de; padding: 0.3em 0.8em; text-align: left; }
+table.indicators td:first-child { text-align: right; }
+table.alerts th { background: #fff8c5; }
+img.chart { display: block; width: 650px; max-width: 100%; margin: 1em 0; }
+`
@@ -60,3 +60,10 @@ func (r *ModuleRegistry[N, M]) Get(ctx context.Context, key N) M {
 	must.Assertf(ctx, ok, "module %v not found", key)
 	return v
 }
+
+func (r *ModuleRegistry[N, M]) Lookup(key N) (M, bool) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	v, ok := r.mods[key]
+	return v, ok
+}
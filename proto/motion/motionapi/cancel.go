@@ -57,9 +57,7 @@ func CancelMotion_StageOnly(
 
 	// log
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "motion_cancel",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"motion": motion},
+		Payload: &CancelTrace{ID: id, Motion: motion},
 	})
 
 	return report, notices
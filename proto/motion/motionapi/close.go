@@ -58,9 +58,7 @@ func CloseMotion_StageOnly(
 
 	// log
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "motion_close",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"motion": motion},
+		Payload: &CloseTrace{ID: id, Motion: motion},
 	})
 
 	return report, notices
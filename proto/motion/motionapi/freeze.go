@@ -55,9 +55,7 @@ func FreezeMotion_StageOnly(
 
 	// log
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "motion_freeze",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"motion": motion},
+		Payload: &FreezeTrace{ID: id, Motion: motion},
 	})
 
 	return report, notices
@@ -107,9 +105,7 @@ func UnfreezeMotion_StageOnly(
 
 	// log
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "motion_unfreeze",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"motion": motion},
+		Payload: &UnfreezeTrace{ID: id, Motion: motion},
 	})
 
 	return report, notices
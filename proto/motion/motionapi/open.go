@@ -84,9 +84,7 @@ func OpenMotion_StageOnly(
 
 	// log
 	trace.Log_StageOnly(ctx, cloned.PublicClone(), &trace.Event{
-		Op:     "motion_open",
-		Args:   trace.M{"id": id},
-		Result: trace.M{"motion": motion},
+		Payload: &OpenTrace{ID: id, Motion: motion},
 	})
 
 	return report, notices
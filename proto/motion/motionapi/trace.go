@@ -0,0 +1,52 @@
+package motionapi
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+)
+
+func init() {
+	ctx := context.Background()
+	trace.Register[OpenTrace](ctx, 1)
+	trace.Register[FreezeTrace](ctx, 1)
+	trace.Register[UnfreezeTrace](ctx, 1)
+	trace.Register[CloseTrace](ctx, 1)
+	trace.Register[CancelTrace](ctx, 1)
+}
+
+type OpenTrace struct {
+	ID     motionproto.MotionID `json:"id"`
+	Motion motionproto.Motion   `json:"motion"`
+}
+
+func (*OpenTrace) TraceOp() string { return "motion_open" }
+
+type FreezeTrace struct {
+	ID     motionproto.MotionID `json:"id"`
+	Motion motionproto.Motion   `json:"motion"`
+}
+
+func (*FreezeTrace) TraceOp() string { return "motion_freeze" }
+
+type UnfreezeTrace struct {
+	ID     motionproto.MotionID `json:"id"`
+	Motion motionproto.Motion   `json:"motion"`
+}
+
+func (*UnfreezeTrace) TraceOp() string { return "motion_unfreeze" }
+
+type CloseTrace struct {
+	ID     motionproto.MotionID `json:"id"`
+	Motion motionproto.Motion   `json:"motion"`
+}
+
+func (*CloseTrace) TraceOp() string { return "motion_close" }
+
+type CancelTrace struct {
+	ID     motionproto.MotionID `json:"id"`
+	Motion motionproto.Motion   `json:"motion"`
+}
+
+func (*CancelTrace) TraceOp() string { return "motion_cancel" }
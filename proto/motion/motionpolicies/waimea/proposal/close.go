@@ -6,6 +6,7 @@ import (
 
 	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/etc"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/history/metric"
 	"github.com/gov4git/gov4git/v2/proto/member"
@@ -105,8 +106,9 @@ func (x proposalPolicy) Close(
 			if realizedBounty > 0 {
 				to := authorAccount
 				amt := account.H(account.PluralAsset, realizedBounty)
+				// bounties are paid by the policy, not issued by an organizer
 				account.Issue_StageOnly(
-					ctx,
+					etc.WithApproval(ctx),
 					cloned.PublicClone(),
 					to,
 					amt,
@@ -17,8 +17,16 @@ func MotionNoticesNS(id MotionID) ns.NS {
 	return MotionKV.KeyNS(MotionNS, id).Append("notices.json")
 }
 
+const motionAccountKey = "motion"
+
 func MotionAccountID(motionID MotionID) account.AccountID {
-	return account.AccountIDFromLine(account.Pair("motion", motionID.String()))
+	return account.AccountIDFromLine(account.Pair(motionAccountKey, motionID.String()))
+}
+
+// AccountMotion returns the motion owning an account, if it is the account of a motion or of one of its policies.
+func AccountMotion(id account.AccountID) (MotionID, bool) {
+	m, ok := account.Line(id).LeadingPair(motionAccountKey)
+	return MotionID(m), ok
 }
 
 var (
@@ -0,0 +1,79 @@
+package multisig
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Approve signs a proposal with the signer's identity and sends the signature to the community.
+// The signature is collected during the next sync.
+func Approve(
+	ctx context.Context,
+	signerAddr id.OwnerAddress,
+	govAddr gov.Address,
+	proposalID ProposalID,
+
+) git.Change[form.Map, mail.RequestEnvelope[ApproveRequest]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	signerOwner := id.CloneOwner(ctx, signerAddr)
+	chg := Approve_StageOnly(ctx, signerOwner, govCloned, proposalID)
+	proto.Commit(ctx, signerOwner.Public.Tree(), chg)
+	signerOwner.Public.Push(ctx)
+	return chg
+}
+
+func Approve_StageOnly(
+	ctx context.Context,
+	signerOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	proposalID ProposalID,
+
+) git.Change[form.Map, mail.RequestEnvelope[ApproveRequest]] {
+
+	// fail early, if the approval would be rejected by the community
+	p := Get_Local(ctx, govCloned, proposalID)
+	must.Assert(ctx, p.IsOpen(), fmt.Errorf("%w: %v", ErrProposalClosed, proposalID))
+	chain := id.GetKeyChain(ctx, signerOwner.Public.Tree())
+	signer := findSigner_Local(ctx, govCloned, p, chain)
+	must.Assert(ctx, !signer.IsNone(), fmt.Errorf("%w: %v", ErrNotSigner, proposalID))
+
+	approval := Approval{Proposal: p.ID, Digest: p.Digest(), Time: time.Now()}
+	request := ApproveRequest{Approval: id.Sign(ctx, id.GetOwnerCredentials(ctx, signerOwner), approval)}
+	sendOnly := mail.Request_StageOnly(ctx, signerOwner, govCloned.Tree(), MultisigTopic, request)
+	return git.NewChange(
+		fmt.Sprintf("Approve proposal %v", proposalID),
+		"multisig_approve",
+		form.Map{"proposal": proposalID, "signer": signer},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+// findSigner_Local returns the designated signer of the proposal, who is registered with one of the keys in chain.
+func findSigner_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	p Proposal,
+	chain id.KeyChain,
+
+) member.User {
+
+	for _, u := range member.LookupUserByID_Local(ctx, cloned, chain.IDs()...) {
+		if slices.Contains(p.Signers, string(u)) {
+			return u
+		}
+	}
+	return ""
+}
@@ -0,0 +1,13 @@
+package multisig
+
+import "errors"
+
+var (
+	ErrNoPolicy           = errors.New("no multi-signature policy is in effect")
+	ErrProposalNotFound   = errors.New("proposal not found")
+	ErrProposalClosed     = errors.New("proposal is already applied or cancelled")
+	ErrNotSigner          = errors.New("user is not a designated signer of the proposal")
+	ErrInvalidSignature   = errors.New("approval signature is not valid")
+	ErrDigestMismatch     = errors.New("approval does not match the proposal")
+	ErrUnrecognizedAction = errors.New("unrecognized organizer action")
+)
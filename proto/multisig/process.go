@@ -0,0 +1,296 @@
+package multisig
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Process collects approvals from the designated signers, and applies the proposals which have reached their threshold.
+func Process(
+	ctx context.Context,
+	govAddr gov.OwnerAddress,
+
+) (git.Change[form.Map, Report], mail.Rejections) {
+
+	base.Infof("fetching approvals of organizer proposals ...")
+
+	govOwner := gov.CloneOwner(ctx, govAddr)
+	chg, changed, rejected := Process_StageOnly(ctx, govOwner)
+	if changed {
+		proto.Commit(ctx, govOwner.Public.Tree(), chg)
+		govOwner.Public.Push(ctx)
+	}
+	return chg, rejected
+}
+
+func Process_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+
+) (change git.Change[form.Map, Report], changed bool, rejected mail.Rejections) {
+
+	cloned := govOwner.PublicClone()
+	report := Report{Approvals: Results{}, Applied: []ProposalID{}, Failed: map[ProposalID]string{}}
+	rejected = mail.Rejections{}
+
+	// collect approvals
+	chains := map[member.User]id.KeyChain{}
+	for _, signer := range listSigners_Local(ctx, cloned) {
+		results, rej, chain, err := processSignerRequests_StageOnly(ctx, govOwner, signer)
+		if err != nil {
+			base.Infof("fetching approvals from signer %v (%v)", signer, err)
+			continue
+		}
+		report.Approvals = append(report.Approvals, results...)
+		rejected = append(rejected, rej...)
+		if chain != nil {
+			chains[signer] = *chain
+		}
+	}
+
+	// apply approved proposals
+	policy := etc.GetSettings_StageOnly(ctx, cloned).Multisig
+	for _, p := range List_Local(ctx, cloned) {
+		if !p.IsOpen() || !isApproved(ctx, policy, chains, p) {
+			continue
+		}
+		if err := must.Try(func() { apply_StageOnly(ctx, govOwner, p) }); err != nil {
+			base.Infof("multisig: applying proposal %v failed (%v)", p.ID, err)
+			p.LastError = err.Error()
+			proposalKV.Set(ctx, proposalNS, cloned.Tree(), p.ID, p)
+			report.Failed[p.ID] = p.LastError
+			continue
+		}
+		report.Applied = append(report.Applied, p.ID)
+	}
+
+	changed = len(report.Approvals) > 0 || len(report.Applied) > 0 || len(report.Failed) > 0
+	return git.NewChange(
+		fmt.Sprintf("Collected %d approvals and applied %d proposals", len(report.Approvals), len(report.Applied)),
+		"multisig_process",
+		form.Map{},
+		report,
+		nil,
+	), changed, rejected
+}
+
+// listSigners_Local returns the signers of open proposals and of the current policy, who are community users.
+func listSigners_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) []member.User {
+
+	names := []string{}
+	if policy := etc.GetSettings_StageOnly(ctx, cloned).Multisig; policy != nil {
+		names = append(names, policy.Signers...)
+	}
+	for _, p := range List_Local(ctx, cloned) {
+		if p.IsOpen() {
+			names = append(names, p.Signers...)
+		}
+	}
+	sort.Strings(names)
+
+	signers := []member.User{}
+	for _, n := range slices.Compact(names) {
+		if u := member.User(n); member.IsUser_Local(ctx, cloned, u) {
+			signers = append(signers, u)
+		}
+	}
+	return signers
+}
+
+// processSignerRequests_StageOnly also returns the key chain of the signer,
+// or nil if the signer's repo does not hold the credentials the signer is registered with.
+func processSignerRequests_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	signer member.User,
+
+) (Results, mail.Rejections, *id.KeyChain, error) {
+
+	profile := member.GetUser_Local(ctx, govOwner.PublicClone(), signer)
+	signerPublic, err := git.TryCloneOne(ctx, git.Address(profile.PublicAddress))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	results, rejected := ProcessSignerRequestsCloned_StageOnly(ctx, govOwner, signer, signerPublic.Tree())
+	if mail.VerifySender_Local(ctx, signerPublic.Tree(), profile.ID) != nil {
+		return results, rejected, nil, nil
+	}
+	chain := id.GetKeyChain(ctx, signerPublic.Tree())
+	return results, rejected, &chain, nil
+}
+
+// isApproved verifies the signatures of a proposal against the current policy and the key chains of the signers.
+// The signatures and threshold recorded in the proposal are not trusted, since anyone with push access to the community repo can edit them.
+func isApproved(
+	ctx context.Context,
+	policy *etc.MultisigPolicy,
+	chains map[member.User]id.KeyChain,
+	p Proposal,
+
+) bool {
+
+	if !policy.IsActive() {
+		return false
+	}
+	digest := p.Digest()
+	signed := map[member.User]bool{}
+	for _, s := range p.Signatures {
+		chain, ok := chains[s.Signer]
+		if !ok || !policy.IsSigner(string(s.Signer)) {
+			continue
+		}
+		a := s.Approval
+		if a.Value.Proposal != p.ID || a.Value.Digest != digest || !a.Verify(ctx) {
+			continue
+		}
+		if chain.CheckKey(a.PublicKeyEd25519, nil) != nil {
+			continue
+		}
+		signed[s.Signer] = true
+	}
+	return len(signed) >= policy.Threshold
+}
+
+// ProcessSignerRequestsCloned_StageOnly collects the pending approvals of a signer, read from a clone of the signer's public repo.
+func ProcessSignerRequestsCloned_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	signer member.User,
+	signerPublic *git.Tree,
+
+) (Results, mail.Rejections) {
+
+	profile := member.GetUser_Local(ctx, govOwner.PublicClone(), signer)
+
+	// refuse approvals from a repo whose credentials are not the ones the signer is registered with
+	if err := mail.VerifySender_Local(ctx, signerPublic, profile.ID); err != nil {
+		base.Infof("multisig: rejecting approvals from signer %v (%v)", signer, err)
+		return Results{}, mail.RejectPending_Local(ctx, govOwner.Public.Tree(), profile.PublicAddress, signerPublic, MultisigTopic, err)
+	}
+	chain := id.GetKeyChain(ctx, signerPublic)
+
+	results := Results{}
+	var respond mail.Responder[ApproveRequest, ApproveResponse] = func(
+		ctx context.Context,
+		seqNo mail.SeqNo,
+		req ApproveRequest,
+	) (ApproveResponse, error) {
+
+		var resp ApproveResponse
+		if err := must.Try(func() { approve_StageOnly(ctx, govOwner.PublicClone(), signer, chain, req) }); err != nil {
+			base.Infof("multisig: approval by signer %v failed (%v)", signer, err)
+			resp.Error = err.Error()
+		}
+		results = append(results, Result{Signer: signer, SeqNo: seqNo, Request: req, Response: resp})
+		return resp, nil
+	}
+
+	_, rejected := mail.Respond_StageOnly[ApproveRequest, ApproveResponse](
+		ctx,
+		govOwner.IDOwnerCloned(),
+		profile.PublicAddress,
+		signerPublic,
+		MultisigTopic,
+		respond,
+	)
+	return results, rejected
+}
+
+func approve_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	signer member.User,
+	chain id.KeyChain,
+	req ApproveRequest,
+
+) {
+
+	signed := req.Approval
+	approval := signed.Value
+	p := Get_Local(ctx, cloned, approval.Proposal)
+	must.Assert(ctx, p.IsOpen(), fmt.Errorf("%w: %v", ErrProposalClosed, p.ID))
+	must.Assert(ctx, slices.Contains(p.Signers, string(signer)), fmt.Errorf("%w: %v", ErrNotSigner, signer))
+	must.Assert(ctx, signed.Verify(ctx), ErrInvalidSignature)
+	received := time.Now()
+	if err := chain.CheckKey(signed.PublicKeyEd25519, &received); err != nil {
+		must.Panic(ctx, fmt.Errorf("%w: approval is not signed by the identity of signer %v (%w)", ErrInvalidSignature, signer, err))
+	}
+	must.Assert(ctx, approval.Digest == p.Digest(), fmt.Errorf("%w: %v", ErrDigestMismatch, p.ID))
+	if p.HasSigned(signer) {
+		return
+	}
+
+	p.Signatures = append(p.Signatures, Signature{Signer: signer, Approval: signed})
+	proposalKV.Set(ctx, proposalNS, cloned.Tree(), p.ID, p)
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Payload: &ApproveTrace{ID: p.ID, Signer: signer, Signatures: len(p.Signatures), Threshold: p.Threshold},
+	})
+}
+
+func apply_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	p Proposal,
+
+) {
+
+	// the proposal carries the approval required by the operations it applies
+	ctx = etc.WithApproval(ctx)
+	cloned := govOwner.PublicClone()
+	a := p.Action
+	switch {
+	case a.Issue != nil:
+		account.Issue_StageOnly(ctx, cloned, a.Issue.To, a.Issue.Amount, a.Issue.Note)
+	case a.Transfer != nil:
+		// transfers are proposed by directives, which move members' credits subject to their controls
+		account.Spend_StageOnly(ctx, cloned, a.Transfer.From, a.Transfer.To, a.Transfer.Amount, a.Transfer.Note)
+	case a.RemoveUser != nil:
+		member.RemoveUser_StageOnly(ctx, cloned, a.RemoveUser.User)
+	case a.EraseBallot != nil:
+		ballotapi.Erase_StageOnly(ctx, govOwner.IDOwnerCloned(), a.EraseBallot.Ballot)
+	case a.FreezeMotion != nil:
+		motionapi.FreezeMotion_StageOnly(ctx, govOwner, a.FreezeMotion.Motion)
+	case a.UnfreezeMotion != nil:
+		motionapi.UnfreezeMotion_StageOnly(ctx, govOwner, a.UnfreezeMotion.Motion)
+	case a.SetPolicy != nil:
+		settings := etc.GetSettings_StageOnly(ctx, cloned)
+		settings.Multisig = a.SetPolicy
+		etc.SetSettings_StageOnly(ctx, cloned, settings)
+	default:
+		must.Panic(ctx, ErrUnrecognizedAction)
+	}
+
+	p.Applied = true
+	p.AppliedAt = time.Now()
+	p.LastError = ""
+	proposalKV.Set(ctx, proposalNS, cloned.Tree(), p.ID, p)
+
+	// the signatures are recorded with the trace entry, so the action can be audited from the trace alone
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Note:    p.Note,
+		Payload: &ApplyTrace{ID: p.ID, Op: p.Op, Action: p.Action, Threshold: p.Threshold, Signatures: p.Signatures},
+	})
+}
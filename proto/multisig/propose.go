@@ -0,0 +1,174 @@
+package multisig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Propose records an organizer action, which is applied once enough designated signers approve it.
+func Propose(
+	ctx context.Context,
+	addr gov.Address,
+	op string,
+	action Action,
+	note string,
+
+) git.Change[form.Map, Proposal] {
+
+	cloned := gov.Clone(ctx, addr)
+	chg := Propose_StageOnly(ctx, cloned, op, action, note)
+	proto.Commit(ctx, cloned.Tree(), chg)
+	cloned.Push(ctx)
+	return chg
+}
+
+func Propose_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	op string,
+	action Action,
+	note string,
+
+) git.Change[form.Map, Proposal] {
+
+	policy := etc.GetSettings_StageOnly(ctx, cloned).Multisig
+	must.Assert(ctx, policy.IsActive(), ErrNoPolicy)
+	must.NoError(ctx, action.validate())
+	if action.SetPolicy != nil {
+		must.NoError(ctx, action.SetPolicy.Validate())
+	}
+
+	p := Proposal{
+		ID:         ProposalID(id.GenerateRandomID()),
+		Op:         op,
+		Action:     action,
+		Note:       note,
+		ProposedAt: time.Now(),
+		Signers:    policy.Signers,
+		Threshold:  policy.Threshold,
+		Signatures: []Signature{},
+	}
+	proposalKV.Set(ctx, proposalNS, cloned.Tree(), p.ID, p)
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Note:    note,
+		Payload: &ProposeTrace{ID: p.ID, Op: op, Action: action, Signers: p.Signers, Threshold: p.Threshold},
+	})
+
+	return git.NewChange(
+		fmt.Sprintf("Propose %v, requiring %d of %d signatures", op, p.Threshold, len(p.Signers)),
+		"multisig_propose",
+		form.Map{"op": op, "action": action, "note": note},
+		p,
+		nil,
+	)
+}
+
+func (x Action) validate() error {
+	n := 0
+	for _, set := range []bool{
+		x.Issue != nil,
+		x.Transfer != nil,
+		x.RemoveUser != nil,
+		x.EraseBallot != nil,
+		x.FreezeMotion != nil,
+		x.UnfreezeMotion != nil,
+		x.SetPolicy != nil,
+	} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("%w: expecting exactly one action, got %d", ErrUnrecognizedAction, n)
+	}
+	return nil
+}
+
+func Get(
+	ctx context.Context,
+	addr gov.Address,
+	proposalID ProposalID,
+
+) Proposal {
+
+	return Get_Local(ctx, gov.Clone(ctx, addr), proposalID)
+}
+
+func Get_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	proposalID ProposalID,
+
+) Proposal {
+
+	p, err := must.Try1(func() Proposal { return proposalKV.Get(ctx, proposalNS, cloned.Tree(), proposalID) })
+	must.Assert(ctx, err == nil, fmt.Errorf("%w: %v", ErrProposalNotFound, proposalID))
+	return p
+}
+
+func List(
+	ctx context.Context,
+	addr gov.Address,
+
+) Proposals {
+
+	return List_Local(ctx, gov.Clone(ctx, addr))
+}
+
+// List_Local returns all proposals, oldest first.
+func List_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) Proposals {
+
+	_, ps := proposalKV.ListKeyValues(ctx, proposalNS, cloned.Tree())
+	sort.Slice(ps, func(i, j int) bool { return ps[i].ProposedAt.Before(ps[j].ProposedAt) })
+	return ps
+}
+
+// Cancel withdraws an open proposal. Cancelling does not require approval, since it applies nothing.
+func Cancel(
+	ctx context.Context,
+	addr gov.Address,
+	proposalID ProposalID,
+	note string,
+
+) {
+
+	cloned := gov.Clone(ctx, addr)
+	Cancel_StageOnly(ctx, cloned, proposalID, note)
+	proto.Commitf(ctx, cloned, "multisig_cancel", "Cancel proposal %v (%v)", proposalID, note)
+	cloned.Push(ctx)
+}
+
+func Cancel_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	proposalID ProposalID,
+	note string,
+
+) {
+
+	p := Get_Local(ctx, cloned, proposalID)
+	must.Assert(ctx, p.IsOpen(), fmt.Errorf("%w: %v", ErrProposalClosed, proposalID))
+	p.Cancelled = true
+	proposalKV.Set(ctx, proposalNS, cloned.Tree(), p.ID, p)
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Note:    note,
+		Payload: &CancelTrace{ID: p.ID},
+	})
+}
@@ -0,0 +1,158 @@
+// Package multisig implements organizer actions which apply only after a threshold of designated organizers approve them.
+package multisig
+
+import (
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/form"
+)
+
+const MultisigTopic = "multisig"
+
+// OpSetPolicy is the operation of changing an active multi-signature policy.
+const OpSetPolicy = "etc_set_multisig"
+
+var (
+	proposalNS = proto.RootNS.Append("multisig").Append("proposals")
+	proposalKV = kv.KV[ProposalID, Proposal]{}
+)
+
+type ProposalID string
+
+func (x ProposalID) String() string {
+	return string(x)
+}
+
+// Action holds exactly one organizer action.
+type Action struct {
+	Issue          *IssueAction        `json:"issue,omitempty"`
+	Transfer       *TransferAction     `json:"transfer,omitempty"`
+	RemoveUser     *RemoveUserAction   `json:"remove_user,omitempty"`
+	EraseBallot    *EraseBallotAction  `json:"erase_ballot,omitempty"`
+	FreezeMotion   *MotionAction       `json:"freeze_motion,omitempty"`
+	UnfreezeMotion *MotionAction       `json:"unfreeze_motion,omitempty"`
+	SetPolicy      *etc.MultisigPolicy `json:"set_policy,omitempty"`
+}
+
+type IssueAction struct {
+	To     account.AccountID `json:"to"`
+	Amount account.Holding   `json:"amount"`
+	Note   string            `json:"note"`
+}
+
+type TransferAction struct {
+	From   account.AccountID `json:"from"`
+	To     account.AccountID `json:"to"`
+	Amount account.Holding   `json:"amount"`
+	Note   string            `json:"note"`
+}
+
+type RemoveUserAction struct {
+	User member.User `json:"user"`
+}
+
+type EraseBallotAction struct {
+	Ballot ballotproto.BallotID `json:"ballot"`
+}
+
+type MotionAction struct {
+	Motion motionproto.MotionID `json:"motion"`
+}
+
+// Proposal is an organizer action awaiting approval.
+// The signers and threshold are fixed when the action is proposed.
+type Proposal struct {
+	ID         ProposalID  `json:"id"`
+	Op         string      `json:"op"`
+	Action     Action      `json:"action"`
+	Note       string      `json:"note"`
+	ProposedAt time.Time   `json:"proposed_at"`
+	Signers    []string    `json:"signers"`
+	Threshold  int         `json:"threshold"`
+	Signatures []Signature `json:"signatures"`
+	//
+	Applied   bool      `json:"applied"`
+	AppliedAt time.Time `json:"applied_at"`
+	Cancelled bool      `json:"cancelled"`
+	LastError string    `json:"last_error,omitempty"` // reason the last attempt to apply failed
+}
+
+func (x Proposal) IsOpen() bool {
+	return !x.Applied && !x.Cancelled
+}
+
+func (x Proposal) HasSigned(user member.User) bool {
+	for _, s := range x.Signatures {
+		if s.Signer == user {
+			return true
+		}
+	}
+	return false
+}
+
+// Digest identifies the contents of the proposal that signers approve.
+func (x Proposal) Digest() string {
+	return form.StringHashForFilename(
+		form.SprintJSON(
+			form.Map{
+				"id":          x.ID,
+				"op":          x.Op,
+				"action":      x.Action,
+				"note":        x.Note,
+				"proposed_at": x.ProposedAt,
+				"signers":     x.Signers,
+				"threshold":   x.Threshold,
+			},
+		),
+	)
+}
+
+type Proposals []Proposal
+
+// Approval is a statement by a signer that they approve the proposal with the given digest.
+type Approval struct {
+	Proposal ProposalID `json:"proposal"`
+	Digest   string     `json:"digest"`
+	Time     time.Time  `json:"time"`
+}
+
+// Signature is an approval collected from a designated signer.
+type Signature struct {
+	Signer   member.User         `json:"signer"`
+	Approval id.Signed[Approval] `json:"approval"`
+}
+
+// ApproveRequest is sent by a signer to the community by mail.
+type ApproveRequest struct {
+	Approval id.Signed[Approval] `json:"approval"`
+}
+
+type ApproveResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Result is the outcome of processing a single approval request.
+type Result struct {
+	Signer   member.User     `json:"signer"`
+	SeqNo    mail.SeqNo      `json:"seqno"`
+	Request  ApproveRequest  `json:"request"`
+	Response ApproveResponse `json:"response"`
+}
+
+type Results []Result
+
+// Report summarizes the approvals collected and the proposals applied during processing.
+type Report struct {
+	Approvals Results               `json:"approvals"`
+	Applied   []ProposalID          `json:"applied"`
+	Failed    map[ProposalID]string `json:"failed"`
+}
@@ -0,0 +1,51 @@
+package multisig
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+func init() {
+	ctx := context.Background()
+	trace.Register[ProposeTrace](ctx, 1)
+	trace.Register[CancelTrace](ctx, 1)
+	trace.Register[ApproveTrace](ctx, 1)
+	trace.Register[ApplyTrace](ctx, 1)
+}
+
+type ProposeTrace struct {
+	ID        ProposalID `json:"id"`
+	Op        string     `json:"op"`
+	Action    Action     `json:"action"`
+	Signers   []string   `json:"signers"`
+	Threshold int        `json:"threshold"`
+}
+
+func (*ProposeTrace) TraceOp() string { return "multisig_propose" }
+
+type CancelTrace struct {
+	ID ProposalID `json:"id"`
+}
+
+func (*CancelTrace) TraceOp() string { return "multisig_cancel" }
+
+type ApproveTrace struct {
+	ID         ProposalID  `json:"id"`
+	Signer     member.User `json:"signer"`
+	Signatures int         `json:"signatures"`
+	Threshold  int         `json:"threshold"`
+}
+
+func (*ApproveTrace) TraceOp() string { return "multisig_approve" }
+
+type ApplyTrace struct {
+	ID         ProposalID  `json:"id"`
+	Op         string      `json:"op"`
+	Action     Action      `json:"action"`
+	Threshold  int         `json:"threshold"`
+	Signatures []Signature `json:"signatures"`
+}
+
+func (*ApplyTrace) TraceOp() string { return "multisig_apply" }
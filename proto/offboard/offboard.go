@@ -0,0 +1,167 @@
+// Package offboard removes users from the community, settling their open commitments.
+package offboard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Options select which of the user's commitments are settled before the user is removed.
+type Options struct {
+	// WithdrawVotes withdraws the user's votes from open ballots and refunds their charges.
+	WithdrawVotes bool `json:"withdraw_votes"`
+	// CancelMotions cancels the open motions authored by the user.
+	CancelMotions bool `json:"cancel_motions"`
+	// ReassignMotionsTo, if set, makes the given user the author of the user's open motions.
+	// It cannot be combined with CancelMotions.
+	ReassignMotionsTo member.User `json:"reassign_motions_to,omitempty"`
+	// SweepBalance disposes of the user's balance according to the community's offboard sweep policy.
+	SweepBalance bool `json:"sweep_balance"`
+}
+
+// Report summarizes what happened when a user was offboarded.
+type Report struct {
+	User              member.User                              `json:"user"`
+	Options           Options                                  `json:"options"`
+	WithdrawnVotes    map[ballotproto.BallotID]account.Holding `json:"withdrawn_votes"` // ballot -> refund
+	CancelledMotions  motionproto.MotionIDs                    `json:"cancelled_motions"`
+	ReassignedMotions motionproto.MotionIDs                    `json:"reassigned_motions"`
+	SweepPolicy       etc.SweepPolicy                          `json:"sweep_policy,omitempty"`
+	Swept             account.Holding                          `json:"swept"`
+	SweptTo           account.AccountID                        `json:"swept_to,omitempty"`
+}
+
+func Offboard(
+	ctx context.Context,
+	addr gov.OwnerAddress,
+	user member.User,
+	opts Options,
+
+) Report {
+
+	cloned := gov.CloneOwner(ctx, addr)
+	chg := Offboard_StageOnly(ctx, cloned, user, opts)
+	proto.Commit(ctx, cloned.Public.Tree(), chg)
+	cloned.Public.Push(ctx)
+	return chg.Result
+}
+
+func Offboard_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	user member.User,
+	opts Options,
+
+) git.Change[form.Map, Report] {
+
+	pub := cloned.PublicClone()
+	// checked before any votes are withdrawn, although removing the user checks it as well
+	must.NoError(ctx, etc.CheckUnilateral_Local(ctx, pub, etc.OpMemberRemoveUser))
+	must.Assertf(ctx, member.IsUser_Local(ctx, pub, user), "user %v is not in the community", user)
+	must.Assertf(ctx, !(opts.CancelMotions && !opts.ReassignMotionsTo.IsNone()), "motions can either be cancelled or reassigned, not both")
+	if !opts.ReassignMotionsTo.IsNone() {
+		must.Assertf(ctx, opts.ReassignMotionsTo != user, "cannot reassign motions to the offboarded user")
+		must.Assertf(ctx, member.IsUser_Local(ctx, pub, opts.ReassignMotionsTo), "user %v is not in the community", opts.ReassignMotionsTo)
+	}
+
+	report := Report{
+		User:              user,
+		Options:           opts,
+		WithdrawnVotes:    map[ballotproto.BallotID]account.Holding{},
+		CancelledMotions:  motionproto.MotionIDs{},
+		ReassignedMotions: motionproto.MotionIDs{},
+	}
+
+	// settle authored motions first, since cancelling a motion cancels its ballots and refunds all voters
+	for _, m := range motionapi.ListMotions_Local(ctx, pub.Tree()) {
+		if m.Closed || m.Author != user {
+			continue
+		}
+		switch {
+		case opts.CancelMotions:
+			motionapi.CancelMotion_StageOnly(ctx, cloned, m.ID)
+			report.CancelledMotions = append(report.CancelledMotions, m.ID)
+		case !opts.ReassignMotionsTo.IsNone():
+			motionapi.EditMotionMeta_StageOnly(ctx, cloned, m.ID, opts.ReassignMotionsTo, m.Title, m.Body, m.TrackerURL, m.Labels)
+			report.ReassignedMotions = append(report.ReassignedMotions, m.ID)
+		}
+	}
+
+	// withdraw votes from the remaining open ballots
+	if opts.WithdrawVotes {
+		for _, ad := range ballotapi.ListFilter_Local(ctx, pub, true, false, false, "") {
+			refund := ballotapi.WithdrawVotes_StageOnly(
+				ctx,
+				pub,
+				ad.ID,
+				user,
+				fmt.Sprintf("voter %v was offboarded", user),
+			)
+			if refund.Quantity != 0 {
+				report.WithdrawnVotes[ad.ID] = refund
+			}
+		}
+	}
+
+	// sweep the balance
+	if opts.SweepBalance {
+		report.SweepPolicy = etc.GetSettings_StageOnly(ctx, pub).OffboardSweep.OrDefault()
+		report.Swept, report.SweptTo = sweep_StageOnly(ctx, pub, user, report.SweepPolicy)
+	}
+
+	member.RemoveUser_StageOnly(ctx, pub, user)
+
+	// log
+	trace.Log_StageOnly(ctx, pub, &trace.Event{
+		Payload: &OffboardTrace{User: user, Options: opts, Report: report},
+	})
+
+	return git.NewChange(
+		fmt.Sprintf("Offboard user %v", user),
+		"member_offboard",
+		form.Map{"user": user},
+		report,
+		nil,
+	)
+}
+
+func sweep_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	user member.User,
+	policy etc.SweepPolicy,
+
+) (account.Holding, account.AccountID) {
+
+	userAccountID := member.UserAccountID(user)
+	balance := account.Get_Local(ctx, cloned, userAccountID).Balance(account.PluralAsset)
+	if balance.Quantity <= 0 {
+		return account.H(account.PluralAsset, 0), ""
+	}
+
+	note := fmt.Sprintf("sweep balance of offboarded user %v", user)
+	switch policy {
+	case etc.SweepBurn:
+		account.Burn_StageOnly(ctx, cloned, userAccountID, balance, note)
+		return balance, account.BurnAccountID
+	case etc.SweepIssuer:
+		// the issuance pool runs an overdraft, so depositing into it uses overdraft semantics
+		account.TransferOverDraft_StageOnly(ctx, cloned, userAccountID, account.IssueAccountID, balance, note)
+		return balance, account.IssueAccountID
+	}
+	return account.H(account.PluralAsset, 0), ""
+}
@@ -0,0 +1,20 @@
+package offboard
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+func init() {
+	trace.Register[OffboardTrace](context.Background(), 1)
+}
+
+type OffboardTrace struct {
+	User    member.User `json:"user"`
+	Options Options     `json:"options"`
+	Report  Report      `json:"report"`
+}
+
+func (*OffboardTrace) TraceOp() string { return "member_offboard" }
@@ -0,0 +1,288 @@
+// Package reconcile finds accounts whose owning ballot or motion no longer exists or has been closed,
+// and sweeps their remaining balances.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+)
+
+type OwnerKind string
+
+const (
+	OwnerBallot OwnerKind = "ballot"
+	OwnerMotion OwnerKind = "motion"
+)
+
+type Sweep struct {
+	To     account.AccountID `json:"to"`
+	Amount account.Holding   `json:"amount"`
+}
+
+type Orphan struct {
+	Account account.AccountID     `json:"account"`
+	Kind    OwnerKind             `json:"owner_kind"`
+	Owner   string                `json:"owner"`
+	Reason  string                `json:"reason"`
+	Assets  account.AssetHoldings `json:"assets"`
+	Sweeps  []Sweep               `json:"sweeps"`
+}
+
+type Orphans []Orphan
+
+func (x Orphans) Sort() {
+	sort.Slice(x, func(i, j int) bool { return x[i].Account < x[j].Account })
+}
+
+type Report struct {
+	Applied bool    `json:"applied"`
+	Refund  bool    `json:"refund"`
+	Orphans Orphans `json:"orphans"`
+}
+
+// Reconcile finds orphaned accounts with non-zero balances.
+// If apply is set, balances are swept to the treasury.
+// If refund is also set, balances of accounts with recorded charges are returned to the payers instead.
+func Reconcile(
+	ctx context.Context,
+	addr gov.Address,
+	apply bool,
+	refund bool,
+
+) git.Change[form.Map, Report] {
+
+	cloned := gov.Clone(ctx, addr)
+	chg := Reconcile_StageOnly(ctx, cloned, apply, refund)
+	if apply && len(chg.Result.Orphans) > 0 {
+		proto.Commit(ctx, cloned.Tree(), chg)
+		cloned.Push(ctx)
+	}
+	return chg
+}
+
+func Reconcile_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	apply bool,
+	refund bool,
+
+) git.Change[form.Map, Report] {
+
+	orphans := FindOrphans_Local(ctx, cloned, refund)
+	if apply {
+		for _, o := range orphans {
+			sweep_StageOnly(ctx, cloned, o)
+		}
+	}
+
+	return git.NewChange(
+		fmt.Sprintf("Reconciled %d orphaned accounts", len(orphans)),
+		"account_reconcile",
+		form.Map{"apply": apply, "refund": refund},
+		Report{Applied: apply, Refund: refund, Orphans: orphans},
+		nil,
+	)
+}
+
+// FindOrphans_Local returns the orphaned accounts with non-zero balances, together with planned sweeps.
+func FindOrphans_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	refund bool,
+
+) Orphans {
+
+	orphans := Orphans{}
+	for _, id := range account.List_Local(ctx, cloned) {
+		a := account.Get_Local(ctx, cloned, id)
+		if isEmpty(a.Assets) {
+			continue
+		}
+		var o *Orphan
+		if ballotID, ok := ballotproto.EscrowAccountBallot(id); ok {
+			o = checkBallotEscrow_Local(ctx, cloned, a, ballotID, refund)
+		} else if motionID, ok := motionproto.AccountMotion(id); ok {
+			o = checkMotionAccount_Local(ctx, cloned, a, motionID)
+		}
+		if o != nil {
+			orphans = append(orphans, *o)
+		}
+	}
+	orphans.Sort()
+	return orphans
+}
+
+func checkBallotEscrow_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	a *account.Account,
+	ballotID ballotproto.BallotID,
+	refund bool,
+
+) *Orphan {
+
+	o := &Orphan{Account: a.ID, Kind: OwnerBallot, Owner: ballotID.String(), Assets: a.Assets}
+
+	if !ballotproto.BallotKV.Contains(ctx, ballotproto.BallotNS, cloned.Tree(), ballotID) {
+		o.Reason = "ballot does not exist"
+		o.Sweeps = sweepToTreasury(a.Assets)
+		return o
+	}
+
+	ad, err := git.TryFromFile[ballotproto.Ad](ctx, cloned.Tree(), ballotID.AdNS())
+	if err != nil {
+		o.Reason = fmt.Sprintf("ballot advertisement cannot be read (%v)", err)
+		o.Sweeps = sweepToTreasury(a.Assets)
+		return o
+	}
+	if !ad.Closed {
+		return nil
+	}
+
+	o.Reason = "ballot is closed"
+	o.Sweeps = sweepToTreasury(a.Assets)
+	if refund {
+		if tally, err := git.TryFromFile[ballotproto.Tally](ctx, cloned.Tree(), ballotID.TallyNS()); err == nil {
+			if refunds := refundCharges(a.Assets, tally.Charges); refunds != nil {
+				o.Sweeps = refunds
+			}
+		}
+	}
+	return o
+}
+
+func checkMotionAccount_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	a *account.Account,
+	motionID motionproto.MotionID,
+
+) *Orphan {
+
+	o := &Orphan{Account: a.ID, Kind: OwnerMotion, Owner: motionID.String(), Assets: a.Assets, Sweeps: sweepToTreasury(a.Assets)}
+
+	if !motionproto.MotionKV.Contains(ctx, motionproto.MotionNS, cloned.Tree(), motionID) {
+		o.Reason = "motion does not exist"
+		return o
+	}
+	m := motionproto.MotionKV.Get(ctx, motionproto.MotionNS, cloned.Tree(), motionID)
+
+	// closed motions are paid out when they are cleared, right before they are archived
+	switch {
+	case m.Closed && m.Cancelled:
+		o.Reason = "motion is cancelled"
+		return o
+	case m.Closed && m.Archived:
+		o.Reason = "motion is archived"
+		return o
+	}
+	return nil
+}
+
+func sweepToTreasury(assets account.AssetHoldings) []Sweep {
+	sweeps := []Sweep{}
+	for _, h := range sortedHoldings(assets) {
+		sweeps = append(sweeps, Sweep{To: account.TreasuryAccountID, Amount: h})
+	}
+	return sweeps
+}
+
+// refundCharges splits the plural balance among the users who were charged, in proportion to their charges.
+// Other assets are swept to the treasury.
+func refundCharges(assets account.AssetHoldings, charges map[member.User]float64) []Sweep {
+
+	users := []member.User{}
+	total := 0.0
+	for u, c := range charges {
+		if c > 0 {
+			users = append(users, u)
+			total += c
+		}
+	}
+	if total <= 0 {
+		return nil
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i] < users[j] })
+
+	sweeps := []Sweep{}
+	for _, h := range sortedHoldings(assets) {
+		if h.Asset != account.PluralAsset {
+			sweeps = append(sweeps, Sweep{To: account.TreasuryAccountID, Amount: h})
+			continue
+		}
+		left := h.Quantity
+		for i, u := range users {
+			share := h.Quantity * charges[u] / total
+			if i == len(users)-1 {
+				share = left // avoid rounding errors
+			}
+			left -= share
+			sweeps = append(sweeps, Sweep{To: member.UserAccountID(u), Amount: account.H(h.Asset, share)})
+		}
+	}
+	return sweeps
+}
+
+func sweep_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	o Orphan,
+
+) {
+
+	if !account.Exists_Local(ctx, cloned, account.TreasuryAccountID) {
+		account.Create_StageOnly(ctx, cloned, account.TreasuryAccountID, account.NobodyAccountID, "treasury account")
+	}
+
+	for _, s := range o.Sweeps {
+		to := s.To
+		if !account.Exists_Local(ctx, cloned, to) {
+			// the payer is no longer a member
+			to = account.TreasuryAccountID
+		}
+		account.Transfer_StageOnly(
+			ctx,
+			cloned,
+			o.Account,
+			to,
+			s.Amount,
+			fmt.Sprintf("reconcile orphaned account of %v %v (%v)", o.Kind, o.Owner, o.Reason),
+		)
+	}
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Note:    o.Reason,
+		Payload: &ReconcileTrace{Account: o.Account, Kind: o.Kind, Owner: o.Owner, Sweeps: o.Sweeps},
+	})
+}
+
+func isEmpty(assets account.AssetHoldings) bool {
+	for _, h := range assets {
+		if h.Quantity != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedHoldings(assets account.AssetHoldings) []account.Holding {
+	hs := []account.Holding{}
+	for _, h := range assets {
+		if h.Quantity > 0 {
+			hs = append(hs, h)
+		}
+	}
+	sort.Slice(hs, func(i, j int) bool { return hs[i].Asset < hs[j].Asset })
+	return hs
+}
@@ -0,0 +1,21 @@
+package reconcile
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+)
+
+func init() {
+	trace.Register[ReconcileTrace](context.Background(), 1)
+}
+
+type ReconcileTrace struct {
+	Account account.AccountID `json:"account"`
+	Kind    OwnerKind         `json:"owner_kind"`
+	Owner   string            `json:"owner"`
+	Sweeps  []Sweep           `json:"sweeps"`
+}
+
+func (*ReconcileTrace) TraceOp() string { return "account_reconcile" }
@@ -0,0 +1,58 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+)
+
+// CompactMail compacts the community's mailboxes with each of its users, keeping the retain most recent messages of each mailbox.
+func CompactMail(
+	ctx context.Context,
+	govAddr gov.OwnerAddress,
+	retain int,
+) git.Change[form.Map, map[member.User]mail.Compactions] {
+
+	govOwner := gov.CloneOwner(ctx, govAddr)
+	chg := CompactMail_StageOnly(ctx, govOwner, retain)
+	if len(chg.Result) > 0 {
+		proto.Commit(ctx, govOwner.Public.Tree(), chg)
+		govOwner.Public.Push(ctx)
+	}
+	return chg
+}
+
+func CompactMail_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	retain int,
+) git.Change[form.Map, map[member.User]mail.Compactions] {
+
+	compacted := map[member.User]mail.Compactions{}
+	for _, user := range member.ListGroupUsers_Local(ctx, govOwner.PublicClone(), member.Everybody) {
+		profile := member.GetUser_Local(ctx, govOwner.PublicClone(), user)
+		userPublic, err := git.TryCloneOne(ctx, git.Address(profile.PublicAddress))
+		if err != nil {
+			base.Infof("compacting mail with user %v (%v)", user, err)
+			continue
+		}
+		if c := mail.Compact_StageOnly(ctx, govOwner.IDOwnerCloned(), userPublic.Tree(), retain); len(c.Result) > 0 {
+			compacted[user] = c.Result
+		}
+	}
+
+	return git.NewChange(
+		fmt.Sprintf("Compacted mailboxes with %d users", len(compacted)),
+		"sync_compact_mail",
+		form.Map{"retain": retain},
+		compacted,
+		nil,
+	)
+}
@@ -0,0 +1,99 @@
+package sync
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/multisig"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// ListMail lists the mailboxes of the community, with the number of messages not yet received by either side.
+func ListMail(
+	ctx context.Context,
+	govAddr gov.Address,
+) mail.Boxes {
+
+	cloned := gov.Clone(ctx, govAddr)
+	peers := []*git.Tree{}
+	for _, user := range member.ListGroupUsers_Local(ctx, cloned, member.Everybody) {
+		profile := member.GetUser_Local(ctx, cloned, user)
+		userPublic, err := git.TryCloneOne(ctx, git.Address(profile.PublicAddress))
+		if err != nil {
+			base.Infof("listing mail with user %v (%v)", user, err)
+			continue
+		}
+		peers = append(peers, userPublic.Tree())
+	}
+	return mail.ListBoxes_Local(ctx, cloned.Tree(), peers...)
+}
+
+// ShowMail returns a message from a mailbox of the community with a user.
+func ShowMail(
+	ctx context.Context,
+	govAddr gov.Address,
+	user member.User,
+	kind mail.BoxKind,
+	topic string,
+	seqNo mail.SeqNo,
+) mail.MessageView {
+
+	cloned := gov.Clone(ctx, govAddr)
+	profile := member.GetUser_Local(ctx, cloned, user)
+	return mail.ShowMessage_Local(ctx, cloned.Tree(), git.CloneOne(ctx, git.Address(profile.PublicAddress)).Tree(), kind, topic, seqNo)
+}
+
+// ReplayReport is the outcome of replaying a range of messages sent by a user to the community.
+type ReplayReport struct {
+	User     member.User     `json:"user"`
+	Topic    string          `json:"topic"`
+	From     mail.SeqNo      `json:"from"`
+	To       mail.SeqNo      `json:"to"`
+	Result   any             `json:"result"`
+	Rejected mail.Rejections `json:"rejected"`
+}
+
+// Replay re-runs the community's receiver for topic over the messages sent by user with sequence numbers in [from, to).
+// The replay is applied to fresh clones of the community and user repos, which are neither committed nor pushed.
+// Bureau, multisig and ballot topics can be replayed.
+func Replay(
+	ctx context.Context,
+	govAddr gov.OwnerAddress,
+	user member.User,
+	topic string,
+	from mail.SeqNo,
+	to mail.SeqNo,
+) ReplayReport {
+
+	govOwner := gov.CloneOwner(ctx, govAddr)
+	profile := member.GetUser_Local(ctx, govOwner.PublicClone(), user)
+	userCloned := git.CloneOne(ctx, git.Address(profile.PublicAddress))
+	mail.Rewind_StageOnly(ctx, govOwner.Public.Tree(), userCloned.Tree(), topic, from, to)
+
+	report := ReplayReport{User: user, Topic: topic, From: from, To: to}
+	switch {
+	case topic == bureau.BureauTopic:
+		processed, rejected := bureau.ProcessUserRequestsCloned_StageOnly(ctx, govOwner, user, profile, userCloned.Tree())
+		report.Result, report.Rejected = processed.Result, rejected
+	case topic == multisig.MultisigTopic:
+		report.Result, report.Rejected = multisig.ProcessSignerRequestsCloned_StageOnly(ctx, govOwner, user, userCloned.Tree())
+	case strings.HasPrefix(topic, ballotproto.BallotTopic("")):
+		ballotID := ballotproto.ParseBallotID(strings.TrimPrefix(topic, ballotproto.BallotTopic("")))
+		fetched, rejected := ballotapi.FetchVotes_StageOnly(ctx, govOwner, ballotID, user, profile, userCloned)
+		report.Result, report.Rejected = fetched.Result, rejected
+	default:
+		must.Errorf(ctx, "replaying topic %q is not supported", topic)
+	}
+	if report.Rejected == nil {
+		report.Rejected = mail.Rejections{}
+	}
+	return report
+}
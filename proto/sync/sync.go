@@ -6,7 +6,10 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
 	"github.com/gov4git/gov4git/v2/proto/bureau"
 	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/invite"
+	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/multisig"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
 )
@@ -18,19 +21,32 @@ func Sync(
 ) git.Change[form.Map, form.Map] {
 
 	// collect votes and tally all open ballots
-	tallyChg := ballotapi.TallyAll(ctx, govAddr, maxPar)
+	tallyChg, tallyRejected := ballotapi.TallyAll(ctx, govAddr, maxPar)
 
 	// process bureau requests by users
-	bureauChg := bureau.Process(ctx, govAddr, member.Everybody)
+	bureauChg, bureauRejected := bureau.Process(ctx, govAddr, member.Everybody)
+
+	// add invitees who redeemed their invitations
+	inviteChg, inviteRejected := invite.Process(ctx, govAddr)
+
+	// collect approvals of organizer proposals and apply the approved ones
+	multisigChg, multisigRejected := multisig.Process(ctx, govAddr)
+
+	// messages which could not be attributed to their senders
+	rejected := append(append(append(append(mail.Rejections{}, tallyRejected...), bureauRejected...), inviteRejected...), multisigRejected...)
 
 	return git.NewChange(
 		"Governance-community sync",
 		"sync_sync",
 		form.Map{},
 		form.Map{
-			"tally_result":  tallyChg.Result,
-			"bureau_result": bureauChg.Result,
+			"tally_result":      tallyChg.Result,
+			"bureau_result":     bureauChg.Result,
+			"invite_result":     inviteChg.Result,
+			"multisig_result":   multisigChg.Result,
+			"rejected_messages": len(rejected),
+			"rejected":          rejected,
 		},
-		form.Forms{tallyChg, bureauChg},
+		form.Forms{tallyChg, bureauChg, inviteChg, multisigChg},
 	)
 }
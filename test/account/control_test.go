@@ -0,0 +1,119 @@
+package account
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestFreeze(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 3.0), "test")
+
+	// user 0 requests a transfer, which is frozen before it is processed
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	account.Freeze(ctx, cty.Gov(), cty.MemberAccountID(0), "test")
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	if u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; u1 != 0.0 {
+		t.Errorf("expecting 0, got %v", u1)
+	}
+
+	// system movements, such as refunds, are not blocked by the freeze
+	account.Transfer(ctx, cty.Gov(), cty.MemberAccountID(0), cty.MemberAccountID(1), account.H(account.PluralAsset, 1.0), "refund")
+	account.Transfer(ctx, cty.Gov(), cty.MemberAccountID(1), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "refund")
+
+	// new requests from a frozen account are refused
+	err := must.Try(func() {
+		bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	})
+	if !errors.Is(err, account.ErrAccountFrozen) {
+		t.Errorf("expecting frozen account error, got %v", err)
+	}
+
+	// unfreeze and transfer
+	account.Unfreeze(ctx, cty.Gov(), cty.MemberAccountID(0), "test")
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	if u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; u1 != 1.0 {
+		t.Errorf("expecting 1, got %v", u1)
+	}
+
+	// audit trail
+	if n := len(account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Audit); n != 2 {
+		t.Errorf("expecting 2 audit records, got %v", n)
+	}
+}
+
+func TestSpendingLimit(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	account.SetSpendingLimit(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 3.0), time.Hour, "test")
+
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 2.0)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	err := must.Try(func() {
+		bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 2.0)
+	})
+	if !errors.Is(err, account.ErrSpendingLimitExceeded) {
+		t.Errorf("expecting spending limit error, got %v", err)
+	}
+
+	// transfers by the organizer are not spending by the user
+	account.Transfer(ctx, cty.Gov(), cty.MemberAccountID(0), cty.MemberAccountID(1), account.H(account.PluralAsset, 2.0), "test")
+
+	account.RemoveSpendingLimit(ctx, cty.Gov(), cty.MemberAccountID(0), account.PluralAsset, "test")
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 2.0)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	if u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; u1 != 6.0 {
+		t.Errorf("expecting 6, got %v", u1)
+	}
+}
+
+func TestFrozenVoter(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 1)
+
+	ballotName := ballotproto.ParseBallotID("frozen")
+	choices := []string{"x"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "ballot_id", "ballot description", choices, member.Everybody)
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+	account.Freeze(ctx, cty.Gov(), cty.MemberAccountID(0), "test")
+
+	elections := ballotproto.Elections{ballotproto.NewElection(choices[0], 1.0)}
+	err := must.Try(func() { ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, elections) })
+	if !errors.Is(err, account.ErrAccountFrozen) {
+		t.Errorf("expecting frozen account error, got %v", err)
+	}
+
+	// the ballot policy refuses the votes as well
+	cloned := gov.Clone(ctx, cty.Gov())
+	ast := ballotapi.Show_Local(ctx, cloned, ballotName)
+	_, policy := ballotio.LoadAdPolicy_Local(ctx, cloned.Tree(), ballotName)
+	err = must.Try(func() {
+		policy.VerifyElections(ctx, cty.MemberOwner(0), cty.Gov(), id.CloneOwner(ctx, cty.MemberOwner(0)), cloned, &ast.Ad, &ast.Tally, elections)
+	})
+	if !errors.Is(err, account.ErrAccountFrozen) {
+		t.Errorf("expecting frozen account error, got %v", err)
+	}
+}
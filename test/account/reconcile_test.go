@@ -0,0 +1,77 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/proto/reconcile"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+const testMaxPar = 2
+
+func TestReconcileErasedBallot(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "title", "description", choices, member.Everybody)
+
+	// vote and tally, charging the voter into escrow
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 4.0), "test")
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[0], 4.0)})
+	ballotapi.Tally(ctx, cty.Organizer(), ballotName, testMaxPar)
+
+	// erasing the ballot leaves the escrow behind
+	ballotapi.Erase(ctx, cty.Organizer(), ballotName)
+
+	report := reconcile.Reconcile(ctx, cty.Gov(), false, false).Result
+	if len(report.Orphans) != 1 || report.Orphans[0].Account != ballotproto.BallotEscrowAccountID(ballotName) {
+		t.Fatalf("expecting the ballot escrow to be orphaned, got %v", report.Orphans)
+	}
+
+	reconcile.Reconcile(ctx, cty.Gov(), true, false)
+
+	if q := account.Get(ctx, cty.Gov(), account.TreasuryAccountID).Balance(account.PluralAsset).Quantity; q != 4.0 {
+		t.Errorf("expecting 4, got %v", q)
+	}
+	if n := len(reconcile.Reconcile(ctx, cty.Gov(), false, false).Result.Orphans); n != 0 {
+		t.Errorf("expecting no orphans, got %v", n)
+	}
+}
+
+func TestReconcileRefundClosedBallot(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "title", "description", choices, member.Everybody)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(1), account.H(account.PluralAsset, 3.0), "test")
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[0], 1.0)})
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[1], 3.0)})
+	ballotapi.Tally(ctx, cty.Organizer(), ballotName, testMaxPar)
+	ballotapi.Close(ctx, cty.Organizer(), ballotName, account.BurnAccountID)
+
+	// funds arrive in the escrow after the ballot closed
+	account.Issue(ctx, cty.Gov(), ballotproto.BallotEscrowAccountID(ballotName), account.H(account.PluralAsset, 8.0), "test")
+
+	reconcile.Reconcile(ctx, cty.Gov(), true, true)
+
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; q != 2.0 {
+		t.Errorf("expecting 2, got %v", q)
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; q != 6.0 {
+		t.Errorf("expecting 6, got %v", q)
+	}
+}
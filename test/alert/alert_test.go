@@ -0,0 +1,120 @@
+package alert
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/alert"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/metrics"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+const testMaxPar = 3
+
+func TestAlert(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(1), account.H(account.PluralAsset, 95.0), "test")
+
+	// alerts are disabled by default
+	if chg := alert.Check(ctx, cty.Gov()); len(chg.Result) != 0 {
+		t.Fatalf("expecting no alerts, got %v", chg.Result)
+	}
+
+	etc.SetSettings(ctx, cty.Gov(), etc.Settings{
+		Alerts: &etc.AlertPolicy{VoteChargePercent: 50, DailyIssuance: 100, RejectedVotes: 1},
+	})
+
+	// member 0 spends most of their balance on one ballot
+	choices := []string{"x", "y"}
+	spent := ballotproto.ParseBallotID("spent")
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), spent, account.NobodyAccountID, purpose.Unspecified, "", "spent", "", choices, member.Everybody)
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), spent, ballotproto.Elections{ballotproto.NewElection(choices[0], 8.0)})
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), spent, ballotproto.Elections{ballotproto.NewElection(choices[1], 1.0)})
+	ballotapi.Tally(ctx, cty.Organizer(), spent, testMaxPar)
+
+	// member 1's votes are rejected, because the ballot is frozen
+	frozen := ballotproto.ParseBallotID("frozen")
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), frozen, account.NobodyAccountID, purpose.Unspecified, "", "frozen", "", choices, member.Everybody)
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), frozen, ballotproto.Elections{
+		ballotproto.NewElection(choices[0], 1.0),
+		ballotproto.NewElection(choices[1], 2.0),
+	})
+	ballotapi.Freeze(ctx, cty.Organizer(), frozen)
+	ballotapi.Tally(ctx, cty.Organizer(), frozen, testMaxPar)
+
+	alerts := alert.Check(ctx, cty.Gov()).Result
+	byRule := map[alert.RuleName]*alert.Alert{}
+	for _, a := range alerts {
+		byRule[a.Rule] = a
+	}
+	if len(alerts) != 3 {
+		t.Fatalf("expecting 3 alerts, got %v", alerts)
+	}
+	if a := byRule[alert.RuleVoteCharge]; a == nil || a.User != cty.MemberUser(0) || a.Value != 80 {
+		t.Errorf("expecting vote charge alert for %v, got %v", cty.MemberUser(0), a)
+	}
+	if a := byRule[alert.RuleDailyIssuance]; a == nil || a.Value != 105 {
+		t.Errorf("expecting daily issuance alert, got %v", a)
+	}
+	if a := byRule[alert.RuleRejectedVotes]; a == nil || a.Ballot != frozen || a.Value != 2 {
+		t.Errorf("expecting rejected votes alert for %v, got %v", frozen, a)
+	}
+
+	// activity is checked only once, and a day's issuance raises only one alert
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+	if chg := alert.Check(ctx, cty.Gov()); len(chg.Result) != 0 {
+		t.Errorf("expecting no new alerts, got %v", chg.Result)
+	}
+
+	if logged := alert.List(ctx, cty.Gov(), journal.All); len(logged) != 3 {
+		t.Errorf("expecting 3 logged alerts, got %v", logged)
+	}
+
+	// alerts are shown on the dashboard
+	report := metrics.AssembleReport(ctx, cty.Gov(), func(path string) string { return path }, metrics.TimeDailyLowerBound, metrics.Today().AddDate(0, 0, 1))
+	if len(report.Alerts) != 3 || !strings.Contains(report.ReportMD, "## Alerts") || !strings.Contains(report.ReportMD, byRule[alert.RuleVoteCharge].Message) {
+		t.Errorf("expecting alerts in report, got %v", report.ReportMD)
+	}
+}
+
+func TestAlertVoteChargeAfterOverDraft(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 1)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	etc.SetSettings(ctx, cty.Gov(), etc.Settings{
+		Alerts: &etc.AlertPolicy{VoteChargePercent: 50},
+	})
+
+	// member 0 spends 40% of their balance on a vote
+	choices := []string{"x", "y"}
+	ballotID := ballotproto.ParseBallotID("b")
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotID, account.NobodyAccountID, purpose.Unspecified, "", "b", "", choices, member.Everybody)
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotID, ballotproto.Elections{ballotproto.NewElection(choices[0], 4.0)})
+	ballotapi.Tally(ctx, cty.Organizer(), ballotID, testMaxPar)
+
+	// afterwards, an overdraft transfer takes their balance below zero
+	cloned := gov.Clone(ctx, cty.Gov())
+	account.TransferOverDraft_StageOnly(ctx, cloned, cty.MemberAccountID(0), account.IssueAccountID, account.H(account.PluralAsset, 9.0), "test")
+	proto.Commitf(ctx, cloned, "test", "overdraft")
+	cloned.Push(ctx)
+
+	if chg := alert.Check(ctx, cty.Gov()); len(chg.Result) != 0 {
+		t.Errorf("expecting no alerts, got %v", chg.Result)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/proto/purpose"
 	"github.com/gov4git/gov4git/v2/runtime"
@@ -54,6 +55,17 @@ func TestOpenClose(t *testing.T) {
 		t.Errorf("expecting %v vote, got %v", 1.0, tallyChg.Result.Scores[choices[0]])
 	}
 
+	// the tally trace records the changed scores only
+	var tallyTrace *ballotapi.TallyTrace
+	for _, e := range trace.List_Local(ctx, gov.Clone(ctx, cty.Gov())) {
+		if x, ok := e.Payload.Payload.(*ballotapi.TallyTrace); ok && x.Ballot == ballotName {
+			tallyTrace = x
+		}
+	}
+	if tallyTrace == nil || len(tallyTrace.Voters) != 1 || tallyTrace.ScoreDeltas[cty.MemberUser(0)][choices[0]] != 1.0 {
+		t.Errorf("unexpected tally trace %v", form.SprintJSON(tallyTrace))
+	}
+
 	// close
 	ballotapi.Close(ctx, cty.Organizer(), ballotName, account.BurnAccountID)
 
@@ -150,7 +162,7 @@ func TestTallyAll(t *testing.T) {
 	fmt.Println("vote 1: ", form.SprintJSON(voteChg1))
 
 	// tally
-	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+	tallyChg, _ := ballotapi.TallyAll(ctx, cty.Organizer(), 2)
 	fmt.Println("tally: ", form.SprintJSON(tallyChg))
 
 	// verify tallies are correct
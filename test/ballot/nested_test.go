@@ -0,0 +1,61 @@
+package ballot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestNestedParticipants(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// member 0 participates through the core group, nested in contributors
+	contributors, core := member.Group("contributors"), member.Group("core")
+	member.AddGroup(ctx, cty.Gov(), contributors)
+	member.AddGroup(ctx, cty.Gov(), core)
+	member.AddSubgroup(ctx, cty.Gov(), contributors, core)
+	member.AddMember(ctx, cty.Gov(), cty.MemberUser(0), core)
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y", "z"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "ballot_id", "ballot description", choices, contributors)
+
+	// the ballot is listed for the member of the nested group
+	if ads := ballotapi.ListFilter(ctx, cty.Gov(), false, false, false, cty.MemberUser(0)); len(ads) != 1 {
+		t.Fatalf("expecting 1 ballot, got %v", len(ads))
+	}
+
+	for i := 0; i < 2; i++ {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(i), account.H(account.PluralAsset, 1.0), "test")
+	}
+	elections := ballotproto.Elections{ballotproto.NewElection(choices[0], 1.0)}
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, elections)
+
+	tallyChg := ballotapi.Tally(ctx, cty.Organizer(), ballotName, testMaxPar)
+	if tallyChg.Result.Scores[choices[0]] != 1.0 {
+		t.Fatalf("expecting %v vote, got %v", 1.0, tallyChg.Result.Scores[choices[0]])
+	}
+
+	// votes from non-participants are rejected
+	cloned := gov.Clone(ctx, cty.Gov())
+	fetched := ballotapi.FetchedVotes{{Voter: cty.MemberUser(1), Elections: elections}}
+	chg, _ := ballotapi.TallyFetchedVotes_StageOnly(ctx, cloned, ballotName, fetched)
+	rejected := chg.Result.RejectedVotes[cty.MemberUser(1)]
+	if len(rejected) != 1 || !strings.Contains(rejected[0].Reason, "not a member") {
+		t.Errorf("expecting rejected vote, got %v", rejected)
+	}
+	if chg.Result.Scores[choices[0]] != 1.0 {
+		t.Errorf("expecting %v vote, got %v", 1.0, chg.Result.Scores[choices[0]])
+	}
+}
@@ -1,13 +1,23 @@
 package bureau
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
+	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/runtime"
 	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
 	"github.com/gov4git/lib4git/testutil"
 )
 
@@ -35,3 +45,188 @@ func TestBureau(t *testing.T) {
 		t.Errorf("expecting 1, got %v", u1)
 	}
 }
+
+func TestBureauJoinLeaveGroup(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	open, closed := member.Group("open"), member.Group("closed")
+	member.AddGroup(ctx, cty.Gov(), open)
+	member.AddGroup(ctx, cty.Gov(), closed)
+	member.SetGroupOpen(ctx, cty.Gov(), open, true)
+
+	// joining a closed group is refused before sending
+	if must.Try(func() { bureau.JoinGroup(ctx, cty.MemberOwner(0), cty.Gov(), closed) }) == nil {
+		t.Fatalf("joining a closed group should fail")
+	}
+
+	bureau.JoinGroup(ctx, cty.MemberOwner(0), cty.Gov(), open)
+	chg, _ := bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if n := chg.Result.NumOK(); n != 1 {
+		t.Errorf("expecting 1 successful request, got %v", n)
+	}
+	if !member.IsMember(ctx, cty.Gov(), cty.MemberUser(0), open) {
+		t.Errorf("expecting user to be a member of the open group")
+	}
+
+	bureau.LeaveGroup(ctx, cty.MemberOwner(0), cty.Gov(), open)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if member.IsMember(ctx, cty.Gov(), cty.MemberUser(0), open) {
+		t.Errorf("expecting user to have left the open group")
+	}
+}
+
+func TestBureauSetProp(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	etc.SetSettings(ctx, cty.Gov(), etc.Settings{MemberEditableUserProps: []string{"nickname"}})
+
+	if must.Try(func() { bureau.SetProp(ctx, cty.MemberOwner(0), cty.Gov(), "role", "admin") }) == nil {
+		t.Fatalf("setting a non-editable property should fail")
+	}
+
+	bureau.SetProp(ctx, cty.MemberOwner(0), cty.Gov(), "nickname", "zero")
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	if v := member.GetUserProp[string](ctx, cty.Gov(), cty.MemberUser(0), "nickname"); v != "zero" {
+		t.Errorf("expecting zero, got %v", v)
+	}
+}
+
+func TestBureauRotateAddress(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	newID := id.NewTestID(ctx, t, git.MainBranch, true)
+	id.Init(ctx, newID.OwnerAddress())
+
+	bureau.RotateAddress(ctx, cty.MemberOwner(0), cty.Gov(), newID.OwnerAddress())
+	chg, _ := bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if n := chg.Result.NumOK(); n != 1 {
+		t.Fatalf("expecting 1 successful request, got %v", form.SprintJSON(chg.Result))
+	}
+
+	profile := member.GetUser(ctx, cty.Gov(), cty.MemberUser(0))
+	if profile.PublicAddress != newID.PublicAddress() {
+		t.Errorf("expecting %v, got %v", newID.PublicAddress(), profile.PublicAddress)
+	}
+
+	// the user can now make requests from the new address
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+	bureau.Transfer(ctx, newID.OwnerAddress(), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; u1 != 1.0 {
+		t.Errorf("expecting 1, got %v", u1)
+	}
+}
+
+func TestBureauRotateAddressWithoutProof(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	newID := id.NewTestID(ctx, t, git.MainBranch, true)
+	id.Init(ctx, newID.OwnerAddress())
+
+	// sign the proof with the user's current identity, instead of the new one
+	govCloned := gov.Clone(ctx, cty.Gov())
+	userOwner := id.CloneOwner(ctx, cty.MemberOwner(0))
+	rotation := bureau.AddressRotation{User: cty.MemberUser(0), NewAddress: newID.PublicAddress()}
+	signed := id.Sign(ctx, id.GetOwnerCredentials(ctx, userOwner), rotation)
+	request := bureau.Request{RotateAddress: &bureau.RotateAddressRequest{Rotation: signed, Proof: signed}}
+	chg := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), bureau.BureauTopic, request)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	userOwner.Public.Push(ctx)
+
+	expectRotateAddressRejected(t, ctx, cty, bureau.ErrorCodeInvalidSignature)
+}
+
+func TestBureauRotateAddressToTakenIdentity(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// member 0 moves to a new identity, which is registered to member 1
+	newID := id.NewTestID(ctx, t, git.MainBranch, true)
+	id.Init(ctx, newID.OwnerAddress())
+	member.SetUserProfile(ctx, cty.Gov(), cty.MemberUser(1), member.UserProfile{
+		ID:            id.FetchPublicCredentials(ctx, newID.PublicAddress()).ID,
+		PublicAddress: cty.MemberOwner(1).Public,
+	})
+
+	bureau.RotateAddress(ctx, cty.MemberOwner(0), cty.Gov(), newID.OwnerAddress())
+	expectRotateAddressRejected(t, ctx, cty, bureau.ErrorCodeIdentityTaken)
+}
+
+func TestBureauRotateAddressToTakenAddress(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// member 0 moves to a new identity, whose address is registered to member 1
+	newID := id.NewTestID(ctx, t, git.MainBranch, true)
+	id.Init(ctx, newID.OwnerAddress())
+	member.SetUserProfile(ctx, cty.Gov(), cty.MemberUser(1), member.UserProfile{
+		ID:            member.GetUser(ctx, cty.Gov(), cty.MemberUser(1)).ID,
+		PublicAddress: newID.PublicAddress(),
+	})
+
+	bureau.RotateAddress(ctx, cty.MemberOwner(0), cty.Gov(), newID.OwnerAddress())
+	expectRotateAddressRejected(t, ctx, cty, bureau.ErrorCodeAddressTaken)
+}
+
+func expectRotateAddressRejected(t *testing.T, ctx context.Context, cty *test.TestCommunity, code bureau.ErrorCode) {
+	chg, _ := bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if n := len(chg.Result); n != 1 {
+		t.Fatalf("expecting 1 request, got %v", form.SprintJSON(chg.Result))
+	}
+	resp := chg.Result[0].Response
+	if resp.OK() || resp.Error.Code != code {
+		t.Fatalf("expecting %v, got %v", code, form.SprintJSON(resp))
+	}
+}
+
+func TestBureauStatus(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// no requests yet
+	if n := len(bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov())); n != 0 {
+		t.Fatalf("expecting no requests, got %v", n)
+	}
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+
+	status := bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov())
+	if len(status) != 2 || status[0].Answered || status[1].Answered {
+		t.Fatalf("expecting two pending requests, got %v", form.SprintJSON(status))
+	}
+
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	status = bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov())
+	if len(status) != 2 || !status[0].Answered || !status[1].Answered {
+		t.Fatalf("expecting two answered requests, got %v", form.SprintJSON(status))
+	}
+	if !status[0].Response.OK() {
+		t.Errorf("expecting first transfer to succeed, got %v", form.SprintJSON(status[0]))
+	}
+	if status[1].Response.OK() || status[1].Response.Error.Code != bureau.ErrorCodeInsufficientFunds {
+		t.Errorf("expecting second transfer to fail with insufficient funds, got %v", form.SprintJSON(status[1]))
+	}
+}
+
+func TestBureauLegacyResponse(t *testing.T) {
+	// responses written before statuses were recorded echo the request, without a status
+	var resp bureau.Response
+	if err := json.Unmarshal([]byte(`{"transfer":{"from_user":"a","to_user":"b","amount":1}}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != bureau.StatusLegacy || resp.OK() {
+		t.Errorf("expecting legacy response, got %v", form.SprintJSON(resp))
+	}
+
+	if err := json.Unmarshal([]byte(`{"status":"ok"}`), &resp); err != nil || !resp.OK() {
+		t.Errorf("expecting successful response, got %v (%v)", form.SprintJSON(resp), err)
+	}
+}
@@ -0,0 +1,65 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/diff"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	pmp "github.com/gov4git/gov4git/v2/test/motion/pmp_0"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestDiff(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	before := git.Head(ctx, gov.Clone(ctx, cty.Gov()).Repo())
+	pmp.SetupTest(t, ctx, cty)
+	member.AddUser(ctx, cty.Gov(), "newbie", member.UserProfile{})
+
+	d := diff.Compare(ctx, cty.Gov(), string(before), "HEAD")
+	if d.From != before || d.To == before {
+		t.Errorf("unexpected revisions %v to %v", d.From, d.To)
+	}
+	if len(d.UsersAdded) != 1 || d.UsersAdded[0] != "newbie" || len(d.UsersRemoved) != 0 {
+		t.Errorf("expecting newbie to be added, got %v and %v", d.UsersAdded, d.UsersRemoved)
+	}
+	if len(d.Motions) != 2 || d.Motions[0].FromStatus != diff.StatusNone || d.Motions[0].ToStatus != diff.StatusOpen {
+		t.Errorf("expecting two opened motions, got %v", d.Motions)
+	}
+	charged := 0.0
+	for _, b := range d.Ballots {
+		charged += b.ChargeDelta
+		if b.FromStatus != diff.StatusNone || b.ToStatus != diff.StatusOpen {
+			t.Errorf("expecting opened ballot, got %v", b)
+		}
+	}
+	if charged <= 0 {
+		t.Errorf("expecting charges for votes, got %v", charged)
+	}
+	if len(d.Balances) == 0 {
+		t.Errorf("expecting balance changes")
+	}
+	if len(d.Journal) == 0 {
+		t.Errorf("expecting new journal entries")
+	}
+	if txt := d.Text(); !strings.Contains(txt, "+ newbie") {
+		t.Errorf("unexpected text %s", txt)
+	}
+
+	// reversing the revisions reverses the changes
+	r := diff.Compare(ctx, cty.Gov(), "HEAD", string(before))
+	if len(r.UsersRemoved) != 1 || len(r.Motions) != 2 || r.Motions[0].ToStatus != diff.StatusNone || len(r.Journal) != 0 {
+		t.Errorf("unexpected reverse diff %v", r.Text())
+	}
+
+	// a revision compared to itself has no changes
+	if s := diff.Compare(ctx, cty.Gov(), "HEAD", "HEAD"); !s.IsEmpty() {
+		t.Errorf("expecting no changes, got %v", s.Text())
+	}
+}
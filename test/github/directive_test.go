@@ -7,6 +7,7 @@ import (
 	"github.com/google/go-github/v58/github"
 	govgh "github.com/gov4git/gov4git/v2/github"
 	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/runtime"
 	"github.com/gov4git/gov4git/v2/test"
 	"github.com/gov4git/lib4git/base"
@@ -99,3 +100,48 @@ func TestDirective(t *testing.T) {
 
 	// <-(chan int(nil))
 }
+
+func TestDirectiveCapability(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// restrict issuance to the treasurers group, of which the directive author is not a member
+	treasurers := member.Group("treasurers")
+	member.AddGroup(ctx, cty.Gov(), treasurers)
+	member.AddMember(ctx, cty.Gov(), cty.MemberUser(1), treasurers)
+	member.GrantCapability(ctx, cty.Gov(), treasurers, member.CapIssueCredits)
+
+	testDirectiveGetIssues := []any{
+		[]*github.Issue{
+			{
+				ID:     github.Int64(111),
+				Number: github.Int(1),
+				Title:  github.String("Issue directive"),
+				URL:    github.String("https://test/issue/1"),
+				Labels: []*github.Label{{Name: github.String(govgh.DirectiveLabel)}},
+				Locked: github.Bool(false),
+				State:  github.String("open"),
+				Body:   github.String(fmt.Sprintf("issue 20 credits to @%v", cty.MemberUser(0))),
+				User:   &github.User{Login: github.String(testDirectiveOrganizerGithubUser)},
+			},
+		},
+	}
+	mockedHTTPClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatch(mock.GetReposIssuesByOwnerByRepo,
+			testDirectiveGetIssues...),
+		mock.WithRequestMatch(mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			testDirectivePostComments...),
+		mock.WithRequestMatch(mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+			testDirectiveEditIssue...),
+	)
+	ghRepo := govgh.Repo{Owner: "owner1", Name: "repo1"}
+	ghClient := github.NewClient(mockedHTTPClient)
+
+	chg := govgh.ProcessDirectiveIssues(ctx, ghRepo, ghClient, cty.Organizer(), []string{testDirectiveOrganizerGithubUser})
+	if len(chg.Result) != 1 || chg.Result[0].Error == nil {
+		t.Fatalf("expecting refused directive, got %v", form.SprintJSON(chg.Result))
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; q != 0 {
+		t.Errorf("expecting 0, got %v", q)
+	}
+}
@@ -0,0 +1,70 @@
+package gov
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// commits are stamped with the time at which the author is set
+	git.SetAuthor("test", "test@test")
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 3.0), "test")
+	before := git.Head(ctx, gov.Clone(ctx, cty.Gov()).Repo())
+	beforeStamp := git.GetAuthor().When
+	time.Sleep(time.Second + 100*time.Millisecond) // commit times have a resolution of seconds
+	git.SetAuthor("test", "test@test")
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 4.0), "test")
+
+	// reads at a past commit see the past state
+	atCtx := gov.WithSnapshot(ctx, string(before))
+	if got := account.Get(atCtx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; got != 3.0 {
+		t.Errorf("expecting 3, got %v", got)
+	}
+
+	// so do reads at a past time
+	stampCtx := gov.WithSnapshot(ctx, beforeStamp.Format(time.RFC3339))
+	if got := account.Get(stampCtx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; got != 3.0 {
+		t.Errorf("expecting 3, got %v", got)
+	}
+
+	// a time in the future resolves to the latest commit
+	futureCtx := gov.WithSnapshot(ctx, "2999-01-01")
+	if got := account.Get(futureCtx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; got != 7.0 {
+		t.Errorf("expecting 7, got %v", got)
+	}
+
+	// a time before any stamped commit does not resolve
+	err := must.Try(func() { gov.Clone(gov.WithSnapshot(ctx, "2000-01-01"), cty.Gov()) })
+	if !errors.Is(err, gov.ErrSnapshotNotFound) {
+		t.Errorf("expecting snapshot not found, got %v", err)
+	}
+
+	// writes are refused
+	err = must.Try(func() {
+		account.Issue(atCtx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+	})
+	if !errors.Is(err, gov.ErrSnapshotReadOnly) {
+		t.Errorf("expecting read-only snapshot, got %v", err)
+	}
+	err = must.Try(func() { gov.CloneOwner(atCtx, cty.Organizer()) })
+	if !errors.Is(err, gov.ErrSnapshotReadOnly) {
+		t.Errorf("expecting read-only snapshot, got %v", err)
+	}
+
+	// the community is unchanged
+	if got := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; got != 7.0 {
+		t.Errorf("expecting 7, got %v", got)
+	}
+}
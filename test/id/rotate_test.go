@@ -0,0 +1,153 @@
+package id
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestRotate(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 3.0), "test")
+
+	// a request sent before the rotation is signed by the old key
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	id.Rotate(ctx, cty.MemberOwner(0))
+
+	// the community finds the user by their new key
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+
+	chg, rejected := bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if len(rejected) != 0 {
+		t.Fatalf("expecting no rejections, got %v", rejected)
+	}
+	if n := chg.Result.NumOK(); n != 2 {
+		t.Fatalf("expecting 2 processed requests, got %v", chg.Result)
+	}
+	if u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; u1 != 2.0 {
+		t.Errorf("expecting 2, got %v", u1)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "ballot", "ballot", choices, member.Everybody)
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 5.0), "test")
+
+	// a vote is cast with a key which turns out to be compromised
+	since := time.Now()
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[0], 4.0)})
+	id.Revoke(ctx, cty.MemberOwner(0), since)
+
+	_, rejected := ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+	if len(rejected) != 1 {
+		t.Fatalf("expecting the vote to be rejected, got %v", rejected)
+	}
+	if tally := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally; len(tally.AcceptedVotes) != 0 {
+		t.Errorf("expecting no accepted votes, got %v", tally.AcceptedVotes)
+	}
+
+	// votes signed by the new key are accepted
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[0], 4.0)})
+	ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+	if tally := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally; len(tally.AcceptedVotes) != 1 {
+		t.Errorf("expecting one accepted vote, got %v", tally.AcceptedVotes)
+	}
+}
+
+func TestRevokeStrikesTalliedVotes(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "ballot", "ballot", choices, member.Everybody)
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 5.0), "test")
+
+	// a vote is accepted before the key is compromised
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[0], 1.0)})
+	ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+	since := time.Now()
+
+	// a vote is accepted after the key is compromised, but before the community learns of the revocation
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[1], 2.0)})
+	ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+	if tally := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally; len(tally.AcceptedVotes[cty.MemberUser(0)]) != 2 {
+		t.Fatalf("expecting two accepted votes, got %v", tally.AcceptedVotes)
+	}
+
+	// the revocation strikes the second vote at the next tally
+	id.Revoke(ctx, cty.MemberOwner(0), since)
+	ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+
+	tally := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally
+	accepted, rejected := tally.AcceptedVotes[cty.MemberUser(0)], tally.RejectedVotes[cty.MemberUser(0)]
+	if len(accepted) != 1 || accepted[0].Vote.VoteChoice != choices[0] {
+		t.Errorf("expecting the first vote to remain accepted, got %v", accepted)
+	}
+	if len(rejected) != 1 || rejected[0].Vote.VoteChoice != choices[1] {
+		t.Errorf("expecting the second vote to be rejected, got %v", rejected)
+	}
+	if tally.Scores[choices[1]] != 0 {
+		t.Errorf("expecting no score for %v, got %v", choices[1], tally.Scores)
+	}
+	if bal := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; bal != 5.0-tally.Charges[cty.MemberUser(0)] {
+		t.Errorf("expecting the charge of the struck vote to be refunded, got balance %v and charges %v", bal, tally.Charges)
+	}
+
+	// striking is not repeated
+	chg, _ := ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+	if len(chg.Result) != 0 {
+		t.Errorf("expecting no changes, got %v", chg.Result)
+	}
+}
+
+func TestRevokeStrikeErrorsReported(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "ballot", "ballot", choices, member.Everybody)
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 5.0), "test")
+
+	since := time.Now()
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[0], 1.0)})
+	ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+
+	// the community's record of the received votes cannot be read
+	cloned := gov.Clone(ctx, cty.Gov())
+	voterID := id.GetRootID(ctx, git.CloneOne(ctx, git.Address(cty.MemberOwner(0).Public)).Tree())
+	topicNS := mail.ReceiveTopicNS(voterID, ballotproto.BallotTopic(ballotName))
+	git.StringToFileStage(ctx, cloned.Tree(), topicNS.Append("1000.json"), "not json")
+	proto.Commitf(ctx, cloned, "test", "Corrupt received votes")
+	cloned.Push(ctx)
+
+	// the failure to strike the vote signed by the revoked key is reported
+	id.Revoke(ctx, cty.MemberOwner(0), since)
+	chg, _ := ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+	if _, ok := chg.Query["strike_errors"]; !ok {
+		t.Errorf("expecting strike errors to be reported, got %v", chg.Query)
+	}
+}
@@ -0,0 +1,63 @@
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestManageGroupsCapability(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// an owner address, whose private repo does not hold the community's credentials
+	impostor := gov.OwnerAddress{Public: cty.Organizer().Public, Private: cty.MemberOwner(0).Private}
+
+	// the organizer holds the capability from the start, members do not
+	if err := member.CheckOwnerCapability(ctx, cty.Organizer(), cty.MemberOwner(0).Public, member.CapManageGroups); err != nil {
+		t.Errorf("expecting organizer to be permitted, got %v", err)
+	}
+	if err := member.CheckOwnerCapability(ctx, impostor, cty.MemberOwner(0).Public, member.CapManageGroups); !errors.Is(err, member.ErrNotPermitted) {
+		t.Errorf("expecting member to be refused, got %v", err)
+	}
+
+	// granting the capability to a group permits its members
+	admins := member.Group("admins")
+	member.AddGroup(ctx, cty.Gov(), admins)
+	member.AddMember(ctx, cty.Gov(), cty.MemberUser(0), admins)
+	member.GrantCapability(ctx, cty.Gov(), admins, member.CapManageGroups)
+	if err := member.CheckOwnerCapability(ctx, impostor, cty.MemberOwner(0).Public, member.CapManageGroups); err != nil {
+		t.Errorf("expecting admin to be permitted, got %v", err)
+	}
+	if err := member.CheckOwnerCapability(ctx, impostor, cty.MemberOwner(1).Public, member.CapManageGroups); !errors.Is(err, member.ErrNotPermitted) {
+		t.Errorf("expecting non-admin to be refused, got %v", err)
+	}
+
+	// the capability remains restricted after it is revoked from all groups
+	member.RevokeCapability(ctx, cty.Gov(), admins, member.CapManageGroups)
+	if err := member.CheckOwnerCapability(ctx, impostor, cty.MemberOwner(0).Public, member.CapManageGroups); !errors.Is(err, member.ErrNotPermitted) {
+		t.Errorf("expecting member to be refused, got %v", err)
+	}
+}
+
+func TestManageMotionsCapability(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	chairs := member.Group("chairs")
+	member.AddGroup(ctx, cty.Gov(), chairs)
+	member.AddMember(ctx, cty.Gov(), cty.MemberUser(0), chairs)
+	member.GrantCapability(ctx, cty.Gov(), chairs, member.CapManageMotions)
+
+	if err := member.CheckOwnerCapability(ctx, cty.Organizer(), cty.MemberOwner(0).Public, member.CapManageMotions); err != nil {
+		t.Errorf("expecting chair to be permitted, got %v", err)
+	}
+	if err := member.CheckOwnerCapability(ctx, cty.Organizer(), cty.MemberOwner(1).Public, member.CapManageMotions); !errors.Is(err, member.ErrNotPermitted) {
+		t.Errorf("expecting non-chair to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package member
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/invite"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestInviteRedeem(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 1)
+
+	invitee := id.NewTestID(ctx, t, git.MainBranch, true)
+	id.Init(ctx, invitee.OwnerAddress())
+
+	team := member.Group("team")
+	member.AddGroup(ctx, cty.Gov(), team)
+
+	code := invite.Mint(ctx, cty.Gov(), invitee.PublicAddress(), "", []member.Group{team}, 5.0, time.Hour)
+
+	// the code is for a specific identity
+	if must.Try(func() { invite.Redeem(ctx, cty.MemberOwner(0), cty.Gov(), code, "imposter") }) == nil {
+		t.Fatalf("redeeming from another identity should fail")
+	}
+
+	invite.Redeem(ctx, invitee.OwnerAddress(), cty.Gov(), code, "newbie")
+	chg, _ := invite.Process(ctx, cty.Organizer())
+	if len(chg.Result) != 1 || chg.Result[0].Error != "" {
+		t.Fatalf("expecting one successful redemption, got %v", chg.Result)
+	}
+
+	newbie := member.User("newbie")
+	if !member.IsMember(ctx, cty.Gov(), newbie, team) {
+		t.Errorf("expecting newbie in team")
+	}
+	if q := account.Get(ctx, cty.Gov(), member.UserAccountID(newbie)).Balance(account.PluralAsset).Quantity; q != 5.0 {
+		t.Errorf("expecting 5, got %v", q)
+	}
+
+	// invitations are single-use
+	if must.Try(func() { invite.Redeem(ctx, invitee.OwnerAddress(), cty.Gov(), code, "newbie2") }) == nil {
+		t.Errorf("redeeming twice should fail")
+	}
+}
+
+func TestInviteExpired(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 1)
+
+	invitee := id.NewTestID(ctx, t, git.MainBranch, true)
+	id.Init(ctx, invitee.OwnerAddress())
+
+	code := invite.Mint(ctx, cty.Gov(), invitee.PublicAddress(), "newbie", nil, 0, time.Second)
+	invite.Redeem(ctx, invitee.OwnerAddress(), cty.Gov(), code, "")
+	time.Sleep(time.Second)
+	invite.Process(ctx, cty.Organizer())
+
+	if member.IsUser_Local(ctx, gov.Clone(ctx, cty.Gov()), "newbie") {
+		t.Errorf("expired invitation should not be redeemed")
+	}
+}
+
+func TestInviteRevoke(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 1)
+
+	invitee := id.NewTestID(ctx, t, git.MainBranch, true)
+	id.Init(ctx, invitee.OwnerAddress())
+
+	code := invite.Mint(ctx, cty.Gov(), invitee.PublicAddress(), "newbie", nil, 0, time.Hour)
+	invite.Revoke(ctx, cty.Gov(), invite.HashCode(code))
+
+	// the revocation is committed to the community repo
+	if invs := invite.List(ctx, cty.Gov()); len(invs) != 0 {
+		t.Fatalf("expecting no invitations, got %v", invs)
+	}
+	repo := gov.Clone(ctx, cty.Gov()).Repo()
+	head, err := repo.Head()
+	must.NoError(ctx, err)
+	commit, err := repo.CommitObject(head.Hash())
+	must.NoError(ctx, err)
+	if !strings.HasPrefix(commit.Message, "Revoke invitation") {
+		t.Errorf("expecting revocation commit, got %q", commit.Message)
+	}
+
+	// the revoked invitation cannot be redeemed
+	if must.Try(func() { invite.Redeem(ctx, invitee.OwnerAddress(), cty.Gov(), code, "") }) == nil {
+		t.Errorf("redeeming a revoked invitation should fail")
+	}
+	invite.Process(ctx, cty.Organizer())
+	if member.IsUser_Local(ctx, gov.Clone(ctx, cty.Gov()), "newbie") {
+		t.Errorf("revoked invitation should not be redeemed")
+	}
+}
+
+func TestInviteMintUnderMultisig(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	policy := &etc.MultisigPolicy{
+		Signers:   []string{string(cty.MemberUser(0)), string(cty.MemberUser(1))},
+		Threshold: 2,
+		Ops:       []string{etc.OpAccountIssue},
+	}
+	etc.SetSettings(ctx, cty.Gov(), etc.Settings{Multisig: policy})
+
+	invitee := id.NewTestID(ctx, t, git.MainBranch, true)
+
+	// invitations with starting credits would issue credits without approval
+	err := must.Try(func() { invite.Mint(ctx, cty.Gov(), invitee.PublicAddress(), "newbie", nil, 5.0, time.Hour) })
+	if !errors.Is(err, etc.ErrMultisigRequired) {
+		t.Fatalf("expecting multisig required, got %v", err)
+	}
+
+	// invitations without credits are unaffected
+	invite.Mint(ctx, cty.Gov(), invitee.PublicAddress(), "newbie", nil, 0, time.Hour)
+}
@@ -0,0 +1,96 @@
+package member
+
+import (
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/zero"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/proto/offboard"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestOffboard(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 3)
+
+	// members 0 and 1 author a motion each
+	for i, id := range []motionproto.MotionID{"1", "2"} {
+		motionapi.OpenMotion(ctx, cty.Organizer(), id, motionproto.MotionConcernType, zero.ZeroPolicyName, cty.MemberUser(i), "concern", "description", "https://"+string(id), nil)
+	}
+
+	// member 1 votes on an open ballot
+	for i := 0; i < 2; i++ {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(i), account.H(account.PluralAsset, 10.0), "test")
+	}
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "ballot_id", "ballot description", choices, member.Everybody)
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), ballotName, ballotproto.Elections{ballotproto.NewElection(choices[0], 2.0)})
+	tally := ballotapi.Tally(ctx, cty.Organizer(), ballotName, 2).Result
+	charge := tally.Charges[cty.MemberUser(1)]
+	if charge <= 0 {
+		t.Fatalf("expecting a positive charge, got %v", charge)
+	}
+
+	// offboard member 0, reassigning their motion to member 2 and keeping the balance
+	report0 := offboard.Offboard(ctx, cty.Organizer(), cty.MemberUser(0), offboard.Options{ReassignMotionsTo: cty.MemberUser(2)})
+	if len(report0.ReassignedMotions) != 1 || report0.ReassignedMotions[0] != "1" {
+		t.Errorf("expecting reassigned motion 1, got %v", report0.ReassignedMotions)
+	}
+	if m := motionapi.ShowMotion(ctx, cty.Gov(), "1").Motion; m.Author != cty.MemberUser(2) || m.Closed {
+		t.Errorf("expecting open motion authored by %v, got %v", cty.MemberUser(2), m)
+	}
+	if member.IsUser_Local(ctx, gov.Clone(ctx, cty.Gov()), cty.MemberUser(0)) {
+		t.Errorf("expecting user to be removed")
+	}
+
+	// offboard member 1, settling everything and returning the balance to the issuer
+	settings := etc.GetSettings(ctx, cty.Gov())
+	settings.OffboardSweep = etc.SweepIssuer
+	etc.SetSettings(ctx, cty.Gov(), settings)
+
+	report1 := offboard.Offboard(
+		ctx,
+		cty.Organizer(),
+		cty.MemberUser(1),
+		offboard.Options{WithdrawVotes: true, CancelMotions: true, SweepBalance: true},
+	)
+	if len(report1.CancelledMotions) != 1 || report1.CancelledMotions[0] != "2" {
+		t.Errorf("expecting cancelled motion 2, got %v", report1.CancelledMotions)
+	}
+	if !motionapi.ShowMotion(ctx, cty.Gov(), "2").Motion.Cancelled {
+		t.Errorf("expecting motion 2 to be cancelled")
+	}
+	if refund := report1.WithdrawnVotes[ballotName]; refund.Quantity != charge {
+		t.Errorf("expecting refund of %v, got %v", charge, refund)
+	}
+	if report1.Swept.Quantity != 10.0 || report1.SweptTo != account.IssueAccountID {
+		t.Errorf("expecting 10 swept to the issuer, got %v to %v", report1.Swept, report1.SweptTo)
+	}
+	if b := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset); b.Quantity != 0 {
+		t.Errorf("expecting empty balance, got %v", b)
+	}
+
+	// the withdrawn votes no longer count
+	show := ballotapi.Show(ctx, cty.Gov(), ballotName)
+	if show.Tally.Scores[choices[0]] != 0 {
+		t.Errorf("expecting zero score, got %v", show.Tally.Scores[choices[0]])
+	}
+	if len(show.Tally.RejectedVotes[cty.MemberUser(1)]) != 1 {
+		t.Errorf("expecting withdrawn vote to be recorded, got %v", show.Tally.RejectedVotes)
+	}
+	if b := account.Get(ctx, cty.Gov(), ballotproto.BallotEscrowAccountID(ballotName)).Balance(account.PluralAsset); b.Quantity != 0 {
+		t.Errorf("expecting empty escrow, got %v", b)
+	}
+}
@@ -0,0 +1,58 @@
+package member
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestProfileSchema(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 1)
+
+	schema := etc.ProfileSchema{
+		{Key: "display_name", Type: etc.PropString, Visibility: etc.PropPublic, MemberEditable: true},
+		{Key: "karma", Type: etc.PropNumber, Visibility: etc.PropOrganizer},
+	}
+	etc.SetSettings(ctx, cty.Gov(), etc.Settings{ProfileSchema: schema})
+	user := cty.MemberUser(0)
+
+	// undeclared properties and mistyped values are refused
+	if err := must.Try(func() { member.SetUserProp(ctx, cty.Gov(), user, "nickname", "zero") }); !errors.Is(err, etc.ErrUnknownUserProp) {
+		t.Fatalf("expecting unknown property, got %v", err)
+	}
+	if err := must.Try(func() { member.SetUserProp(ctx, cty.Gov(), user, "karma", "lots") }); !errors.Is(err, etc.ErrUserPropType) {
+		t.Fatalf("expecting type mismatch, got %v", err)
+	}
+	member.SetUserProp(ctx, cty.Gov(), user, "karma", 7)
+
+	// members edit properties declared member-editable
+	if err := must.Try(func() { bureau.SetProp(ctx, cty.MemberOwner(0), cty.Gov(), "karma", 100) }); !errors.Is(err, bureau.ErrPropNotEditable) {
+		t.Fatalf("expecting not editable, got %v", err)
+	}
+	bureau.SetProp(ctx, cty.MemberOwner(0), cty.Gov(), "display_name", "Zero")
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	// organizer-only properties are shown only on request
+	public := member.ShowUser(ctx, cty.Gov(), user, false)
+	if len(public.Props) != 1 || public.Props[0].Key != "display_name" || public.Props[0].Value != "Zero" {
+		t.Errorf("unexpected public profile %v", public.Props)
+	}
+	all := member.ShowUser(ctx, cty.Gov(), user, true)
+	if len(all.Props) != 2 || all.Props[1].Type != etc.PropNumber || all.Props[1].Value != 7.0 {
+		t.Errorf("unexpected full profile %v", all.Props)
+	}
+
+	// invalid schemas are refused
+	bad := etc.ProfileSchema{{Key: "x", Type: "date", Visibility: etc.PropPublic}}
+	if err := must.Try(func() { etc.SetSettings(ctx, cty.Gov(), etc.Settings{ProfileSchema: bad}) }); !errors.Is(err, etc.ErrInvalidProfileSchema) {
+		t.Errorf("expecting invalid schema, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/metrics"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	pmp "github.com/gov4git/gov4git/v2/test/motion/pmp_0"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestMemberReport(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	pmp.SetupTest(t, ctx, cty)
+
+	// accept the proposal, which member 0 voted for and member 1 voted against
+	for _, m := range motionapi.ListMotions(ctx, cty.Gov()) {
+		if m.IsProposal() {
+			motionapi.CloseMotion(ctx, cty.Organizer(), m.ID, motionproto.Accept)
+		}
+	}
+
+	r0 := metrics.AssembleMemberReport(ctx, cty.Gov(), cty.MemberUser(0))
+	if r0.NumVotes[metric.VotePurposeConcern] != 1 || r0.NumVotes[metric.VotePurposeProposal] != 1 {
+		t.Errorf("expecting one concern and one proposal vote, got %v", r0.NumVotes)
+	}
+	if r0.Spent[metric.VotePurposeConcern] <= 0 {
+		t.Errorf("expecting credits spent on concern votes, got %v", r0.Spent)
+	}
+	if r0.NumReviews != 1 || r0.NumAlignedReviews != 1 || r0.AlignmentRate != 1 {
+		t.Errorf("expecting one aligned review, got %v of %v", r0.NumAlignedReviews, r0.NumReviews)
+	}
+
+	r1 := metrics.AssembleMemberReport(ctx, cty.Gov(), cty.MemberUser(1))
+	if r1.NumAuthored != 1 || r1.NumAccepted != 1 {
+		t.Errorf("expecting one authored and accepted motion, got %v and %v", r1.NumAuthored, r1.NumAccepted)
+	}
+	if r1.NumReviews != 1 || r1.NumAlignedReviews != 0 || r1.AlignmentRate != 0 {
+		t.Errorf("expecting one unaligned review, got %v of %v", r1.NumAlignedReviews, r1.NumReviews)
+	}
+
+	for _, r := range []*metrics.MemberReport{r0, r1} {
+		h := r.BalanceHistory
+		if len(h) == 0 || h[len(h)-1].Balance != r.Balance {
+			t.Errorf("expecting balance history to end at the current balance %v, got %v", r.Balance, h)
+		}
+		if r.ReportMD == "" {
+			t.Errorf("expecting a markdown report")
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/metrics/prom"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_0"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	pmp "github.com/gov4git/gov4git/v2/test/motion/pmp_0"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestPrometheusEndpoint(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	pmp.SetupTest(t, ctx, cty)
+
+	srv := prom.NewServer(cty.Gov())
+	if err := srv.Refresh(ctx); err != nil {
+		t.Fatalf("refresh (%v)", err)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest("GET", prom.MetricsPath, nil))
+	if got := rec.Header().Get("Content-Type"); got != prom.ContentType {
+		t.Errorf("expecting content type %q, got %q", prom.ContentType, got)
+	}
+	body := rec.Body.String()
+
+	expected := []string{
+		"# TYPE gov4git_motions_open gauge\n",
+		`gov4git_motions_open{policy="` + string(pmp_0.ConcernPolicyName) + `"} 1` + "\n",
+		`gov4git_motions_open{policy="` + string(pmp_0.ProposalPolicyName) + `"} 1` + "\n",
+		"# TYPE gov4git_votes_total counter\n",
+		`gov4git_votes_total{purpose="concern"} 2` + "\n",
+		`gov4git_votes_today{purpose="concern"} `,
+		`gov4git_member_credits{user="` + string(cty.MemberUser(0)) + `"} `,
+		`gov4git_treasury_credits{account="issue"} `,
+		"gov4git_matching_pool_credits ",
+		"gov4git_refreshes_total 1\n",
+		"gov4git_refresh_errors_total 0\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(body, e) {
+			t.Errorf("expecting %q in\n%v", e, body)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/metrics"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	pmp "github.com/gov4git/gov4git/v2/test/motion/pmp_0"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/ns"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestSite(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	pmp.SetupTest(t, ctx, cty)
+
+	site := metrics.AssembleSite(ctx, cty.Gov(), metrics.TimeDailyLowerBound, metrics.Today().AddDate(0, 0, 1))
+	for _, path := range []string{"index.html", "motions.html", "style.css", "daily_votes.png", "members/" + string(cty.MemberUser(0)) + ".html"} {
+		if len(site.Files[path]) == 0 {
+			t.Errorf("expecting site file %v", path)
+		}
+	}
+	if page := string(site.Files["motions.html"]); !strings.Contains(page, "concern #1") || !strings.Contains(page, "proposal #2") {
+		t.Errorf("expecting open motions in the leaderboards, got %v", page)
+	}
+	if page := string(site.Files["index.html"]); !strings.Contains(page, `href="members/`+string(cty.MemberUser(1))+`.html"`) {
+		t.Errorf("expecting links to member pages, got %v", page)
+	}
+
+	// write to a directory
+	dir := t.TempDir()
+	site.WriteDir(ctx, dir)
+	if _, err := os.Stat(filepath.Join(dir, "members", string(cty.MemberUser(1))+".html")); err != nil {
+		t.Errorf("expecting member page on disk (%v)", err)
+	}
+
+	// publish to a branch
+	addr := git.Address(cty.Gov())
+	addr.Branch = addr.Branch + ".site"
+	metrics.PublishSite(ctx, addr, site)
+	published := git.CloneOne(ctx, addr)
+	if got := git.FileToString(ctx, published.Tree(), ns.ParseFromGitPath("index.html")); got != string(site.Files["index.html"]) {
+		t.Errorf("published index differs")
+	}
+
+	// republishing removes files which are no longer part of the site
+	delete(site.Files, "motions.html")
+	metrics.PublishSite(ctx, addr, site)
+	published = git.CloneOne(ctx, addr)
+	if _, err := git.TreeStat(ctx, published.Tree(), ns.ParseFromGitPath("motions.html")); err == nil {
+		t.Errorf("expecting motions page to be removed")
+	}
+
+	// republishing an unchanged site makes no commit
+	head := git.Head(ctx, published.Repo())
+	metrics.PublishSite(ctx, addr, site)
+	if h := git.Head(ctx, git.CloneOne(ctx, addr).Repo()); h != head {
+		t.Errorf("expecting no new commit, got %v after %v", h, head)
+	}
+}
@@ -0,0 +1,173 @@
+package multisig
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/etc"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/multisig"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestMultisigIssue(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 3)
+
+	policy := &etc.MultisigPolicy{
+		Signers:   []string{string(cty.MemberUser(0)), string(cty.MemberUser(1))},
+		Threshold: 2,
+		Ops:       []string{etc.OpAccountIssue},
+	}
+	etc.SetSettings(ctx, cty.Gov(), etc.Settings{Multisig: policy})
+
+	// unilateral issuance is refused
+	err := must.Try(func() {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(2), account.H(account.PluralAsset, 10), "test")
+	})
+	if !errors.Is(err, etc.ErrMultisigRequired) {
+		t.Fatalf("expecting multisig required, got %v", err)
+	}
+
+	// the policy cannot be changed unilaterally
+	err = must.Try(func() { etc.SetSettings(ctx, cty.Gov(), etc.Settings{}) })
+	if !errors.Is(err, etc.ErrMultisigRequired) {
+		t.Fatalf("expecting multisig required, got %v", err)
+	}
+
+	// nor by callers of the stage-only layer
+	err = must.Try(func() {
+		account.Issue_StageOnly(ctx, gov.Clone(ctx, cty.Gov()), cty.MemberAccountID(2), account.H(account.PluralAsset, 10), "test")
+	})
+	if !errors.Is(err, etc.ErrMultisigRequired) {
+		t.Fatalf("expecting multisig required, got %v", err)
+	}
+	err = must.Try(func() { etc.SetSettings_StageOnly(ctx, gov.Clone(ctx, cty.Gov()), etc.Settings{}) })
+	if !errors.Is(err, etc.ErrMultisigRequired) {
+		t.Fatalf("expecting multisig required, got %v", err)
+	}
+
+	action := multisig.Action{
+		Issue: &multisig.IssueAction{To: cty.MemberAccountID(2), Amount: account.H(account.PluralAsset, 10), Note: "test"},
+	}
+	p := multisig.Propose(ctx, cty.Gov(), etc.OpAccountIssue, action, "test").Result
+
+	// non-signers cannot approve
+	if err := must.Try(func() { multisig.Approve(ctx, cty.MemberOwner(2), cty.Gov(), p.ID) }); !errors.Is(err, multisig.ErrNotSigner) {
+		t.Fatalf("expecting not signer, got %v", err)
+	}
+
+	// one approval is below the threshold
+	multisig.Approve(ctx, cty.MemberOwner(0), cty.Gov(), p.ID)
+	multisig.Approve(ctx, cty.MemberOwner(0), cty.Gov(), p.ID) // repeated approvals count once
+	chg, _ := multisig.Process(ctx, cty.Organizer())
+	if len(chg.Result.Approvals) != 2 || len(chg.Result.Applied) != 0 {
+		t.Fatalf("expecting two approvals and no applied proposals, got %v", chg.Result)
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset).Quantity; q != 0 {
+		t.Fatalf("expecting 0, got %v", q)
+	}
+
+	// the second signer reaches the threshold
+	multisig.Approve(ctx, cty.MemberOwner(1), cty.Gov(), p.ID)
+	chg, _ = multisig.Process(ctx, cty.Organizer())
+	if len(chg.Result.Applied) != 1 || chg.Result.Applied[0] != p.ID {
+		t.Fatalf("expecting proposal to be applied, got %v", chg.Result)
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset).Quantity; q != 10 {
+		t.Errorf("expecting 10, got %v", q)
+	}
+
+	applied := multisig.Get(ctx, cty.Gov(), p.ID)
+	if !applied.Applied || len(applied.Signatures) != 2 {
+		t.Errorf("expecting applied proposal with two signatures, got %v", applied)
+	}
+
+	// the signatures are recorded with the trace entry
+	found := false
+	for _, e := range trace.List_Local(ctx, gov.Clone(ctx, cty.Gov())) {
+		if a, ok := e.Payload.Payload.(*multisig.ApplyTrace); ok && a.ID == p.ID {
+			found = len(a.Signatures) == 2
+		}
+	}
+	if !found {
+		t.Errorf("expecting trace entry with two signatures")
+	}
+
+	// applied proposals cannot be approved again
+	if err := must.Try(func() { multisig.Approve(ctx, cty.MemberOwner(0), cty.Gov(), p.ID) }); !errors.Is(err, multisig.ErrProposalClosed) {
+		t.Errorf("expecting proposal closed, got %v", err)
+	}
+}
+
+func TestMultisigSetPolicy(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	policy := &etc.MultisigPolicy{
+		Signers:   []string{string(cty.MemberUser(0)), string(cty.MemberUser(1))},
+		Threshold: 1,
+		Ops:       []string{etc.OpMemberRemoveUser},
+	}
+	etc.SetSettings(ctx, cty.Gov(), etc.Settings{Multisig: policy})
+
+	err := must.Try(func() { member.RemoveUser(ctx, cty.Gov(), cty.MemberUser(1)) })
+	if !errors.Is(err, etc.ErrMultisigRequired) {
+		t.Fatalf("expecting multisig required, got %v", err)
+	}
+
+	// disable the policy by approved proposal
+	p := multisig.Propose(ctx, cty.Gov(), multisig.OpSetPolicy, multisig.Action{SetPolicy: &etc.MultisigPolicy{}}, "disable").Result
+	multisig.Approve(ctx, cty.MemberOwner(1), cty.Gov(), p.ID)
+	multisig.Process(ctx, cty.Organizer())
+
+	if etc.GetSettings(ctx, cty.Gov()).Multisig.IsActive() {
+		t.Fatalf("expecting policy to be disabled")
+	}
+	member.RemoveUser(ctx, cty.Gov(), cty.MemberUser(1))
+}
+
+func TestMultisigTamperedProposal(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 3)
+
+	policy := &etc.MultisigPolicy{
+		Signers:   []string{string(cty.MemberUser(0)), string(cty.MemberUser(1))},
+		Threshold: 2,
+		Ops:       []string{etc.OpAccountIssue},
+	}
+	etc.SetSettings(ctx, cty.Gov(), etc.Settings{Multisig: policy})
+
+	action := multisig.Action{
+		Issue: &multisig.IssueAction{To: cty.MemberAccountID(2), Amount: account.H(account.PluralAsset, 10), Note: "test"},
+	}
+	p := multisig.Propose(ctx, cty.Gov(), etc.OpAccountIssue, action, "test").Result
+	multisig.Approve(ctx, cty.MemberOwner(0), cty.Gov(), p.ID)
+	multisig.Process(ctx, cty.Organizer())
+
+	// lower the recorded threshold and pass off the first signature as the second signer's
+	cloned := gov.Clone(ctx, cty.Gov())
+	p = multisig.Get_Local(ctx, cloned, p.ID)
+	p.Threshold = 1
+	p.Signatures = append(p.Signatures, multisig.Signature{Signer: cty.MemberUser(1), Approval: p.Signatures[0].Approval})
+	kv.KV[multisig.ProposalID, multisig.Proposal]{}.Set(ctx, proto.RootNS.Append("multisig", "proposals"), cloned.Tree(), p.ID, p)
+	proto.Commitf(ctx, cloned, "tamper", "Tamper with proposal %v", p.ID)
+	cloned.Push(ctx)
+
+	// the proposal is not applied, since its signatures do not meet the policy
+	chg, _ := multisig.Process(ctx, cty.Organizer())
+	if len(chg.Result.Applied) != 0 {
+		t.Fatalf("expecting no applied proposals, got %v", chg.Result)
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset).Quantity; q != 0 {
+		t.Errorf("expecting 0, got %v", q)
+	}
+}
@@ -0,0 +1,49 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/sync"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestCompactMail(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	for i := 0; i < 3; i++ {
+		bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	}
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	// compact both sides, keeping the latest message
+	memberChg := mail.Compact(ctx, cty.MemberOwner(0), id.PublicAddress(cty.Gov()), 1)
+	if len(memberChg.Result) != 1 || memberChg.Result[0].Removed != 2 {
+		t.Fatalf("unexpected member compaction %v", form.SprintJSON(memberChg.Result))
+	}
+	govChg := sync.CompactMail(ctx, cty.Organizer(), 1)
+	if c := govChg.Result[cty.MemberUser(0)]; len(c) != 1 || c[0].Removed != 2 {
+		t.Fatalf("unexpected community compaction %v", form.SprintJSON(govChg.Result))
+	}
+
+	// requests continue to be processed after compaction
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; q != 4.0 {
+		t.Errorf("expecting 4, got %v", q)
+	}
+
+	status := bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov())
+	if len(status) != 2 || !status[0].Answered || !status[1].Answered {
+		t.Errorf("expecting two answered requests, got %v", form.SprintJSON(status))
+	}
+}
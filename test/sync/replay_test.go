@@ -0,0 +1,49 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/sync"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestReplay(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	for i := 0; i < 3; i++ {
+		bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	}
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	// the community's mailboxes are listed with the number of unreceived messages
+	found := false
+	for _, box := range sync.ListMail(ctx, cty.Gov()) {
+		if box.Kind == mail.ReceivedBox && box.Topic == bureau.BureauTopic && box.Next == 3 {
+			found = box.Unreceived != nil && *box.Unreceived == 0
+		}
+	}
+	if !found {
+		t.Fatalf("expecting received bureau mailbox with no unreceived messages")
+	}
+
+	// replay the last two transfers
+	report := sync.Replay(ctx, cty.Organizer(), cty.MemberUser(0), bureau.BureauTopic, 1, 3)
+	results, ok := report.Result.(bureau.Results)
+	if !ok || len(results) != 2 || results[0].SeqNo != 1 {
+		t.Fatalf("unexpected replay %v", form.SprintJSON(report))
+	}
+
+	// the replay is not committed
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; q != 3.0 {
+		t.Errorf("expecting 3, got %v", q)
+	}
+}
@@ -48,6 +48,9 @@ func TestSync(t *testing.T) {
 	// tally
 	syncChg := sync.Sync(ctx, cty.Organizer(), 2)
 	fmt.Println("sync: ", form.SprintJSON(syncChg))
+	if n := syncChg.Result["rejected_messages"]; n != 0 {
+		t.Errorf("expecting no rejected messages, got %v", n)
+	}
 
 	// verify tallies are correct
 	ast0 := ballotapi.Show(ctx, cty.Gov(), ballotName0)
@@ -0,0 +1,93 @@
+package trace
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	_ "github.com/gov4git/gov4git/v2/proto/account"
+	_ "github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	_ "github.com/gov4git/gov4git/v2/proto/invite"
+	_ "github.com/gov4git/gov4git/v2/proto/member"
+	_ "github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	_ "github.com/gov4git/gov4git/v2/proto/multisig"
+	_ "github.com/gov4git/gov4git/v2/proto/offboard"
+	_ "github.com/gov4git/gov4git/v2/proto/reconcile"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+// TestPayloadsRegistered verifies that every payload type in the source tree is registered,
+// by finding the operations returned by TraceOp methods.
+func TestPayloadsRegistered(t *testing.T) {
+	fset := token.NewFileSet()
+	ops := map[string]string{} // op -> file
+	err := filepath.WalkDir("../../proto", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != "TraceOp" || len(fn.Body.List) != 1 {
+				continue
+			}
+			ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+			if lit, ok := ret.Results[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				op, _ := strconv.Unquote(lit.Value)
+				ops[op] = path
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) == 0 {
+		t.Fatalf("expecting payload types")
+	}
+	for op, path := range ops {
+		if _, ok := trace.LookupSchema(op); !ok {
+			t.Errorf("payload of operation %v (%v) is not registered, or its package is not imported by this test", op, path)
+		}
+	}
+}
+
+type testUnregistered struct {
+	X int `json:"x"`
+}
+
+func (*testUnregistered) TraceOp() string { return "test_unregistered" }
+
+func TestLogUnregistered(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 1)
+
+	// logging an unregistered payload does not fail the operation
+	cloned := gov.Clone(ctx, cty.Gov())
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{Payload: &testUnregistered{X: 1}})
+
+	found := false
+	for _, e := range trace.List_Local(ctx, cloned) {
+		if u, ok := e.Payload.Payload.(*trace.Unknown); ok && u.Op == "test_unregistered" {
+			found = string(u.Data) == `{"x":1}`
+		}
+	}
+	if !found {
+		t.Errorf("expecting the payload to be preserved as unknown")
+	}
+}